@@ -0,0 +1,255 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+// Aggregation 聚合 DSL 中的聚合定义
+type Aggregation interface {
+	// Source 返回聚合定义的 JSON 结构
+	Source() map[string]interface{}
+}
+
+// AggregationsBody 将命名的聚合转换为请求体中 aggregations 的结构
+func AggregationsBody(aggs map[string]Aggregation) map[string]interface{} {
+	body := make(map[string]interface{}, len(aggs))
+	for name, agg := range aggs {
+		body[name] = agg.Source()
+	}
+	return body
+}
+
+// RawAggregation 将已构建的聚合结构（如 GeohashGridAggregation 的返回值）作为 Aggregation 使用
+type RawAggregation map[string]interface{}
+
+// Source 返回原始聚合结构
+func (a RawAggregation) Source() map[string]interface{} {
+	return a
+}
+
+// subAggregations 分桶聚合的子聚合
+type subAggregations map[string]Aggregation
+
+// with 返回附加子聚合后的聚合结构
+func (s subAggregations) with(body map[string]interface{}) map[string]interface{} {
+	if len(s) > 0 {
+		body["aggregations"] = AggregationsBody(s)
+	}
+	return body
+}
+
+// TermsAggregation terms 分桶聚合
+type TermsAggregation struct {
+	subs        subAggregations
+	field       string
+	size        int
+	minDocCount *int
+	order       map[string]interface{}
+}
+
+// TermsAgg 创建 terms 聚合，按字段值分桶
+func TermsAgg(field string) *TermsAggregation {
+	return &TermsAggregation{field: field}
+}
+
+// Size 设置返回的分桶数，默认 10
+func (a *TermsAggregation) Size(size int) *TermsAggregation {
+	a.size = size
+	return a
+}
+
+// MinDocCount 设置分桶的最少文档数
+func (a *TermsAggregation) MinDocCount(count int) *TermsAggregation {
+	a.minDocCount = &count
+	return a
+}
+
+// Order 设置分桶排序（如 Order("_count", "desc")、Order("_key", "asc") 或按子聚合名称排序）
+func (a *TermsAggregation) Order(key string, direction string) *TermsAggregation {
+	a.order = map[string]interface{}{key: direction}
+	return a
+}
+
+// SubAggregation 添加在每个分桶内计算的子聚合
+func (a *TermsAggregation) SubAggregation(name string, agg Aggregation) *TermsAggregation {
+	if a.subs == nil {
+		a.subs = subAggregations{}
+	}
+	a.subs[name] = agg
+	return a
+}
+
+// Source 返回 terms 聚合的 JSON 结构
+func (a *TermsAggregation) Source() map[string]interface{} {
+	params := map[string]interface{}{"field": a.field}
+	if a.size > 0 {
+		params["size"] = a.size
+	}
+	if a.minDocCount != nil {
+		params["min_doc_count"] = *a.minDocCount
+	}
+	if a.order != nil {
+		params["order"] = a.order
+	}
+	return a.subs.with(map[string]interface{}{"terms": params})
+}
+
+// DateHistogramAggregation date_histogram 分桶聚合
+type DateHistogramAggregation struct {
+	subs        subAggregations
+	field       string
+	interval    string
+	format      string
+	timeZone    string
+	minDocCount *int
+}
+
+// DateHistogramAgg 创建按时间间隔分桶的聚合，interval 为日历间隔（如 hour、1d、month）或固定间隔（如 5m、12h）
+func DateHistogramAgg(field string, interval string) *DateHistogramAggregation {
+	return &DateHistogramAggregation{field: field, interval: interval}
+}
+
+// Format 设置分桶 key_as_string 的日期格式
+func (a *DateHistogramAggregation) Format(format string) *DateHistogramAggregation {
+	a.format = format
+	return a
+}
+
+// TimeZone 设置分桶使用的时区（如 "+08:00"、"Asia/Shanghai"）
+func (a *DateHistogramAggregation) TimeZone(timeZone string) *DateHistogramAggregation {
+	a.timeZone = timeZone
+	return a
+}
+
+// MinDocCount 设置分桶的最少文档数，设为 0 时返回中间没有数据的分桶
+func (a *DateHistogramAggregation) MinDocCount(count int) *DateHistogramAggregation {
+	a.minDocCount = &count
+	return a
+}
+
+// SubAggregation 添加在每个分桶内计算的子聚合
+func (a *DateHistogramAggregation) SubAggregation(name string, agg Aggregation) *DateHistogramAggregation {
+	if a.subs == nil {
+		a.subs = subAggregations{}
+	}
+	a.subs[name] = agg
+	return a
+}
+
+// Source 返回 date_histogram 聚合的 JSON 结构
+func (a *DateHistogramAggregation) Source() map[string]interface{} {
+	params := map[string]interface{}{"field": a.field}
+	if calendarIntervals[a.interval] {
+		params["calendar_interval"] = a.interval
+	} else {
+		params["fixed_interval"] = a.interval
+	}
+	if a.format != "" {
+		params["format"] = a.format
+	}
+	if a.timeZone != "" {
+		params["time_zone"] = a.timeZone
+	}
+	if a.minDocCount != nil {
+		params["min_doc_count"] = *a.minDocCount
+	}
+	return a.subs.with(map[string]interface{}{"date_histogram": params})
+}
+
+// MetricAggregation 单值指标聚合
+type MetricAggregation struct {
+	kind  string
+	field string
+}
+
+// AvgAgg 创建字段平均值聚合
+func AvgAgg(field string) *MetricAggregation {
+	return &MetricAggregation{kind: "avg", field: field}
+}
+
+// SumAgg 创建字段求和聚合
+func SumAgg(field string) *MetricAggregation {
+	return &MetricAggregation{kind: "sum", field: field}
+}
+
+// MinAgg 创建字段最小值聚合
+func MinAgg(field string) *MetricAggregation {
+	return &MetricAggregation{kind: "min", field: field}
+}
+
+// MaxAgg 创建字段最大值聚合
+func MaxAgg(field string) *MetricAggregation {
+	return &MetricAggregation{kind: "max", field: field}
+}
+
+// CardinalityAgg 创建字段不同值数量聚合（近似值）
+func CardinalityAgg(field string) *MetricAggregation {
+	return &MetricAggregation{kind: "cardinality", field: field}
+}
+
+// Source 返回指标聚合的 JSON 结构
+func (a *MetricAggregation) Source() map[string]interface{} {
+	return map[string]interface{}{a.kind: map[string]interface{}{"field": a.field}}
+}
+
+// NestedAggregation nested 单桶聚合，在嵌套对象上计算子聚合
+type NestedAggregation struct {
+	subs subAggregations
+	path string
+}
+
+// NestedAgg 创建 nested 聚合，path 为 nested 字段路径
+func NestedAgg(path string) *NestedAggregation {
+	return &NestedAggregation{path: path}
+}
+
+// SubAggregation 添加在嵌套对象上计算的子聚合
+func (a *NestedAggregation) SubAggregation(name string, agg Aggregation) *NestedAggregation {
+	if a.subs == nil {
+		a.subs = subAggregations{}
+	}
+	a.subs[name] = agg
+	return a
+}
+
+// Source 返回 nested 聚合的 JSON 结构
+func (a *NestedAggregation) Source() map[string]interface{} {
+	return a.subs.with(map[string]interface{}{"nested": map[string]interface{}{"path": a.path}})
+}
+
+// FilterAggregation filter 单桶聚合，在匹配过滤条件的文档上计算子聚合
+type FilterAggregation struct {
+	subs   subAggregations
+	filter Query
+}
+
+// FilterAgg 创建 filter 聚合
+func FilterAgg(filter Query) *FilterAggregation {
+	return &FilterAggregation{filter: filter}
+}
+
+// SubAggregation 添加在匹配文档上计算的子聚合
+func (a *FilterAggregation) SubAggregation(name string, agg Aggregation) *FilterAggregation {
+	if a.subs == nil {
+		a.subs = subAggregations{}
+	}
+	a.subs[name] = agg
+	return a
+}
+
+// Source 返回 filter 聚合的 JSON 结构
+func (a *FilterAggregation) Source() map[string]interface{} {
+	return a.subs.with(map[string]interface{}{"filter": a.filter.Source()})
+}
@@ -0,0 +1,78 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAggregationBuilders(t *testing.T) {
+	aggs := AggregationsBody(map[string]Aggregation{
+		"by_status": TermsAgg("status").Size(5).Order("_count", "desc").
+			SubAggregation("revenue", SumAgg("amount")),
+		"per_day": DateHistogramAgg("created_at", "1d").MinDocCount(0).TimeZone("+08:00").
+			SubAggregation("avg_amount", AvgAgg("amount")),
+		"items": NestedAgg("items").SubAggregation("skus", CardinalityAgg("items.sku")),
+		"paid":  FilterAgg(Term("status", "paid")).SubAggregation("max", MaxAgg("amount")),
+		"grid":  RawAggregation(GeotileGridAggregation("location", 8)),
+		"min":   MinAgg("amount"),
+	})
+
+	got, err := json.Marshal(aggs)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"by_status":{"aggregations":{"revenue":{"sum":{"field":"amount"}}},"terms":{"field":"status","order":{"_count":"desc"},"size":5}},` +
+		`"grid":{"geotile_grid":{"field":"location","precision":8}},` +
+		`"items":{"aggregations":{"skus":{"cardinality":{"field":"items.sku"}}},"nested":{"path":"items"}},` +
+		`"min":{"min":{"field":"amount"}},` +
+		`"paid":{"aggregations":{"max":{"max":{"field":"amount"}}},"filter":{"term":{"status":"paid"}}},` +
+		`"per_day":{"aggregations":{"avg_amount":{"avg":{"field":"amount"}}},"date_histogram":{"calendar_interval":"1d","field":"created_at","min_doc_count":0,"time_zone":"+08:00"}}}`
+	if string(got) != want {
+		t.Errorf("AggregationsBody() = %s\nwant %s", got, want)
+	}
+}
+
+func TestTypedAggregationResults(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"hits":{"total":{"value":3},"hits":[]},"aggregations":{
+			"by_status":{"buckets":[{"key":"paid","doc_count":2,"revenue":{"value":30}},{"key":"new","doc_count":1,"revenue":{"value":5}}]},
+			"per_day":{"buckets":[{"key":1700006400000,"key_as_string":"2023-11-15","doc_count":3,"avg_amount":{"value":11.5}}]},
+			"items":{"doc_count":7,"skus":{"value":4}}}}`))
+	})
+
+	result, err := client.SearchTyped(context.Background(), "orders", map[string]interface{}{"size": 0})
+	if err != nil {
+		t.Fatalf("SearchTyped() error = %v", err)
+	}
+	aggs := result.Aggregations
+
+	statuses, err := aggs.Terms("by_status")
+	if err != nil || len(statuses) != 2 || statuses[0].Key != "paid" {
+		t.Fatalf("Terms() = %+v, %v", statuses, err)
+	}
+	if revenue, err := statuses[0].Aggregations.Value("revenue"); err != nil || revenue != 30 {
+		t.Errorf("revenue = %v, %v", revenue, err)
+	}
+
+	days, err := aggs.Histogram("per_day")
+	if err != nil || len(days) != 1 || days[0].DocCount != 3 {
+		t.Fatalf("Histogram() = %+v, %v", days, err)
+	}
+	if !days[0].Time().Equal(time.UnixMilli(1700006400000)) || days[0].KeyAsString != "2023-11-15" {
+		t.Errorf("bucket time = %s, key = %s", days[0].Time(), days[0].KeyAsString)
+	}
+	if avg, _ := days[0].Aggregations.Value("avg_amount"); avg != 11.5 {
+		t.Errorf("avg_amount = %v", avg)
+	}
+
+	items, err := aggs.SingleBucket("items")
+	if err != nil || items.DocCount != 7 {
+		t.Fatalf("SingleBucket() = %+v, %v", items, err)
+	}
+	if skus, _ := items.Aggregations.Value("skus"); skus != 4 {
+		t.Errorf("skus = %v", skus)
+	}
+}
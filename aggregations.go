@@ -0,0 +1,208 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Aggregations 聚合结果，键为聚合名称，值为该聚合的原始 JSON。
+// 通过 Terms、Histogram、SingleBucket、Value 等方法按聚合类型读取
+type Aggregations map[string]json.RawMessage
+
+// HistogramBucket date_histogram/histogram 聚合的单个分桶
+type HistogramBucket struct {
+	Key          float64      // 分桶起始值，date_histogram 时为毫秒时间戳
+	KeyAsString  string       // 格式化后的分桶起始值
+	DocCount     int64        // 分桶内的文档数
+	Aggregations Aggregations // 分桶内的子聚合
+}
+
+// Time 返回 date_histogram 分桶的起始时间（UTC）
+func (b HistogramBucket) Time() time.Time {
+	return time.UnixMilli(int64(b.Key)).UTC()
+}
+
+// UnmarshalJSON 解析分桶及其子聚合
+func (b *HistogramBucket) UnmarshalJSON(data []byte) error {
+	aggs, err := decodeBucket(data, map[string]interface{}{
+		"key":           &b.Key,
+		"key_as_string": &b.KeyAsString,
+		"doc_count":     &b.DocCount,
+	})
+	b.Aggregations = aggs
+	return err
+}
+
+// SingleBucket nested、filter 等单桶聚合的结果
+type SingleBucket struct {
+	DocCount     int64        // 桶内的文档数
+	Aggregations Aggregations // 桶内的子聚合
+}
+
+// decodeBucket 将分桶中 fields 列出的字段解析到对应的指针，其余对象字段作为子聚合返回
+func decodeBucket(data []byte, fields map[string]interface{}) (Aggregations, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode bucket: %w", err)
+	}
+	var subs Aggregations
+	for key, value := range raw {
+		if target, ok := fields[key]; ok {
+			if err := json.Unmarshal(value, target); err != nil {
+				return nil, fmt.Errorf("failed to decode bucket %s: %w", key, err)
+			}
+			continue
+		}
+		if len(value) > 0 && value[0] == '{' {
+			if subs == nil {
+				subs = Aggregations{}
+			}
+			subs[key] = value
+		}
+	}
+	return subs, nil
+}
+
+// AggregationsFromResult 从 Search 返回的响应中提取聚合结果
+func AggregationsFromResult(result map[string]interface{}) (Aggregations, error) {
+	raw, ok := result["aggregations"]
+	if !ok || raw == nil {
+		return Aggregations{}, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal aggregations: %w", err)
+	}
+	var aggs Aggregations
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode aggregations: %w", err)
+	}
+	return aggs, nil
+}
+
+// searchAggregations 执行只返回聚合结果的搜索（size 为 0），query 中只使用 query 子句
+func (c *ElasticsearchClient) searchAggregations(ctx context.Context, index string, query map[string]interface{}, aggs map[string]interface{}) (Aggregations, error) {
+	body := map[string]interface{}{
+		"size":         0,
+		"aggregations": aggs,
+	}
+	if q, ok := query["query"]; ok {
+		body["query"] = q
+	}
+
+	result, err := c.Search(ctx, index, body)
+	if err != nil {
+		return nil, err
+	}
+	return AggregationsFromResult(result)
+}
+
+// get 返回指定名称的聚合结果
+func (a Aggregations) get(name string) (json.RawMessage, error) {
+	raw, ok := a[name]
+	if !ok {
+		return nil, fmt.Errorf("aggregation %s not found", name)
+	}
+	return raw, nil
+}
+
+// Decode 将指定聚合的原始结果解析到 v
+func (a Aggregations) Decode(name string, v interface{}) error {
+	raw, err := a.get(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to decode aggregation %s: %w", name, err)
+	}
+	return nil
+}
+
+// Histogram 返回 date_histogram/histogram 聚合的分桶
+func (a Aggregations) Histogram(name string) ([]HistogramBucket, error) {
+	var result struct {
+		Buckets []HistogramBucket `json:"buckets"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
+
+// SingleBucket 返回 nested、filter 等单桶聚合的文档数和子聚合
+func (a Aggregations) SingleBucket(name string) (*SingleBucket, error) {
+	raw, err := a.get(name)
+	if err != nil {
+		return nil, err
+	}
+	bucket := &SingleBucket{}
+	aggs, err := decodeBucket(raw, map[string]interface{}{"doc_count": &bucket.DocCount})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode aggregation %s: %w", name, err)
+	}
+	bucket.Aggregations = aggs
+	return bucket, nil
+}
+
+// TopHits 返回 top_hits 聚合的命中文档
+func (a Aggregations) TopHits(name string) ([]Hit, error) {
+	var result struct {
+		Hits struct {
+			Hits []Hit `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return result.Hits.Hits, nil
+}
+
+// ScriptedMetric 将 scripted_metric 聚合的 value 解析到 v
+func (a Aggregations) ScriptedMetric(name string, v interface{}) error {
+	var result struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return err
+	}
+	if len(result.Value) == 0 {
+		return fmt.Errorf("aggregation %s has no value", name)
+	}
+	if err := json.Unmarshal(result.Value, v); err != nil {
+		return fmt.Errorf("failed to decode aggregation %s value: %w", name, err)
+	}
+	return nil
+}
+
+// DecodeTopHits 将 top_hits 聚合命中的 _source 解析为 T 列表
+func DecodeTopHits[T any](a Aggregations, name string) ([]T, error) {
+	hits, err := a.TopHits(name)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, len(hits))
+	for i := range hits {
+		if err := hits[i].DecodeSource(&items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,61 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testAggregationsResponse = `{
+	"aggregations": {
+		"latest": {"hits": {"total": {"value": 2}, "hits": [
+			{"_id": "1", "_index": "orders", "_score": null, "_source": {"sku": "a", "qty": 2}},
+			{"_id": "2", "_index": "orders", "_score": null, "_source": {"sku": "b", "qty": 1}}
+		]}},
+		"profit": {"value": {"total": 42.5, "count": 3}}
+	}
+}`
+
+func testAggregations(t *testing.T) Aggregations {
+	t.Helper()
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(testAggregationsResponse), &result); err != nil {
+		t.Fatal(err)
+	}
+	aggs, err := AggregationsFromResult(result)
+	if err != nil {
+		t.Fatalf("AggregationsFromResult() error = %v", err)
+	}
+	return aggs
+}
+
+func TestDecodeTopHits(t *testing.T) {
+	type order struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}
+
+	orders, err := DecodeTopHits[order](testAggregations(t), "latest")
+	if err != nil {
+		t.Fatalf("DecodeTopHits() error = %v", err)
+	}
+	if len(orders) != 2 || orders[0].SKU != "a" || orders[1].Qty != 1 {
+		t.Errorf("DecodeTopHits() = %+v", orders)
+	}
+
+	if _, err := DecodeTopHits[order](testAggregations(t), "missing"); err == nil {
+		t.Error("DecodeTopHits() with unknown name should return error")
+	}
+}
+
+func TestScriptedMetric(t *testing.T) {
+	var profit struct {
+		Total float64 `json:"total"`
+		Count int     `json:"count"`
+	}
+	if err := testAggregations(t).ScriptedMetric("profit", &profit); err != nil {
+		t.Fatalf("ScriptedMetric() error = %v", err)
+	}
+	if profit.Total != 42.5 || profit.Count != 3 {
+		t.Errorf("ScriptedMetric() = %+v", profit)
+	}
+}
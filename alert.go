@@ -0,0 +1,281 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// DefaultAlertTimeField 告警规则默认的时间字段
+const DefaultAlertTimeField = "@timestamp"
+
+// Comparator 告警条件的比较方式
+type Comparator string
+
+// 告警条件支持的比较方式
+const (
+	Above        Comparator = ">"
+	AboveOrEqual Comparator = ">="
+	Below        Comparator = "<"
+	BelowOrEqual Comparator = "<="
+)
+
+// Condition 告警条件：指标值与阈值比较，Aggregation 为 nil 时指标为匹配的文档数
+type Condition struct {
+	Aggregation Aggregation // 单值指标聚合（如 AvgAgg、MaxAgg），为 nil 时使用文档数
+	Comparator  Comparator
+	Threshold   float64
+}
+
+// Rule 告警规则：统计 Index 中最近 Window 内匹配 Query 的文档，判断是否满足 Condition
+type Rule struct {
+	Name      string
+	Index     string
+	Query     Query         // 过滤条件，为 nil 时匹配全部文档
+	TimeField string        // 时间字段，默认 @timestamp
+	Window    time.Duration // 统计的时间窗口，0 表示不限制时间
+	Condition Condition
+}
+
+// RuleResult 规则的一次评估结果
+type RuleResult struct {
+	Rule        string
+	Value       float64 // 指标值，聚合没有值（如窗口内没有文档）时为 0
+	Triggered   bool    // 是否满足告警条件
+	EvaluatedAt time.Time
+}
+
+// EvaluateRule 评估告警规则，返回指标值以及是否满足条件
+func (c *ElasticsearchClient) EvaluateRule(ctx context.Context, rule Rule) (*RuleResult, error) {
+	return c.evaluateRule(ctx, rule, time.Now())
+}
+
+// evaluateRule 以 now 为窗口终点评估规则
+func (c *ElasticsearchClient) evaluateRule(ctx context.Context, rule Rule, now time.Time) (*RuleResult, error) {
+	if rule.Index == "" {
+		return nil, fmt.Errorf("rule %s index cannot be empty", rule.Name)
+	}
+	compare, err := rule.Condition.compareFunc()
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+	}
+
+	query := NewBoolQuery()
+	if rule.Query != nil {
+		query.Must(rule.Query)
+	} else {
+		query.Must(MatchAll())
+	}
+	if rule.Window > 0 {
+		timeField := rule.TimeField
+		if timeField == "" {
+			timeField = DefaultAlertTimeField
+		}
+		query.Filter(Range(timeField).Gte(now.Add(-rule.Window).UTC().Format(time.RFC3339Nano)))
+	}
+
+	var value float64
+	if rule.Condition.Aggregation == nil {
+		count, err := c.Count(ctx, rule.Index, SearchBody(query))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate rule %s: %w", rule.Name, err)
+		}
+		value = float64(count)
+	} else {
+		body := SearchBody(query)
+		body["size"] = 0
+		body["aggs"] = AggregationsBody(map[string]Aggregation{"value": rule.Condition.Aggregation})
+		result, err := c.SearchTyped(ctx, rule.Index, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate rule %s: %w", rule.Name, err)
+		}
+		var metric struct {
+			Value *float64 `json:"value"`
+		}
+		if err := result.Aggregations.Decode("value", &metric); err != nil {
+			return nil, fmt.Errorf("failed to evaluate rule %s: %w", rule.Name, err)
+		}
+		if metric.Value != nil {
+			value = *metric.Value
+		}
+	}
+
+	return &RuleResult{
+		Rule:        rule.Name,
+		Value:       value,
+		Triggered:   compare(value, rule.Condition.Threshold),
+		EvaluatedAt: now,
+	}, nil
+}
+
+// compareFunc 返回条件的比较函数
+func (cond Condition) compareFunc() (func(value, threshold float64) bool, error) {
+	switch cond.Comparator {
+	case Above:
+		return func(v, t float64) bool { return v > t }, nil
+	case AboveOrEqual:
+		return func(v, t float64) bool { return v >= t }, nil
+	case Below:
+		return func(v, t float64) bool { return v < t }, nil
+	case BelowOrEqual:
+		return func(v, t float64) bool { return v <= t }, nil
+	default:
+		return nil, fmt.Errorf("invalid comparator %q", cond.Comparator)
+	}
+}
+
+// Alert 告警状态变化通知
+type Alert struct {
+	RuleResult
+	Resolved bool // 为 true 表示规则从触发恢复为正常
+}
+
+// AlertHandler 告警通知回调
+type AlertHandler func(ctx context.Context, alert Alert)
+
+// AlertRunner 按计划定期评估一组告警规则，规则开始触发或恢复正常时调用通知回调，
+// 持续触发期间不重复通知。客户端关闭时自动停止
+type AlertRunner struct {
+	client   *ElasticsearchClient
+	schedule Schedule
+	notify   AlertHandler
+
+	mu      sync.Mutex
+	rules   []Rule
+	firing  map[string]bool
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewAlertRunner 创建告警规则执行器，添加规则后调用 Start 开始评估
+func (c *ElasticsearchClient) NewAlertRunner(schedule Schedule, notify AlertHandler) *AlertRunner {
+	return &AlertRunner{
+		client:   c,
+		schedule: schedule,
+		notify:   notify,
+		firing:   make(map[string]bool),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// AddRule 添加告警规则，规则名称不能重复
+func (r *AlertRunner) AddRule(rule Rule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("rule name cannot be empty")
+	}
+	if _, err := rule.Condition.compareFunc(); err != nil {
+		return fmt.Errorf("rule %s: %w", rule.Name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.rules {
+		if existing.Name == rule.Name {
+			return fmt.Errorf("rule %s already exists", rule.Name)
+		}
+	}
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Start 开始按计划评估规则
+func (r *AlertRunner) Start() error {
+	if err := r.client.ready(); err != nil {
+		return err
+	}
+	if r.schedule == nil || r.notify == nil {
+		return fmt.Errorf("alert runner requires a schedule and a notify callback")
+	}
+	if err := validateSchedule(r.schedule); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		return fmt.Errorf("alert runner already started")
+	}
+	if err := r.client.lifecycle.track("alert runner", r.Stop); err != nil {
+		return err
+	}
+	r.started = true
+	go func() {
+		defer close(r.done)
+		runSchedule(r.schedule, r.stop, func(time.Time) {
+			r.EvaluateAll(context.Background())
+		})
+	}()
+	return nil
+}
+
+// Stop 停止评估并等待进行中的评估结束，ctx 到期时不再等待。可重复调用
+func (r *AlertRunner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	started := r.started
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	r.mu.Unlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// EvaluateAll 立即评估所有规则并按状态变化发送通知，返回成功评估的结果。
+// 单个规则评估失败时记录 WARN 日志并保持其上次的状态
+func (r *AlertRunner) EvaluateAll(ctx context.Context) []RuleResult {
+	r.mu.Lock()
+	rules := append([]Rule(nil), r.rules...)
+	r.mu.Unlock()
+
+	results := make([]RuleResult, 0, len(rules))
+	for _, rule := range rules {
+		result, err := r.client.EvaluateRule(WithOperationTag(ctx, "alert_rule", rule.Name), rule)
+		if err != nil {
+			log.FromContext(ctx).Warn("Failed to evaluate Elasticsearch alert rule", zap.String("rule", rule.Name), zap.Error(err))
+			continue
+		}
+		results = append(results, *result)
+
+		r.mu.Lock()
+		wasFiring := r.firing[rule.Name]
+		r.firing[rule.Name] = result.Triggered
+		r.mu.Unlock()
+
+		if result.Triggered != wasFiring {
+			r.notify(ctx, Alert{RuleResult: *result, Resolved: !result.Triggered})
+		}
+	}
+	return results
+}
@@ -0,0 +1,75 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateRule(t *testing.T) {
+	var count int64 = 3
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_count"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"count": count})
+		case strings.HasSuffix(r.URL.Path, "/_search"):
+			w.Write([]byte(`{"hits":{"total":{"value":10},"hits":[]},"aggregations":{"value":{"value":812.5}}}`))
+		}
+	})
+	ctx := context.Background()
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	errorsRule := Rule{
+		Name:      "errors",
+		Index:     "logs",
+		Query:     Term("level", "error"),
+		Window:    5 * time.Minute,
+		Condition: Condition{Comparator: AboveOrEqual, Threshold: 5},
+	}
+	result, err := client.evaluateRule(ctx, errorsRule, now)
+	if err != nil || result.Value != 3 || result.Triggered {
+		t.Fatalf("evaluateRule() = %+v, %v", result, err)
+	}
+	filter := body["query"].(map[string]interface{})["bool"].(map[string]interface{})["filter"].([]interface{})
+	gte := filter[0].(map[string]interface{})["range"].(map[string]interface{})["@timestamp"].(map[string]interface{})["gte"]
+	if gte != "2024-03-15T09:55:00Z" {
+		t.Errorf("window start = %v", gte)
+	}
+
+	latencyRule := Rule{
+		Name:      "latency",
+		Index:     "requests",
+		Condition: Condition{Aggregation: AvgAgg("duration_ms"), Comparator: Above, Threshold: 500},
+	}
+	if result, err := client.EvaluateRule(ctx, latencyRule); err != nil || result.Value != 812.5 || !result.Triggered {
+		t.Errorf("EvaluateRule() with aggregation = %+v, %v", result, err)
+	}
+
+	if _, err := client.EvaluateRule(ctx, Rule{Name: "bad", Index: "logs", Condition: Condition{Comparator: "=="}}); err == nil {
+		t.Error("EvaluateRule() should reject invalid comparators")
+	}
+
+	var alerts []Alert
+	runner := client.NewAlertRunner(Every(time.Hour), func(_ context.Context, alert Alert) {
+		alerts = append(alerts, alert)
+	})
+	if err := runner.AddRule(errorsRule); err != nil {
+		t.Fatal(err)
+	}
+	runner.EvaluateAll(ctx)
+	count = 8
+	runner.EvaluateAll(ctx)
+	runner.EvaluateAll(ctx)
+	count = 1
+	runner.EvaluateAll(ctx)
+	if len(alerts) != 2 || alerts[0].Resolved || !alerts[0].Triggered || !alerts[1].Resolved {
+		t.Errorf("alerts = %+v, want one firing and one resolved notification", alerts)
+	}
+}
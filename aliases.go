@@ -0,0 +1,318 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// CreateFilteredAlias 创建带过滤条件和路由的别名，通过别名的搜索只返回匹配 filter 的文档
+func (c *ElasticsearchClient) CreateFilteredAlias(ctx context.Context, index string, alias string, filter map[string]interface{}, routing string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+	aliasName := c.resolveIndexName(ctx, alias)
+	if err := ValidateIndexName(aliasName); err != nil {
+		return err
+	}
+	alias = escapeIndexPath(aliasName)
+
+	body := make(map[string]interface{})
+	if filter != nil {
+		body["filter"] = filter
+	}
+	if routing != "" {
+		body["routing"] = routing
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias: %w", err)
+	}
+
+	req := esapi.IndicesPutAliasRequest{
+		Index: []string{index},
+		Name:  alias,
+		Body:  strings.NewReader(string(bodyBytes)),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return c.requestError("create alias", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return c.responseError("create alias", res)
+	}
+
+	return nil
+}
+
+// 别名变更操作类型
+const (
+	AliasActionAdd    = "add"
+	AliasActionRemove = "remove"
+)
+
+// AliasAction UpdateAliases 中的单个别名变更
+type AliasAction struct {
+	Action       string                 `json:"-"` // AliasActionAdd 或 AliasActionRemove
+	Index        string                 `json:"index"`
+	Alias        string                 `json:"alias"`
+	Filter       map[string]interface{} `json:"filter,omitempty"`
+	Routing      string                 `json:"routing,omitempty"`
+	IsWriteIndex *bool                  `json:"is_write_index,omitempty"`
+}
+
+// AddAliasAction 返回将 alias 指向 index 的变更
+func AddAliasAction(index string, alias string) AliasAction {
+	return AliasAction{Action: AliasActionAdd, Index: index, Alias: alias}
+}
+
+// RemoveAliasAction 返回移除 index 上 alias 的变更
+func RemoveAliasAction(index string, alias string) AliasAction {
+	return AliasAction{Action: AliasActionRemove, Index: index, Alias: alias}
+}
+
+// PutAlias 为索引创建别名
+func (c *ElasticsearchClient) PutAlias(ctx context.Context, index string, alias string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.CreateFilteredAlias(ctx, index, alias, nil, "")
+}
+
+// DeleteAlias 移除索引上的别名
+func (c *ElasticsearchClient) DeleteAlias(ctx context.Context, index string, alias string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.UpdateAliases(ctx, []AliasAction{RemoveAliasAction(index, alias)})
+}
+
+// SwapAlias 原子地将 alias 从 from 索引切换到 to 索引，用于重建索引后零停机切换
+func (c *ElasticsearchClient) SwapAlias(ctx context.Context, alias string, from string, to string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.UpdateAliases(ctx, []AliasAction{
+		RemoveAliasAction(from, alias),
+		AddAliasAction(to, alias),
+	})
+}
+
+// UpdateAliases 在一次请求中原子地执行多个别名变更，任一变更失败时全部不生效
+func (c *ElasticsearchClient) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+
+	indices := make([]string, 0, len(actions))
+	body := make([]map[string]AliasAction, 0, len(actions))
+	for _, action := range actions {
+		if action.Action != AliasActionAdd && action.Action != AliasActionRemove {
+			return fmt.Errorf("invalid alias action %q", action.Action)
+		}
+		action.Index = c.resolveIndexName(ctx, action.Index)
+		action.Alias = c.resolveIndexName(ctx, action.Alias)
+		if err := ValidateIndexName(action.Alias); err != nil {
+			return err
+		}
+		indices = append(indices, action.Index)
+		body = append(body, map[string]AliasAction{action.Action: action})
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{"actions": body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias actions: %w", err)
+	}
+
+	details := map[string]interface{}{"actions": body}
+	return c.audit(ctx, "update_aliases", strings.Join(indices, ","), details, func(ctx context.Context) error {
+		req := esapi.IndicesUpdateAliasesRequest{
+			Body: strings.NewReader(string(bodyBytes)),
+		}
+
+		res, err := req.Do(ctx, c.client)
+		if err != nil {
+			return c.requestError("update aliases", err)
+		}
+		defer res.Body.Close()
+
+		if res.IsError() {
+			return c.responseError("update aliases", res)
+		}
+		return nil
+	})
+}
+
+// GetAliases 返回索引到别名列表的映射（别名按名称排序），index 可为索引名、别名或通配符，为空时返回所有索引
+func (c *ElasticsearchClient) GetAliases(ctx context.Context, index string) (map[string][]string, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	req := esapi.IndicesGetAliasRequest{}
+	if index != "" {
+		req.Index = []string{c.resolveIndex(ctx, index)}
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("get aliases", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("get aliases", res)
+	}
+
+	var response map[string]struct {
+		Aliases map[string]json.RawMessage `json:"aliases"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	aliases := make(map[string][]string, len(response))
+	for name, entry := range response {
+		names := make([]string, 0, len(entry.Aliases))
+		for alias := range entry.Aliases {
+			names = append(names, alias)
+		}
+		sort.Strings(names)
+		aliases[name] = names
+	}
+	return aliases, nil
+}
+
+// TenantAliasName 返回租户别名的名称
+func TenantAliasName(index string, tenant string) string {
+	return index + "-tenant-" + tenant
+}
+
+// TenantScope 基于过滤别名的租户作用域。
+// 搜索和统计通过别名执行，由服务端过滤条件保证隔离；
+// 按 ID 读写不会应用别名过滤，因此由客户端校验文档的租户字段
+type TenantScope struct {
+	client *ElasticsearchClient
+	index  string
+	field  string
+	tenant string
+}
+
+// ForTenant 返回租户作用域，field 为文档中存放租户 ID 的 keyword 字段
+func (c *ElasticsearchClient) ForTenant(index string, field string, tenant string) *TenantScope {
+	return &TenantScope{
+		client: c,
+		index:  index,
+		field:  field,
+		tenant: tenant,
+	}
+}
+
+// CreateTenantAlias 为租户创建按租户字段过滤并按租户路由的别名
+func (c *ElasticsearchClient) CreateTenantAlias(ctx context.Context, index string, field string, tenant string) (*TenantScope, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	scope := c.ForTenant(index, field, tenant)
+	filter := Term(field, tenant).Source()
+	if err := c.CreateFilteredAlias(ctx, index, scope.Alias(), filter, tenant); err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+// Alias 返回租户别名名称
+func (s *TenantScope) Alias() string {
+	return TenantAliasName(s.index, s.tenant)
+}
+
+// Tenant 返回租户 ID
+func (s *TenantScope) Tenant() string {
+	return s.tenant
+}
+
+// Search 在租户别名上搜索文档
+func (s *TenantScope) Search(ctx context.Context, query map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.Search(ctx, s.Alias(), query)
+}
+
+// Count 统计租户的文档数量
+func (s *TenantScope) Count(ctx context.Context, query map[string]interface{}) (int64, error) {
+	return s.client.Count(ctx, s.Alias(), query)
+}
+
+// Index 通过租户别名写入文档，自动填充租户字段；文档中已有其他租户的值时返回错误
+func (s *TenantScope) Index(ctx context.Context, documentID string, body interface{}) error {
+	doc, err := documentMap(body)
+	if err != nil {
+		return err
+	}
+	if value, ok := doc[s.field]; ok && fmt.Sprint(value) != s.tenant {
+		return fmt.Errorf("document %s belongs to tenant %v, not %s", s.field, value, s.tenant)
+	}
+	doc[s.field] = s.tenant
+	return s.client.Index(ctx, s.Alias(), documentID, doc)
+}
+
+// Get 获取租户的文档，文档不属于该租户时按不存在处理
+func (s *TenantScope) Get(ctx context.Context, documentID string) (map[string]interface{}, error) {
+	result, err := s.client.Get(ctx, s.Alias(), documentID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.owns(result) {
+		return nil, fmt.Errorf("document %w", ErrNotFound)
+	}
+	return result, nil
+}
+
+// Delete 删除租户的文档，删除前校验文档归属
+func (s *TenantScope) Delete(ctx context.Context, documentID string) error {
+	if _, err := s.Get(ctx, documentID); err != nil {
+		return err
+	}
+	return s.client.Delete(ctx, s.Alias(), documentID)
+}
+
+// owns 判断 Get 返回的文档是否属于当前租户
+func (s *TenantScope) owns(result map[string]interface{}) bool {
+	source, ok := result["_source"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	value, ok := source[s.field]
+	return ok && fmt.Sprint(value) == s.tenant
+}
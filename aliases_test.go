@@ -0,0 +1,132 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateTenantAlias(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/orders/_aliases/orders-tenant-acme" {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+
+	scope, err := client.CreateTenantAlias(context.Background(), "orders", "tenant_id", "acme")
+	if err != nil {
+		t.Fatalf("CreateTenantAlias() error = %v", err)
+	}
+	if scope.Alias() != "orders-tenant-acme" {
+		t.Errorf("Alias() = %s", scope.Alias())
+	}
+	if got["routing"] != "acme" {
+		t.Errorf("alias routing = %v, want acme", got["routing"])
+	}
+	filter, _ := json.Marshal(got["filter"])
+	if string(filter) != `{"term":{"tenant_id":"acme"}}` {
+		t.Errorf("alias filter = %s", filter)
+	}
+}
+
+func TestTenantScope_IndexAndGet(t *testing.T) {
+	var indexed map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/orders-tenant-acme/_doc/1":
+			json.NewDecoder(r.Body).Decode(&indexed)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"result":"created"}`))
+		case r.Method == "GET" && r.URL.Path == "/orders-tenant-acme/_doc/1":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"_id":"1","found":true,"_source":{"tenant_id":"acme"}}`))
+		case r.Method == "GET" && r.URL.Path == "/orders-tenant-acme/_doc/2":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"_id":"2","found":true,"_source":{"tenant_id":"other"}}`))
+		}
+	})
+
+	scope := client.ForTenant("orders", "tenant_id", "acme")
+	if err := scope.Index(context.Background(), "1", map[string]interface{}{"amount": 10}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if indexed["tenant_id"] != "acme" {
+		t.Errorf("Index() body = %v, want tenant_id filled", indexed)
+	}
+	if err := scope.Index(context.Background(), "1", map[string]interface{}{"tenant_id": "other"}); err == nil {
+		t.Error("Index() with foreign tenant should return error")
+	}
+
+	if _, err := scope.Get(context.Background(), "1"); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+	if _, err := scope.Get(context.Background(), "2"); err == nil {
+		t.Error("Get() of foreign tenant document should return error")
+	}
+	if err := scope.Delete(context.Background(), "2"); err == nil {
+		t.Error("Delete() of foreign tenant document should return error")
+	}
+}
+
+func TestAliasManagement(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/_aliases":
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == "PUT" && r.URL.Path == "/orders_v1/_aliases/orders":
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == "GET" && r.URL.Path == "/orders/_alias":
+			w.Write([]byte(`{"orders_v2":{"aliases":{"orders":{},"orders_read":{"filter":{"term":{"a":1}}}}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.PutAlias(ctx, "orders_v1", "orders"); err != nil {
+		t.Fatalf("PutAlias() error = %v", err)
+	}
+	if err := client.SwapAlias(ctx, "orders", "orders_v1", "orders_v2"); err != nil {
+		t.Fatalf("SwapAlias() error = %v", err)
+	}
+	if err := client.DeleteAlias(ctx, "orders_v1", "orders_old"); err != nil {
+		t.Fatalf("DeleteAlias() error = %v", err)
+	}
+	want := []string{
+		`{"actions":[{"remove":{"index":"orders_v1","alias":"orders"}},{"add":{"index":"orders_v2","alias":"orders"}}]}`,
+		`{"actions":[{"remove":{"index":"orders_v1","alias":"orders_old"}}]}`,
+	}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("bodies = %q", bodies)
+	}
+
+	writeIndex := true
+	action := AddAliasAction("orders_v2", "orders_write")
+	action.IsWriteIndex = &writeIndex
+	if err := client.UpdateAliases(ctx, []AliasAction{action}); err != nil {
+		t.Fatalf("UpdateAliases() error = %v", err)
+	}
+	if !strings.Contains(bodies[len(bodies)-1], `"is_write_index":true`) {
+		t.Errorf("body = %s", bodies[len(bodies)-1])
+	}
+	if err := client.UpdateAliases(ctx, []AliasAction{{Action: "remove_index", Index: "orders_v1"}}); err == nil {
+		t.Error("UpdateAliases() with unknown action should fail")
+	}
+
+	aliases, err := client.GetAliases(ctx, "orders")
+	if err != nil {
+		t.Fatalf("GetAliases() error = %v", err)
+	}
+	if got := aliases["orders_v2"]; len(got) != 2 || got[0] != "orders" || got[1] != "orders_read" {
+		t.Errorf("GetAliases() = %v", aliases)
+	}
+}
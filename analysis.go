@@ -0,0 +1,216 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// 语言分析预设名称
+const (
+	AnalysisEnglish      = "english"          // 英文：所有格处理、停用词、词干提取
+	AnalysisCJK          = "cjk"              // 中日韩：全半角归一化和二元分词，无需插件
+	AnalysisMultilingual = "icu_multilingual" // 多语言：ICU 分词和归一化，需要安装 analysis-icu 插件
+)
+
+// analysisPreset 语言分析预设，包含分析器名称和所需的 analysis 设置
+type analysisPreset struct {
+	analyzer string
+	analysis map[string]interface{}
+}
+
+// analysisPresets 按名称注册的语言分析预设
+var analysisPresets = map[string]analysisPreset{
+	AnalysisEnglish: {
+		analyzer: "english_text",
+		analysis: map[string]interface{}{
+			"filter": map[string]interface{}{
+				"english_possessive_stemmer": map[string]interface{}{"type": "stemmer", "language": "possessive_english"},
+				"english_stop":               map[string]interface{}{"type": "stop", "stopwords": "_english_"},
+				"english_stemmer":            map[string]interface{}{"type": "stemmer", "language": "english"},
+			},
+			"analyzer": map[string]interface{}{
+				"english_text": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"english_possessive_stemmer", "lowercase", "english_stop", "english_stemmer"},
+				},
+			},
+		},
+	},
+	AnalysisCJK: {
+		analyzer: "cjk_text",
+		analysis: map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				"cjk_text": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"cjk_width", "lowercase", "cjk_bigram"},
+				},
+			},
+		},
+	},
+	AnalysisMultilingual: {
+		analyzer: "icu_text",
+		analysis: map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				"icu_text": map[string]interface{}{
+					"type":        "custom",
+					"char_filter": []string{"icu_normalizer"},
+					"tokenizer":   "icu_tokenizer",
+					"filter":      []string{"icu_folding"},
+				},
+			},
+		},
+	},
+}
+
+// AnalysisPresets 返回所有可用的语言分析预设名称
+func AnalysisPresets() []string {
+	names := make([]string, 0, len(analysisPresets))
+	for name := range analysisPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SuggestFieldSuffix LanguageField 生成的输入提示字段名后缀
+const SuggestFieldSuffix = "_suggest"
+
+// LanguageField 按预设名称添加语言分析设置和文本字段映射。
+// 字段包含 keyword 子字段（精确匹配、聚合），并通过 copy_to 填充顶层 search_as_you_type 字段
+// field+SuggestFieldSuffix（输入提示）；search_as_you_type 不能声明为多字段
+func (s *IndexSpec) LanguageField(field string, preset string) error {
+	p, ok := analysisPresets[preset]
+	if !ok {
+		return fmt.Errorf("unknown analysis preset %q, available: %v", preset, AnalysisPresets())
+	}
+	suggest := field + SuggestFieldSuffix
+	if s.hasProperty(field) || s.hasProperty(suggest) {
+		return fmt.Errorf("field %s or %s is already mapped", field, suggest)
+	}
+	if err := s.mergeAnalysis(p.analysis); err != nil {
+		return err
+	}
+	if err := s.addProperty(suggest, map[string]interface{}{"type": "search_as_you_type", "analyzer": p.analyzer}); err != nil {
+		return err
+	}
+	return s.addProperty(field, map[string]interface{}{
+		"type":     "text",
+		"analyzer": p.analyzer,
+		"copy_to":  suggest,
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+		},
+	})
+}
+
+// AutocompleteField 添加用于输入提示的 search_as_you_type 字段，
+// 服务端自动生成 _2gram、_3gram 和 _index_prefix（edge n-gram）子字段，配合 SearchPrefix 使用
+func (s *IndexSpec) AutocompleteField(field string) error {
+	return s.addProperty(field, map[string]interface{}{
+		"type": "search_as_you_type",
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+		},
+	})
+}
+
+// SearchPrefix 对 search_as_you_type 字段执行前缀搜索（multi_match bool_prefix），
+// 最后一个词按前缀匹配，适用于 AutocompleteField 字段和 LanguageField 生成的 field+SuggestFieldSuffix 字段
+func (c *ElasticsearchClient) SearchPrefix(ctx context.Context, index string, field string, text string) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  text,
+				"type":   "bool_prefix",
+				"fields": []string{field, field + "._2gram", field + "._3gram"},
+			},
+		},
+	}
+	return c.Search(ctx, index, query)
+}
+
+// mergeAnalysis 将 analysis 设置合并到 Settings 中，同名组件定义不同时返回错误
+func (s *IndexSpec) mergeAnalysis(analysis map[string]interface{}) error {
+	if len(s.RawSettings) > 0 {
+		return fmt.Errorf("cannot add analysis settings to RawSettings")
+	}
+	if s.Settings == nil {
+		s.Settings = make(map[string]interface{})
+	}
+	target, ok := s.Settings["analysis"].(map[string]interface{})
+	if !ok {
+		if s.Settings["analysis"] != nil {
+			return fmt.Errorf("index settings analysis must be an object")
+		}
+		target = make(map[string]interface{})
+		s.Settings["analysis"] = target
+	}
+
+	for kind, components := range analysis {
+		existing, ok := target[kind].(map[string]interface{})
+		if !ok {
+			existing = make(map[string]interface{})
+			target[kind] = existing
+		}
+		for name, definition := range components.(map[string]interface{}) {
+			if current, ok := existing[name]; ok && fmt.Sprint(current) != fmt.Sprint(definition) {
+				return fmt.Errorf("analysis %s %q already defined with a different configuration", kind, name)
+			}
+			existing[name] = definition
+		}
+	}
+	return nil
+}
+
+// hasProperty 判断 Mappings 的 properties 中是否已映射字段
+func (s *IndexSpec) hasProperty(field string) bool {
+	properties, _ := s.Mappings["properties"].(map[string]interface{})
+	_, ok := properties[field]
+	return ok
+}
+
+// addProperty 在 Mappings 的 properties 中添加字段映射
+func (s *IndexSpec) addProperty(field string, mapping map[string]interface{}) error {
+	if len(s.RawMappings) > 0 {
+		return fmt.Errorf("cannot add field mapping to RawMappings")
+	}
+	if s.Mappings == nil {
+		s.Mappings = make(map[string]interface{})
+	}
+	properties, ok := s.Mappings["properties"].(map[string]interface{})
+	if !ok {
+		if s.Mappings["properties"] != nil {
+			return fmt.Errorf("mappings properties must be an object")
+		}
+		properties = make(map[string]interface{})
+		s.Mappings["properties"] = properties
+	}
+	if _, ok := properties[field]; ok {
+		return fmt.Errorf("field %s is already mapped", field)
+	}
+	properties[field] = mapping
+	return nil
+}
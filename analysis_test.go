@@ -0,0 +1,95 @@
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestIndexSpecLanguageField(t *testing.T) {
+	spec := &IndexSpec{}
+	if err := spec.LanguageField("title", AnalysisEnglish); err != nil {
+		t.Fatalf("LanguageField() error = %v", err)
+	}
+	if err := spec.LanguageField("summary", AnalysisEnglish); err != nil {
+		t.Fatalf("LanguageField() second field error = %v", err)
+	}
+	if err := spec.LanguageField("title_zh", AnalysisCJK); err != nil {
+		t.Fatalf("LanguageField() cjk error = %v", err)
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	analyzers := spec.Settings["analysis"].(map[string]interface{})["analyzer"].(map[string]interface{})
+	if _, ok := analyzers["english_text"]; !ok {
+		t.Error("english_text analyzer missing")
+	}
+	if _, ok := analyzers["cjk_text"]; !ok {
+		t.Error("cjk_text analyzer missing")
+	}
+
+	title := spec.Mappings["properties"].(map[string]interface{})["title"].(map[string]interface{})
+	if title["analyzer"] != "english_text" {
+		t.Errorf("title analyzer = %v", title["analyzer"])
+	}
+	fields := title["fields"].(map[string]interface{})
+	if fields["keyword"] == nil || fields["suggest"] != nil {
+		t.Errorf("title sub-fields = %v", fields)
+	}
+	if title["copy_to"] != "title_suggest" {
+		t.Errorf("title copy_to = %v", title["copy_to"])
+	}
+	suggest := spec.Mappings["properties"].(map[string]interface{})["title_suggest"].(map[string]interface{})
+	if suggest["type"] != "search_as_you_type" || suggest["analyzer"] != "english_text" {
+		t.Errorf("title_suggest = %v", suggest)
+	}
+}
+
+func TestIndexSpecLanguageFieldErrors(t *testing.T) {
+	spec := &IndexSpec{}
+	if err := spec.LanguageField("title", "klingon"); err == nil {
+		t.Error("LanguageField() with unknown preset should return error")
+	}
+
+	spec = &IndexSpec{}
+	spec.LanguageField("title", AnalysisEnglish)
+	if err := spec.LanguageField("title", AnalysisCJK); err == nil {
+		t.Error("LanguageField() on mapped field should return error")
+	}
+
+	spec = &IndexSpec{RawSettings: []byte(`{}`)}
+	if err := spec.LanguageField("title", AnalysisEnglish); err == nil {
+		t.Error("LanguageField() with RawSettings should return error")
+	}
+}
+
+func TestIndexSpecAutocompleteField(t *testing.T) {
+	spec := &IndexSpec{}
+	if err := spec.AutocompleteField("name"); err != nil {
+		t.Fatalf("AutocompleteField() error = %v", err)
+	}
+	name := spec.Mappings["properties"].(map[string]interface{})["name"].(map[string]interface{})
+	if name["type"] != "search_as_you_type" {
+		t.Errorf("name type = %v, want search_as_you_type", name["type"])
+	}
+}
+
+func TestSearchPrefix(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	})
+
+	if _, err := client.SearchPrefix(context.Background(), "products", "name", "quick br"); err != nil {
+		t.Fatalf("SearchPrefix() error = %v", err)
+	}
+	want := `{"query":{"multi_match":{"fields":["name","name._2gram","name._3gram"],"query":"quick br","type":"bool_prefix"}}}`
+	if got != want {
+		t.Errorf("SearchPrefix() body = %s, want %s", got, want)
+	}
+}
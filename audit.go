@@ -0,0 +1,85 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// auditLoggerName 审计日志使用的独立 logger 名称，便于单独采集和保留
+const auditLoggerName = "elasticsearch.audit"
+
+// AuditEvent 破坏性操作的审计事件
+type AuditEvent struct {
+	Operation string                 // 操作类型，如 delete_index、delete_by_query、update_by_query、update_aliases
+	Index     string                 // 目标索引
+	Actor     string                 // 调用方身份，由 AuditActor 从上下文提取
+	Details   map[string]interface{} // 操作详情，如查询条件、脚本、别名变更
+	Time      time.Time              // 操作开始时间
+	Duration  time.Duration          // 操作耗时
+	Err       error                  // 操作失败时的错误
+}
+
+// AuditHook 审计事件回调，设置后替代默认的审计日志输出
+type AuditHook func(ctx context.Context, event AuditEvent)
+
+// AuditActor 从上下文中提取调用方身份（如用户 ID、服务账号）
+type AuditActor func(ctx context.Context) string
+
+// audit 执行破坏性操作并在完成后发出审计事件
+func (c *ElasticsearchClient) audit(ctx context.Context, operation string, index string, details map[string]interface{}, fn func(context.Context) error) error {
+	event := AuditEvent{
+		Operation: operation,
+		Index:     index,
+		Details:   details,
+		Time:      time.Now(),
+	}
+	if c.auditActor != nil {
+		event.Actor = c.auditActor(ctx)
+	}
+
+	err := fn(ctx)
+	event.Duration = time.Since(event.Time)
+	event.Err = err
+
+	if c.auditHook != nil {
+		c.auditHook(ctx, event)
+		return err
+	}
+
+	fields := []zap.Field{
+		zap.String("operation", event.Operation),
+		zap.String("index", event.Index),
+		zap.String("actor", event.Actor),
+		zap.Time("time", event.Time),
+		zap.Duration("duration", event.Duration),
+		zap.Bool("success", err == nil),
+	}
+	if details != nil {
+		fields = append(fields, zap.Any("details", details))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	log.FromContext(ctx).Named(auditLoggerName).Info("Elasticsearch audit event", fields...)
+
+	return err
+}
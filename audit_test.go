@@ -0,0 +1,62 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type actorKey struct{}
+
+func TestAudit_DestructiveOperations(t *testing.T) {
+	var events []AuditEvent
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE" && r.URL.Path == "/old-index":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.URL.Path == "/orders/_delete_by_query":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"deleted":3}`))
+		case r.URL.Path == "/orders/_update_by_query":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"type":"exception"}}`))
+		}
+	}, func(opts *Options) {
+		opts.AuditHook = func(ctx context.Context, event AuditEvent) {
+			events = append(events, event)
+		}
+		opts.AuditActor = func(ctx context.Context) string {
+			actor, _ := ctx.Value(actorKey{}).(string)
+			return actor
+		}
+	})
+
+	ctx := context.WithValue(context.Background(), actorKey{}, "alice")
+	if err := client.DeleteIndex(ctx, "old-index"); err != nil {
+		t.Fatalf("DeleteIndex() error = %v", err)
+	}
+	query := map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}
+	if _, err := client.DeleteByQuery(ctx, "orders", query); err != nil {
+		t.Fatalf("DeleteByQuery() error = %v", err)
+	}
+	if _, err := client.UpdateByQuery(ctx, "orders", query, nil); err == nil {
+		t.Fatal("UpdateByQuery() should return error")
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("events = %d, want 3", len(events))
+	}
+	wantOps := []string{"delete_index", "delete_by_query", "update_by_query"}
+	for i, event := range events {
+		if event.Operation != wantOps[i] || event.Actor != "alice" {
+			t.Errorf("events[%d] = %+v", i, event)
+		}
+	}
+	if events[1].Details["query"] == nil {
+		t.Error("delete_by_query event should include the query")
+	}
+	if events[2].Err == nil {
+		t.Error("failed update_by_query event should carry the error")
+	}
+}
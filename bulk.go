@@ -0,0 +1,154 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BulkOption 批量操作的选项
+type BulkOption func(*bulkConfig)
+
+// bulkConfig 单次批量操作的配置
+type bulkConfig struct {
+	failOnItemErrors bool
+}
+
+// newBulkConfig 应用批量操作选项
+func newBulkConfig(opts []BulkOption) *bulkConfig {
+	cfg := &bulkConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithFailOnItemErrors 任一操作失败时返回 *BulkError，而不仅检查 HTTP 状态
+func WithFailOnItemErrors() BulkOption {
+	return func(cfg *bulkConfig) {
+		cfg.failOnItemErrors = true
+	}
+}
+
+// BulkResult Bulk 响应
+type BulkResult struct {
+	Took   int64            // 服务端耗时（毫秒）
+	Errors bool             // 是否有操作失败
+	Items  []BulkItemResult // 每个操作的结果，顺序与请求一致
+}
+
+// Failed 返回失败的操作
+func (r *BulkResult) Failed() []BulkItemResult {
+	if r == nil || !r.Errors {
+		return nil
+	}
+	var failed []BulkItemResult
+	for _, item := range r.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// BulkItemResult Bulk 响应中单个操作的结果
+type BulkItemResult struct {
+	Action      string // index、create、update 或 delete
+	Index       string // 实际写入的索引
+	DocumentID  string // 文档 ID
+	Result      string // created、updated、deleted、noop 或 not_found
+	Status      int    // HTTP 状态码
+	Version     int64  // 文档版本
+	ErrorType   string // 失败时的错误类型
+	ErrorReason string // 失败时的错误原因
+}
+
+// Failed 判断操作是否失败（响应中带有 error），删除不存在的文档（not_found）不算失败
+func (r BulkItemResult) Failed() bool {
+	return r.ErrorType != ""
+}
+
+// BulkError 批量操作中部分或全部操作失败
+type BulkError struct {
+	Total  int              // 操作总数
+	Failed []BulkItemResult // 失败的操作
+}
+
+// newBulkError 根据 Bulk 响应创建错误
+func newBulkError(result *BulkResult) *BulkError {
+	return &BulkError{Total: len(result.Items), Failed: result.Failed()}
+}
+
+// Error 实现 error 接口，只展示第一个失败操作的原因
+func (e *BulkError) Error() string {
+	if len(e.Failed) == 0 {
+		return fmt.Sprintf("elasticsearch bulk error: items failed out of %d", e.Total)
+	}
+	first := e.Failed[0]
+	return fmt.Sprintf("elasticsearch bulk error: %d of %d items failed, first: %s %s/%s [%d %s] %s",
+		len(e.Failed), e.Total, first.Action, first.Index, first.DocumentID, first.Status, first.ErrorType, first.ErrorReason)
+}
+
+// bulkResponse Bulk 响应的 JSON 结构
+type bulkResponse struct {
+	Took   int64                         `json:"took"`
+	Errors bool                          `json:"errors"`
+	Items  []map[string]bulkResponseItem `json:"items"`
+}
+
+// bulkResponseItem Bulk 响应中单个操作的结果
+type bulkResponseItem struct {
+	Index      string `json:"_index"`
+	DocumentID string `json:"_id"`
+	Result     string `json:"result"`
+	Status     int    `json:"status"`
+	Version    int64  `json:"_version"`
+	Error      *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// decodeBulkResult 解析 Bulk 响应
+func decodeBulkResult(body io.Reader) (*BulkResult, error) {
+	var res bulkResponse
+	if err := json.NewDecoder(body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	result := &BulkResult{Took: res.Took, Errors: res.Errors, Items: make([]BulkItemResult, 0, len(res.Items))}
+	for _, entry := range res.Items {
+		for action, item := range entry {
+			r := BulkItemResult{
+				Action:     action,
+				Index:      item.Index,
+				DocumentID: item.DocumentID,
+				Result:     item.Result,
+				Status:     item.Status,
+				Version:    item.Version,
+			}
+			if item.Error != nil {
+				r.ErrorType = item.Error.Type
+				r.ErrorReason = item.Error.Reason
+			}
+			result.Items = append(result.Items, r)
+		}
+	}
+	return result, nil
+}
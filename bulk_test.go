@@ -0,0 +1,57 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testBulkPartialFailure = `{"took":12,"errors":true,"items":[
+	{"index":{"_index":"orders","_id":"1","_version":1,"result":"created","status":201}},
+	{"create":{"_index":"orders","_id":"2","status":409,"error":{"type":"version_conflict_engine_exception","reason":"[2]: version conflict"}}},
+	{"delete":{"_index":"orders","_id":"3","_version":2,"result":"not_found","status":404}}]}`
+
+func TestBulkWithResult(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testBulkPartialFailure))
+	})
+
+	result, err := client.BulkWithResult(context.Background(), "{}\n")
+	if err != nil {
+		t.Fatalf("BulkWithResult() error = %v", err)
+	}
+	if result.Took != 12 || !result.Errors || len(result.Items) != 3 {
+		t.Fatalf("BulkWithResult() = %+v", result)
+	}
+	if result.Items[2].Action != "delete" || result.Items[2].Result != "not_found" || result.Items[2].Failed() {
+		t.Errorf("Items[2] = %+v", result.Items[2])
+	}
+	failed := result.Failed()
+	if len(failed) != 1 || failed[0].DocumentID != "2" || failed[0].ErrorType != "version_conflict_engine_exception" {
+		t.Errorf("Failed() = %+v", failed)
+	}
+
+	if err := client.Bulk(context.Background(), "{}\n"); err != nil {
+		t.Errorf("Bulk() error = %v, want nil without WithFailOnItemErrors", err)
+	}
+}
+
+func TestBulkFailOnItemErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testBulkPartialFailure))
+	})
+
+	result, err := client.BulkWithResult(context.Background(), "{}\n", WithFailOnItemErrors())
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("BulkWithResult() error = %v, want *BulkError", err)
+	}
+	if result == nil || bulkErr.Total != 3 || len(bulkErr.Failed) != 1 {
+		t.Errorf("BulkError = %+v, result = %v", bulkErr, result)
+	}
+	if !strings.Contains(err.Error(), "1 of 3 items failed, first: create orders/2 [409 version_conflict_engine_exception]") {
+		t.Errorf("Error() = %s", err)
+	}
+}
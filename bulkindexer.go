@@ -0,0 +1,282 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+)
+
+// BulkItem BulkIndexer 中的单个写入操作
+type BulkItem struct {
+	Action     string      // index、create、update 或 delete，默认 index
+	Index      string      // 目标索引，为空时使用 BulkIndexerOptions.Index
+	DocumentID string      // 文档 ID，index 操作为空时由服务端生成
+	Body       interface{} // 文档内容（struct、map、JSON 字符串或字节）；update 为完整请求体（如 {"doc": ...}）；delete 忽略
+
+	OnSuccess func(ctx context.Context, item BulkItem, res BulkItemResult)            // 单条成功回调，为 nil 时使用 BulkIndexerOptions.OnSuccess
+	OnFailure func(ctx context.Context, item BulkItem, res BulkItemResult, err error) // 单条失败回调，为 nil 时使用 BulkIndexerOptions.OnFailure
+}
+
+// BulkIndexerOptions BulkIndexer 选项
+type BulkIndexerOptions struct {
+	Index         string        // 默认目标索引
+	NumWorkers    int           // 并发发送 Bulk 请求的 worker 数，默认 CPU 核数
+	FlushBytes    int           // 缓冲区达到该字节数时发送，默认 5MB
+	FlushInterval time.Duration // 距上次发送超过该时间时发送，默认 30 秒
+	Refresh       string        // Bulk 请求的 refresh 参数（true、false、wait_for），为空时与单文档写入一致，使用 WithRefresh 或客户端的 Refresh 设置
+	Dedup         *ContentDedup // 设置后 index/create 操作按内容哈希设置文档 ID 和操作类型；DedupSkip 下的重复文档计入 Stats().Skipped，不调用失败回调
+
+	OnSuccess func(ctx context.Context, item BulkItem, res BulkItemResult)            // 默认单条成功回调
+	OnFailure func(ctx context.Context, item BulkItem, res BulkItemResult, err error) // 默认单条失败回调，整批请求失败时对批内每条调用
+	OnError   func(ctx context.Context, err error)                                    // 整批请求失败等 indexer 级别错误的回调
+}
+
+// BulkIndexerStats BulkIndexer 累计统计
+type BulkIndexerStats struct {
+	Added        uint64 // 已加入的操作数
+	Succeeded    uint64 // 写入成功的操作数
+	Failed       uint64 // 写入失败的操作数（含整批请求失败），不含 Skipped
+	Skipped      uint64 // Dedup 为 DedupSkip 时因内容重复被服务端拒绝（409）而跳过的操作数
+	Indexed      uint64 // 成功的 index 操作数
+	Created      uint64 // 成功的 create 操作数
+	Updated      uint64 // 成功的 update 操作数
+	Deleted      uint64 // 成功的 delete 操作数
+	Requests     uint64 // 发送的 Bulk 请求数
+	FlushedBytes uint64 // 发送的字节数
+}
+
+// BulkIndexer 按字节数和时间间隔自动分批的 Bulk 写入器，由多个 worker 并发发送。
+// 客户端关闭时会先刷新并关闭所有未关闭的 BulkIndexer
+type BulkIndexer struct {
+	client  *ElasticsearchClient
+	opts    BulkIndexerOptions
+	indexer esutil.BulkIndexer
+	skipped atomic.Uint64
+
+	mu       sync.RWMutex
+	closed   bool
+	closeErr error
+}
+
+// NewBulkIndexer 创建 BulkIndexer，使用完毕后调用 Close 发送剩余的操作。
+// ctx 只用于确定未设置 Refresh 时的刷新策略（WithRefresh），不控制 BulkIndexer 的生命周期
+func (c *ElasticsearchClient) NewBulkIndexer(ctx context.Context, opts *BulkIndexerOptions) (*BulkIndexer, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	b := &BulkIndexer{client: c}
+	if opts != nil {
+		b.opts = *opts
+	}
+	if b.opts.NumWorkers < 0 || b.opts.FlushBytes < 0 || b.opts.FlushInterval < 0 {
+		return nil, fmt.Errorf("bulk indexer options cannot be negative")
+	}
+	refresh, err := c.refreshPolicy(ctx, RefreshPolicy(b.opts.Refresh))
+	if err != nil {
+		return nil, err
+	}
+	b.opts.Refresh = refresh
+
+	indexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client:        c.client,
+		NumWorkers:    b.opts.NumWorkers,
+		FlushBytes:    b.opts.FlushBytes,
+		FlushInterval: b.opts.FlushInterval,
+		Refresh:       b.opts.Refresh,
+		OnError: func(ctx context.Context, err error) {
+			if b.opts.OnError != nil {
+				b.opts.OnError(ctx, redactError(err, c.secrets))
+			}
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk indexer: %w", err)
+	}
+	b.indexer = indexer
+
+	if err := c.lifecycle.track("bulk indexer", b.Close); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Add 将操作加入缓冲区，缓冲区满时阻塞直到 worker 取走。写入结果通过回调通知
+func (b *BulkIndexer) Add(ctx context.Context, item BulkItem) error {
+	if err := b.client.ready(); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return fmt.Errorf("bulk indexer is closed")
+	}
+
+	esItem, err := b.esItem(ctx, item)
+	if err != nil {
+		return err
+	}
+	return b.indexer.Add(ctx, esItem)
+}
+
+// esItem 将 BulkItem 转换为 esutil 的写入项
+func (b *BulkIndexer) esItem(ctx context.Context, item BulkItem) (esutil.BulkIndexerItem, error) {
+	if item.Action == "" {
+		item.Action = "index"
+	}
+	switch item.Action {
+	case "index", "create", "update", "delete":
+	default:
+		return esutil.BulkIndexerItem{}, fmt.Errorf("unsupported bulk action %q", item.Action)
+	}
+	if item.Index == "" {
+		item.Index = b.opts.Index
+	}
+	if item.Index == "" {
+		return esutil.BulkIndexerItem{}, fmt.Errorf("bulk item index cannot be empty")
+	}
+
+	body := item.Body
+	dedupSkip := false
+	if b.opts.Dedup != nil && (item.Action == "index" || item.Action == "create") {
+		documentID, opType, doc, err := b.opts.Dedup.Apply(body)
+		if err != nil {
+			return esutil.BulkIndexerItem{}, err
+		}
+		if documentID != "" {
+			item.DocumentID = documentID
+		}
+		item.Action = opType
+		body = doc
+		dedupSkip = b.opts.Dedup.Mode == DedupSkip
+	}
+	if item.Action == "index" || item.Action == "create" {
+		doc, err := b.client.denormalize(ctx, item.Index, body)
+		if err != nil {
+			return esutil.BulkIndexerItem{}, err
+		}
+		body = doc
+	}
+
+	esItem := esutil.BulkIndexerItem{
+		Index:      b.client.resolveIndexName(ctx, item.Index),
+		Action:     item.Action,
+		DocumentID: item.DocumentID,
+		OnSuccess: func(ctx context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem) {
+			if fn := b.onSuccess(item); fn != nil {
+				fn(ctx, item, newBulkItemResult(item.Action, res))
+			}
+		},
+		OnFailure: func(ctx context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+			// 内容重复的文档已存在，属于预期结果而非写入失败
+			if dedupSkip && err == nil && res.Status == http.StatusConflict {
+				b.skipped.Add(1)
+				return
+			}
+			fn := b.onFailure(item)
+			if fn == nil {
+				return
+			}
+			result := newBulkItemResult(item.Action, res)
+			if err != nil {
+				err = b.client.requestError("bulk", err)
+			} else {
+				err = fmt.Errorf("bulk %s failed: [%d %s] %s", item.Action, result.Status, result.ErrorType, result.ErrorReason)
+			}
+			fn(ctx, item, result, err)
+		},
+	}
+	if item.Action != "delete" {
+		bodyBytes, err := marshalDocument(body)
+		if err != nil {
+			return esutil.BulkIndexerItem{}, err
+		}
+		esItem.Body = bytes.NewReader(bodyBytes)
+	}
+	return esItem, nil
+}
+
+// onSuccess 返回单条成功回调
+func (b *BulkIndexer) onSuccess(item BulkItem) func(context.Context, BulkItem, BulkItemResult) {
+	if item.OnSuccess != nil {
+		return item.OnSuccess
+	}
+	return b.opts.OnSuccess
+}
+
+// onFailure 返回单条失败回调
+func (b *BulkIndexer) onFailure(item BulkItem) func(context.Context, BulkItem, BulkItemResult, error) {
+	if item.OnFailure != nil {
+		return item.OnFailure
+	}
+	return b.opts.OnFailure
+}
+
+// newBulkItemResult 转换 esutil 的单条响应
+func newBulkItemResult(action string, res esutil.BulkIndexerResponseItem) BulkItemResult {
+	return BulkItemResult{
+		Action:      action,
+		Index:       res.Index,
+		DocumentID:  res.DocumentID,
+		Result:      res.Result,
+		Status:      res.Status,
+		Version:     res.Version,
+		ErrorType:   res.Error.Type,
+		ErrorReason: res.Error.Reason,
+	}
+}
+
+// Close 发送缓冲区中剩余的操作并停止 worker，可重复调用
+func (b *BulkIndexer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return b.closeErr
+	}
+	b.closed = true
+	if err := b.indexer.Close(ctx); err != nil {
+		b.closeErr = fmt.Errorf("failed to close bulk indexer: %w", err)
+	}
+	return b.closeErr
+}
+
+// Stats 返回累计统计
+func (b *BulkIndexer) Stats() BulkIndexerStats {
+	stats := b.indexer.Stats()
+	skipped := b.skipped.Load()
+	return BulkIndexerStats{
+		Added:        stats.NumAdded,
+		Succeeded:    stats.NumFlushed,
+		Failed:       stats.NumFailed - skipped,
+		Skipped:      skipped,
+		Indexed:      stats.NumIndexed,
+		Created:      stats.NumCreated,
+		Updated:      stats.NumUpdated,
+		Deleted:      stats.NumDeleted,
+		Requests:     stats.NumRequests,
+		FlushedBytes: stats.FlushedBytes,
+	}
+}
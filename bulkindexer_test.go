@@ -0,0 +1,205 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// bulkTestHandler 解析 Bulk 请求并为每个操作返回结果，ID 为 "bad" 的文档返回 400
+func bulkTestHandler(t *testing.T, actions *[]map[string]map[string]interface{}, mu *sync.Mutex) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" {
+			t.Errorf("path = %s, want /_bulk", r.URL.Path)
+		}
+		var items []string
+		errors := false
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+				t.Fatalf("invalid bulk line: %s", scanner.Text())
+			}
+			mu.Lock()
+			*actions = append(*actions, action)
+			mu.Unlock()
+			for op, meta := range action {
+				if op != "delete" {
+					scanner.Scan()
+				}
+				id, _ := meta["_id"].(string)
+				if id == "bad" {
+					errors = true
+					items = append(items, fmt.Sprintf(`{%q:{"_index":%q,"_id":"bad","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}`, op, meta["_index"]))
+				} else {
+					items = append(items, fmt.Sprintf(`{%q:{"_index":%q,"_id":%q,"status":201,"result":"created"}}`, op, meta["_index"], id))
+				}
+			}
+		}
+		fmt.Fprintf(w, `{"errors":%t,"items":[%s]}`, errors, strings.Join(items, ","))
+	}
+}
+
+func TestBulkIndexer(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		actions []map[string]map[string]interface{}
+		failed  []string
+		success int
+	)
+	client := newTestClient(t, bulkTestHandler(t, &actions, &mu))
+
+	indexer, err := client.NewBulkIndexer(context.Background(), &BulkIndexerOptions{
+		Index:      "orders",
+		NumWorkers: 1,
+		OnSuccess: func(ctx context.Context, item BulkItem, res BulkItemResult) {
+			mu.Lock()
+			success++
+			mu.Unlock()
+		},
+		OnFailure: func(ctx context.Context, item BulkItem, res BulkItemResult, err error) {
+			mu.Lock()
+			failed = append(failed, res.ErrorType+":"+err.Error())
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewBulkIndexer() error = %v", err)
+	}
+
+	ctx := context.Background()
+	items := []BulkItem{
+		{DocumentID: "1", Body: map[string]interface{}{"status": "paid"}},
+		{Index: "archive", DocumentID: "bad", Body: `{"status":1}`},
+		{Action: "delete", DocumentID: "2"},
+	}
+	for _, item := range items {
+		if err := indexer.Add(ctx, item); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if err := indexer.Add(ctx, BulkItem{Action: "upsert", DocumentID: "3"}); err == nil {
+		t.Error("Add() with unsupported action should return error")
+	}
+	if err := indexer.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	stats := indexer.Stats()
+	if stats.Added != 3 || stats.Succeeded != 2 || stats.Failed != 1 || stats.Requests != 1 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if success != 2 || len(failed) != 1 || !strings.HasPrefix(failed[0], "mapper_parsing_exception:bulk index failed: [400") {
+		t.Errorf("success = %d, failed = %v", success, failed)
+	}
+	if actions[1]["index"]["_index"] != "archive" {
+		t.Errorf("second action = %v, want archive index", actions[1])
+	}
+	if err := indexer.Add(ctx, items[0]); err == nil {
+		t.Error("Add() after Close should return error")
+	}
+}
+
+func TestBulkIndexerDedupAndClientClose(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		actions []map[string]map[string]interface{}
+	)
+	client := newTestClient(t, bulkTestHandler(t, &actions, &mu))
+
+	dedup := &ContentDedup{Mode: DedupSkip}
+	indexer, err := client.NewBulkIndexer(context.Background(), &BulkIndexerOptions{Index: "events", Dedup: dedup})
+	if err != nil {
+		t.Fatalf("NewBulkIndexer() error = %v", err)
+	}
+	doc := map[string]interface{}{"message": "hello"}
+	if err := indexer.Add(context.Background(), BulkItem{Body: doc}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// 关闭客户端时刷新未关闭的 BulkIndexer
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	hash, _ := dedup.Hash(doc)
+	if len(actions) != 1 || actions[0]["create"]["_id"] != hash {
+		t.Errorf("actions = %v, want create with content hash ID", actions)
+	}
+	if indexer.Stats().Created != 1 {
+		t.Errorf("Stats() = %+v", indexer.Stats())
+	}
+}
+
+func TestBulkIndexerDedupSkipConflict(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var items []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]map[string]interface{}
+			json.Unmarshal(scanner.Bytes(), &action)
+			scanner.Scan()
+			for op, meta := range action {
+				items = append(items, fmt.Sprintf(`{%q:{"_index":"events","_id":%q,"status":409,"error":{"type":"version_conflict_engine_exception","reason":"document already exists"}}}`, op, meta["_id"]))
+			}
+		}
+		fmt.Fprintf(w, `{"errors":true,"items":[%s]}`, strings.Join(items, ","))
+	})
+
+	var failures int
+	indexer, err := client.NewBulkIndexer(context.Background(), &BulkIndexerOptions{
+		Index: "events",
+		Dedup: &ContentDedup{Mode: DedupSkip},
+		OnFailure: func(context.Context, BulkItem, BulkItemResult, error) {
+			failures++
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewBulkIndexer() error = %v", err)
+	}
+	if err := indexer.Add(context.Background(), BulkItem{Body: map[string]interface{}{"message": "hello"}}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if stats := indexer.Stats(); stats.Skipped != 1 || stats.Failed != 0 || failures != 0 {
+		t.Errorf("Stats() = %+v, failures = %d, want the duplicate skipped", stats, failures)
+	}
+}
+
+func TestBulkIndexerRefreshDefault(t *testing.T) {
+	refresh := make(chan string, 2)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		refresh <- r.URL.Query().Get("refresh")
+		w.Write([]byte(`{"errors":false,"items":[{"index":{"_index":"orders","_id":"1","status":201}}]}`))
+	})
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		want string
+	}{
+		{context.Background(), string(DefaultRefreshPolicy)},
+		{WithRefresh(context.Background(), RefreshWaitFor), string(RefreshWaitFor)},
+	} {
+		indexer, err := client.NewBulkIndexer(tc.ctx, &BulkIndexerOptions{Index: "orders", NumWorkers: 1})
+		if err != nil {
+			t.Fatalf("NewBulkIndexer() error = %v", err)
+		}
+		if err := indexer.Add(context.Background(), BulkItem{DocumentID: "1", Body: map[string]interface{}{"a": 1}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Close(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if got := <-refresh; got != tc.want {
+			t.Errorf("refresh = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ErrDestructiveOpsDisabled 客户端未配置 DestructiveOpsToken 或传入的令牌不匹配
+var ErrDestructiveOpsDisabled = errors.New("destructive operations are disabled")
+
+// deleteIndicesBatchSize 每个删除请求包含的索引数，避免 URL 过长
+const deleteIndicesBatchSize = 100
+
+// checkDestructiveOps 检查客户端是否配置了 DestructiveOpsToken，且调用方传入的令牌与之一致
+func (c *ElasticsearchClient) checkDestructiveOps(safetyToken string) error {
+	if c.destructiveOpsToken == "" {
+		return fmt.Errorf("%w: DestructiveOpsToken is not configured", ErrDestructiveOpsDisabled)
+	}
+	if subtle.ConstantTimeCompare([]byte(safetyToken), []byte(c.destructiveOpsToken)) != 1 {
+		return fmt.Errorf("%w: safety token does not match", ErrDestructiveOpsDisabled)
+	}
+	return nil
+}
+
+// ResetFeatures 将所有系统功能（如安全、机器学习、Watcher、异步搜索）恢复到初始状态并删除其系统索引，
+// 返回已重置的功能名称。用于在 CI 运行之间重置共享的测试集群。safetyToken 必须与客户端配置的
+// DestructiveOpsToken 一致，未配置令牌的客户端始终返回 ErrDestructiveOpsDisabled
+func (c *ElasticsearchClient) ResetFeatures(ctx context.Context, safetyToken string) ([]string, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if err := c.checkDestructiveOps(safetyToken); err != nil {
+		return nil, err
+	}
+
+	var reset []string
+	err := c.audit(ctx, "reset_features", "", nil, func(ctx context.Context) error {
+		return executeWithTrace(
+			ctx,
+			"reset_features",
+			"",
+			"",
+			c.EnableTrace,
+			c.latency,
+			func(ctx context.Context) error {
+				req := esapi.FeaturesResetFeaturesRequest{}
+				res, err := req.Do(ctx, c.client)
+				if err != nil {
+					return c.requestError("reset features", err)
+				}
+				defer res.Body.Close()
+
+				if res.IsError() {
+					return c.responseError("reset features", res)
+				}
+
+				var result struct {
+					Features []struct {
+						FeatureName string `json:"feature_name"`
+						Status      string `json:"status"`
+					} `json:"features"`
+				}
+				if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+					return fmt.Errorf("failed to decode response: %w", err)
+				}
+
+				var failed []string
+				for _, feature := range result.Features {
+					if feature.Status != "SUCCESS" {
+						failed = append(failed, feature.FeatureName)
+						continue
+					}
+					reset = append(reset, feature.FeatureName)
+				}
+				if len(failed) > 0 {
+					return fmt.Errorf("failed to reset features: %s", strings.Join(failed, ", "))
+				}
+				return nil
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
+
+// DeleteAllIndices 删除名称匹配 pattern（支持通配符和逗号分隔的多个模式）的所有索引，返回已删除的索引名。
+// 隐藏索引、系统索引和数据流的后备索引会被跳过。safetyToken 必须与客户端配置的 DestructiveOpsToken 一致，
+// 未配置令牌的客户端（如生产环境）始终返回 ErrDestructiveOpsDisabled
+func (c *ElasticsearchClient) DeleteAllIndices(ctx context.Context, pattern string, safetyToken string) ([]string, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if err := c.checkDestructiveOps(safetyToken); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(pattern) == "" {
+		return nil, fmt.Errorf("delete all indices requires a non-empty pattern")
+	}
+
+	var deleted []string
+	err := c.audit(ctx, "delete_all_indices", pattern, nil, func(ctx context.Context) error {
+		indices, err := c.resolveCleanupIndices(ctx, pattern)
+		if err != nil {
+			return err
+		}
+
+		// 按具体名称删除，不依赖集群的 action.destructive_requires_name 设置
+		for start := 0; start < len(indices); start += deleteIndicesBatchSize {
+			batch := indices[start:min(start+deleteIndicesBatchSize, len(indices))]
+			err := executeWithTrace(
+				ctx,
+				"delete_all_indices",
+				pattern,
+				"",
+				c.EnableTrace,
+				c.latency,
+				func(ctx context.Context) error {
+					req := esapi.IndicesDeleteRequest{Index: batch}
+					res, err := req.Do(ctx, c.client)
+					if err != nil {
+						return c.requestError("delete all indices", err)
+					}
+					defer res.Body.Close()
+
+					if res.IsError() {
+						return c.responseError("delete all indices", res)
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+			deleted = append(deleted, batch...)
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// resolveCleanupIndices 返回 pattern 匹配的可删除索引，跳过隐藏索引、系统索引和数据流的后备索引
+func (c *ElasticsearchClient) resolveCleanupIndices(ctx context.Context, pattern string) ([]string, error) {
+	allowNoIndices := true
+	req := esapi.IndicesResolveIndexRequest{
+		Name:            strings.Split(pattern, ","),
+		AllowNoIndices:  &allowNoIndices,
+		ExpandWildcards: "open,closed",
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("resolve index", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("resolve index", res)
+	}
+
+	var result struct {
+		Indices []struct {
+			Name       string   `json:"name"`
+			Attributes []string `json:"attributes"`
+			DataStream string   `json:"data_stream"`
+		} `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	var indices []string
+	for _, index := range result.Indices {
+		if index.DataStream != "" || strings.HasPrefix(index.Name, ".") || hasAttribute(index.Attributes, "hidden", "system") {
+			continue
+		}
+		indices = append(indices, index.Name)
+	}
+	sort.Strings(indices)
+	return indices, nil
+}
+
+// hasAttribute 判断属性列表是否包含任一给定属性
+func hasAttribute(attributes []string, names ...string) bool {
+	for _, attribute := range attributes {
+		for _, name := range names {
+			if attribute == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDestructiveOpsGate(t *testing.T) {
+	var calls int
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	ctx := context.Background()
+
+	disabled := newTestClient(t, handler)
+	if _, err := disabled.ResetFeatures(ctx, ""); !errors.Is(err, ErrDestructiveOpsDisabled) {
+		t.Errorf("ResetFeatures() error = %v, want ErrDestructiveOpsDisabled", err)
+	}
+	if _, err := disabled.DeleteAllIndices(ctx, "ci-*", ""); !errors.Is(err, ErrDestructiveOpsDisabled) {
+		t.Errorf("DeleteAllIndices() error = %v, want ErrDestructiveOpsDisabled", err)
+	}
+
+	enabled := newTestClient(t, handler, func(o *Options) { o.DestructiveOpsToken = "ci-only" })
+	if _, err := enabled.ResetFeatures(ctx, "wrong"); !errors.Is(err, ErrDestructiveOpsDisabled) {
+		t.Errorf("ResetFeatures(wrong token) error = %v, want ErrDestructiveOpsDisabled", err)
+	}
+	if _, err := enabled.DeleteAllIndices(ctx, "ci-*", "wrong"); !errors.Is(err, ErrDestructiveOpsDisabled) {
+		t.Errorf("DeleteAllIndices(wrong token) error = %v, want ErrDestructiveOpsDisabled", err)
+	}
+	if _, err := enabled.DeleteAllIndices(ctx, " ", "ci-only"); err == nil {
+		t.Error("DeleteAllIndices(empty pattern) error = nil")
+	}
+	if calls != 0 {
+		t.Errorf("requests sent = %d, want 0", calls)
+	}
+}
+
+func TestResetFeatures(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_features/_reset" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"features":[{"feature_name":"security","status":"SUCCESS"},{"feature_name":"machine_learning","status":"SUCCESS"}]}`))
+	}, func(o *Options) { o.DestructiveOpsToken = "ci-only" })
+
+	reset, err := client.ResetFeatures(context.Background(), "ci-only")
+	if err != nil {
+		t.Fatalf("ResetFeatures() error = %v", err)
+	}
+	if want := []string{"security", "machine_learning"}; !reflect.DeepEqual(reset, want) {
+		t.Errorf("ResetFeatures() = %v, want %v", reset, want)
+	}
+}
+
+func TestDeleteAllIndices(t *testing.T) {
+	var deletedPath string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/_resolve/index/ci-*":
+			w.Write([]byte(`{"indices":[
+				{"name":"ci-orders","attributes":["open"]},
+				{"name":"ci-closed","attributes":["closed"]},
+				{"name":"ci-hidden","attributes":["hidden","open"]},
+				{"name":".ds-ci-logs-000001","attributes":["hidden","open"],"data_stream":"ci-logs"}
+			],"aliases":[],"data_streams":[{"name":"ci-logs"}]}`))
+		case r.Method == http.MethodDelete:
+			deletedPath = r.URL.Path
+			w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, func(o *Options) { o.DestructiveOpsToken = "ci-only" })
+
+	deleted, err := client.DeleteAllIndices(context.Background(), "ci-*", "ci-only")
+	if err != nil {
+		t.Fatalf("DeleteAllIndices() error = %v", err)
+	}
+	if want := []string{"ci-closed", "ci-orders"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("DeleteAllIndices() = %v, want %v", deleted, want)
+	}
+	if deletedPath != "/ci-closed,ci-orders" {
+		t.Errorf("DeleteAllIndices() path = %q", deletedPath)
+	}
+}
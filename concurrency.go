@@ -0,0 +1,98 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+// 外部版本号类型，配合 WithVersion 和 WithDeleteVersion 使用
+const (
+	VersionTypeExternal    = "external"     // 新版本号必须大于当前版本号
+	VersionTypeExternalGte = "external_gte" // 新版本号必须大于或等于当前版本号
+)
+
+// concurrencyControl 乐观并发控制条件，条件不满足时服务端返回 409 版本冲突
+type concurrencyControl struct {
+	ifSeqNo       *int
+	ifPrimaryTerm *int
+	version       *int
+	versionType   string
+}
+
+// setIfSeqNo 设置 if_seq_no 和 if_primary_term 条件
+func (cc *concurrencyControl) setIfSeqNo(seqNo, primaryTerm int64) {
+	s, p := int(seqNo), int(primaryTerm)
+	cc.ifSeqNo, cc.ifPrimaryTerm = &s, &p
+}
+
+// setVersion 设置外部版本号
+func (cc *concurrencyControl) setVersion(version int64, versionType string) {
+	v := int(version)
+	cc.version, cc.versionType = &v, versionType
+}
+
+// IndexOption 单次索引的选项
+type IndexOption func(*indexConfig)
+
+// indexConfig 单次索引的配置
+type indexConfig struct {
+	refresh RefreshPolicy
+	concurrencyControl
+}
+
+// newIndexConfig 应用索引选项
+func newIndexConfig(opts []IndexOption) *indexConfig {
+	cfg := &indexConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithIfSeqNo 仅当文档当前的 _seq_no 和 _primary_term 与给定值一致时才写入，
+// 用于读-改-写场景下防止覆盖并发修改，取值来自 Get 或上一次写入的结果
+func WithIfSeqNo(seqNo, primaryTerm int64) IndexOption {
+	return func(cfg *indexConfig) {
+		cfg.setIfSeqNo(seqNo, primaryTerm)
+	}
+}
+
+// WithVersion 使用外部版本号写入（versionType 为 VersionTypeExternal 或 VersionTypeExternalGte），
+// 适用于以其他系统为数据源、版本号由数据源维护的同步场景
+func WithVersion(version int64, versionType string) IndexOption {
+	return func(cfg *indexConfig) {
+		cfg.setVersion(version, versionType)
+	}
+}
+
+// WithUpdateIfSeqNo 仅当文档当前的 _seq_no 和 _primary_term 与给定值一致时才更新
+func WithUpdateIfSeqNo(seqNo, primaryTerm int64) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.setIfSeqNo(seqNo, primaryTerm)
+	}
+}
+
+// WithDeleteIfSeqNo 仅当文档当前的 _seq_no 和 _primary_term 与给定值一致时才删除
+func WithDeleteIfSeqNo(seqNo, primaryTerm int64) DeleteOption {
+	return func(cfg *deleteConfig) {
+		cfg.setIfSeqNo(seqNo, primaryTerm)
+	}
+}
+
+// WithDeleteVersion 使用外部版本号删除，版本号不满足 versionType 的要求时返回版本冲突
+func WithDeleteVersion(version int64, versionType string) DeleteOption {
+	return func(cfg *deleteConfig) {
+		cfg.setVersion(version, versionType)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// CountMany 在一次 msearch 请求中统计多个查询的文档数量，结果与 queries 一一对应，
+// 适用于一个页面展示大量计数的场景。任意一个查询失败时返回错误
+func (c *ElasticsearchClient) CountMany(ctx context.Context, index string, queries []map[string]interface{}) ([]int64, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var counts []int64
+	err := executeWithTrace(
+		ctx,
+		"count_many",
+		index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			counts, err = c.countMany(ctx, index, queries)
+			return err
+		},
+	)
+	return counts, err
+}
+
+// countMany 内部批量计数方法
+func (c *ElasticsearchClient) countMany(ctx context.Context, index string, queries []map[string]interface{}) ([]int64, error) {
+	if len(queries) == 0 {
+		return []int64{}, nil
+	}
+
+	header := map[string]interface{}{}
+	if allow := c.allowPartialSearchResults(ctx); allow != nil {
+		header["allow_partial_search_results"] = *allow
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal msearch header: %w", err)
+	}
+
+	var buf strings.Builder
+	for i, query := range queries {
+		query, err := c.applyDocumentFilter(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.limits.checkQuery(query); err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+
+		body := make(map[string]interface{}, len(query)+2)
+		for k, v := range query {
+			body[k] = v
+		}
+		body["size"] = 0
+		body["track_total_hits"] = true
+
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal query %d: %w", i, err)
+		}
+		if err := c.limits.checkBytes(len(bodyBytes)); err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+		buf.Write(headerBytes)
+		buf.WriteByte('\n')
+		buf.Write(bodyBytes)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.MsearchRequest{
+		Index: []string{index},
+		Body:  strings.NewReader(buf.String()),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("count many", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("count many", res)
+	}
+
+	var result struct {
+		Responses []struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+			Hits   struct {
+				Total struct {
+					Value int64 `json:"value"`
+				} `json:"total"`
+			} `json:"hits"`
+		} `json:"responses"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Responses) != len(queries) {
+		return nil, fmt.Errorf("msearch returned %d responses for %d queries", len(result.Responses), len(queries))
+	}
+
+	counts := make([]int64, len(queries))
+	for i, item := range result.Responses {
+		if len(item.Error) > 0 {
+			return nil, redactError(fmt.Errorf("count query %d failed: [%d] %s", i, item.Status, item.Error), c.secrets)
+		}
+		counts[i] = item.Hits.Total.Value
+	}
+	return counts, nil
+}
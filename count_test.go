@@ -0,0 +1,54 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCountMany(t *testing.T) {
+	var bodies []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/_msearch" {
+			t.Errorf("path = %s, want /orders/_msearch", r.URL.Path)
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for line := 0; scanner.Scan(); line++ {
+			if line%2 == 0 {
+				continue
+			}
+			var body map[string]interface{}
+			json.Unmarshal(scanner.Bytes(), &body)
+			bodies = append(bodies, body)
+		}
+		w.Write([]byte(`{"responses":[{"status":200,"hits":{"total":{"value":12}}},{"status":200,"hits":{"total":{"value":0}}}]}`))
+	})
+
+	counts, err := client.CountMany(context.Background(), "orders", []map[string]interface{}{
+		SearchBody(Term("status", "paid")),
+		SearchBody(Term("status", "refunded")),
+	})
+	if err != nil {
+		t.Fatalf("CountMany() error = %v", err)
+	}
+	if len(counts) != 2 || counts[0] != 12 || counts[1] != 0 {
+		t.Errorf("CountMany() = %v, want [12 0]", counts)
+	}
+	if len(bodies) != 2 || bodies[0]["size"] != float64(0) || bodies[0]["track_total_hits"] != true {
+		t.Errorf("msearch bodies = %v", bodies)
+	}
+}
+
+func TestCountManyItemError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"responses":[{"status":200,"hits":{"total":{"value":1}}},{"status":400,"error":{"type":"query_shard_exception"}}]}`))
+	})
+
+	_, err := client.CountMany(context.Background(), "orders", []map[string]interface{}{{}, {}})
+	if err == nil || !strings.Contains(err.Error(), "count query 1 failed") {
+		t.Fatalf("CountMany() error = %v, want item error", err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// defaultDebugHTTPMaxBodyBytes 调试日志中请求体和响应体的默认截断长度
+const defaultDebugHTTPMaxBodyBytes = 4096
+
+// debugHTTPLogger 通过框架日志在 DEBUG 级别输出每次 HTTP 往返，实现 elastictransport.Logger。
+// 不记录请求头，请求体和响应体按长度截断并剔除凭据
+type debugHTTPLogger struct {
+	maxBody int
+	secrets []string
+}
+
+// newDebugHTTPLogger 创建调试日志记录器
+func newDebugHTTPLogger(maxBody int, secrets []string) *debugHTTPLogger {
+	if maxBody <= 0 {
+		maxBody = defaultDebugHTTPMaxBodyBytes
+	}
+	return &debugHTTPLogger{maxBody: maxBody, secrets: secrets}
+}
+
+// LogRoundTrip 记录一次 HTTP 往返
+func (l *debugHTTPLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	ctx := context.Background()
+	if req != nil {
+		ctx = req.Context()
+	}
+	logger := log.FromContext(ctx)
+	if !logger.Core().Enabled(zap.DebugLevel) {
+		l.drain(req, res)
+		return nil
+	}
+
+	logger.Debug("Elasticsearch HTTP round trip", l.fields(req, res, err, dur)...)
+	return nil
+}
+
+// fields 构建一次 HTTP 往返的日志字段
+func (l *debugHTTPLogger) fields(req *http.Request, res *http.Response, err error, dur time.Duration) []zap.Field {
+	fields := []zap.Field{zap.Duration("duration", dur)}
+	if req != nil {
+		fields = append(fields,
+			zap.String("method", req.Method),
+			zap.String("url", redactSecrets(redactURL(req.URL.String()), l.secrets)),
+		)
+		if body := l.readBody(req.Body); body != "" {
+			fields = append(fields, zap.String("request_body", body))
+		}
+	}
+	if res != nil && res.StatusCode != 0 {
+		fields = append(fields, zap.Int("status", res.StatusCode))
+		if body := l.readBody(res.Body); body != "" {
+			fields = append(fields, zap.String("response_body", body))
+		}
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", redactSecrets(err.Error(), l.secrets)))
+	}
+	return fields
+}
+
+// RequestBodyEnabled 让传输层传入请求体副本
+func (l *debugHTTPLogger) RequestBodyEnabled() bool { return true }
+
+// ResponseBodyEnabled 让传输层传入响应体副本
+func (l *debugHTTPLogger) ResponseBodyEnabled() bool { return true }
+
+// readBody 读取并关闭 body，超过上限时截断。多读取最长敏感信息的长度，
+// 使从上限之前开始的敏感信息完整出现在读取的内容中，先脱敏再截断
+func (l *debugHTTPLogger) readBody(body io.ReadCloser) string {
+	if body == nil || body == http.NoBody {
+		return ""
+	}
+	defer body.Close()
+
+	window := l.maxBody
+	for _, secret := range l.secrets {
+		window = max(window, l.maxBody+len(secret))
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, int64(window)+1))
+	text, truncated := redactTruncate(data, l.maxBody, l.secrets)
+	if len(data) > window {
+		truncated = true
+	}
+	if truncated {
+		text += "...(truncated)"
+	}
+	return text
+}
+
+// drain 关闭未读取的 body 副本
+func (l *debugHTTPLogger) drain(req *http.Request, res *http.Response) {
+	if req != nil && req.Body != nil {
+		req.Body.Close()
+	}
+	if res != nil && res.Body != nil {
+		res.Body.Close()
+	}
+}
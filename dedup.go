@@ -0,0 +1,139 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultDedupField 默认的内容哈希字段
+const DefaultDedupField = "content_hash"
+
+// DedupMode 内容哈希去重方式
+type DedupMode int
+
+const (
+	// DedupSkip 哈希作为文档 ID 并以 create 写入，重复文档被服务端拒绝（409），保留首次写入的版本；
+	// BulkIndexer 将这类 409 计入 Stats().Skipped 而不是写入失败
+	DedupSkip DedupMode = iota
+	// DedupOverwrite 哈希作为文档 ID 并以 index 写入，重复文档覆盖已有版本
+	DedupOverwrite
+	// DedupFieldOnly 仅将哈希写入 Field 字段，不改变文档 ID，由查询或后续任务去重
+	DedupFieldOnly
+)
+
+// ContentDedup 基于内容哈希的写入去重，用于至少一次投递的数据管道
+type ContentDedup struct {
+	Fields []string  // 参与哈希的顶层字段，为空时使用整个文档（不含 Field 字段）
+	Mode   DedupMode // 去重方式
+	Field  string    // 写入哈希的字段，默认 content_hash，所有模式下都会写入
+}
+
+// Hash 计算文档的内容哈希（SHA-256），字段按名称排序后编码，与字段顺序无关
+func (d *ContentDedup) Hash(body interface{}) (string, error) {
+	doc, err := documentMap(body)
+	if err != nil {
+		return "", err
+	}
+	return d.hash(doc)
+}
+
+// Apply 计算内容哈希并返回写入所需的文档 ID、操作类型（create/index）和补充哈希字段后的文档
+func (d *ContentDedup) Apply(body interface{}) (documentID string, opType string, doc map[string]interface{}, err error) {
+	doc, err = documentMap(body)
+	if err != nil {
+		return "", "", nil, err
+	}
+	hash, err := d.hash(doc)
+	if err != nil {
+		return "", "", nil, err
+	}
+	doc[d.field()] = hash
+
+	switch d.Mode {
+	case DedupSkip:
+		return hash, "create", doc, nil
+	case DedupOverwrite:
+		return hash, "index", doc, nil
+	default:
+		return "", "index", doc, nil
+	}
+}
+
+// BulkLines 生成单个文档的 Bulk NDJSON（操作行和文档行），可拼接后传给 Bulk
+func (d *ContentDedup) BulkLines(index string, body interface{}) (string, error) {
+	documentID, opType, doc, err := d.Apply(body)
+	if err != nil {
+		return "", err
+	}
+
+	meta := map[string]interface{}{"_index": index}
+	if documentID != "" {
+		meta["_id"] = documentID
+	}
+	action, err := json.Marshal(map[string]interface{}{opType: meta})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bulk action: %w", err)
+	}
+	source, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	var b strings.Builder
+	b.Write(action)
+	b.WriteByte('\n')
+	b.Write(source)
+	b.WriteByte('\n')
+	return b.String(), nil
+}
+
+// field 返回写入哈希的字段
+func (d *ContentDedup) field() string {
+	if d.Field != "" {
+		return d.Field
+	}
+	return DefaultDedupField
+}
+
+// hash 计算已转换为 map 的文档哈希
+func (d *ContentDedup) hash(doc map[string]interface{}) (string, error) {
+	selected := make(map[string]interface{}, len(doc))
+	if len(d.Fields) == 0 {
+		for k, v := range doc {
+			if k != d.field() {
+				selected[k] = v
+			}
+		}
+	} else {
+		for _, field := range d.Fields {
+			selected[field] = doc[field]
+		}
+	}
+
+	// encoding/json 对 map 键排序，保证同一内容得到相同的编码
+	data, err := json.Marshal(selected)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal document for hashing: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
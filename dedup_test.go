@@ -0,0 +1,69 @@
+package elasticsearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContentDedupHash(t *testing.T) {
+	d := &ContentDedup{Fields: []string{"event_id", "type"}}
+
+	h1, err := d.Hash(map[string]interface{}{"event_id": "e1", "type": "click", "received_at": "t1"})
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	h2, _ := d.Hash(`{"type":"click","event_id":"e1","received_at":"t2"}`)
+	if h1 != h2 {
+		t.Errorf("Hash() differs for documents with same selected fields: %s != %s", h1, h2)
+	}
+	h3, _ := d.Hash(map[string]interface{}{"event_id": "e2", "type": "click"})
+	if h1 == h3 {
+		t.Error("Hash() should differ for different content")
+	}
+}
+
+func TestContentDedupHashIgnoresHashField(t *testing.T) {
+	d := &ContentDedup{}
+	doc := map[string]interface{}{"a": 1}
+	h1, _ := d.Hash(doc)
+	_, _, withHash, _ := d.Apply(doc)
+	h2, _ := d.Hash(withHash)
+	if h1 != h2 {
+		t.Error("Hash() should ignore the hash field itself")
+	}
+}
+
+func TestContentDedupBulkLines(t *testing.T) {
+	doc := map[string]interface{}{"event_id": "e1"}
+	tests := []struct {
+		mode       DedupMode
+		wantAction string
+		wantID     bool
+	}{
+		{DedupSkip, `{"create":`, true},
+		{DedupOverwrite, `{"index":`, true},
+		{DedupFieldOnly, `{"index":`, false},
+	}
+	for _, tt := range tests {
+		d := &ContentDedup{Mode: tt.mode}
+		hash, _ := d.Hash(doc)
+
+		lines, err := d.BulkLines("events", doc)
+		if err != nil {
+			t.Fatalf("BulkLines() error = %v", err)
+		}
+		parts := strings.Split(strings.TrimSuffix(lines, "\n"), "\n")
+		if len(parts) != 2 {
+			t.Fatalf("BulkLines() = %q, want 2 lines", lines)
+		}
+		if !strings.HasPrefix(parts[0], tt.wantAction) {
+			t.Errorf("mode %d action = %s, want prefix %s", tt.mode, parts[0], tt.wantAction)
+		}
+		if got := strings.Contains(parts[0], `"_id":"`+hash+`"`); got != tt.wantID {
+			t.Errorf("mode %d action = %s, want id %v", tt.mode, parts[0], tt.wantID)
+		}
+		if !strings.Contains(parts[1], `"content_hash":"`+hash+`"`) {
+			t.Errorf("mode %d source = %s, want content_hash", tt.mode, parts[1])
+		}
+	}
+}
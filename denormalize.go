@@ -0,0 +1,145 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DenormalizeSpec 反范式化规则：写入 Index 的文档时，按 RefField 中的 ID 读取 RefIndex 中的引用文档，
+// 将其 Fields 复制到 Target 字段下，查询时无需关联查询。
+// 规则作用于写入完整文档的路径：Index、IndexWithResult、IndexAutoID、PutIfAbsent 和 BulkIndexer 的 index/create 操作；
+// Bulk（原始 NDJSON 请求体）和 Update 系列的部分更新不会嵌入引用字段，需要调用 ResyncDenormalized 同步
+type DenormalizeSpec struct {
+	Index         string   // 写入的（逻辑）索引名
+	RefField      string   // 文档中保存引用文档 ID 的顶层字段
+	RefIndex      string   // 引用文档所在的（逻辑）索引名
+	Fields        []string // 从引用文档 _source 复制的顶层字段，为空时复制全部字段
+	Target        string   // 嵌入复制字段的对象字段名
+	IgnoreMissing bool     // 引用文档不存在时不嵌入而不是返回错误
+}
+
+// validate 检查规则是否完整
+func (s *DenormalizeSpec) validate() error {
+	if s.Index == "" || s.RefField == "" || s.RefIndex == "" || s.Target == "" {
+		return fmt.Errorf("elasticsearch denormalize spec requires Index, RefField, RefIndex and Target")
+	}
+	return nil
+}
+
+// denormalize 按规则为写入 index 的文档嵌入引用文档的字段，没有匹配规则时原样返回
+func (c *ElasticsearchClient) denormalize(ctx context.Context, index string, body interface{}) (interface{}, error) {
+	var doc map[string]interface{}
+	for i := range c.denormalizeSpecs {
+		spec := &c.denormalizeSpecs[i]
+		if spec.Index != index {
+			continue
+		}
+		if doc == nil {
+			var err error
+			if doc, err = documentMap(body); err != nil {
+				return nil, err
+			}
+		}
+		ref, ok := doc[spec.RefField]
+		if !ok || ref == nil {
+			continue
+		}
+		embedded, err := c.fetchDenormalized(ctx, spec, fmt.Sprint(ref))
+		if err != nil {
+			return nil, err
+		}
+		if embedded != nil {
+			doc[spec.Target] = embedded
+		}
+	}
+	if doc == nil {
+		return body, nil
+	}
+	return doc, nil
+}
+
+// fetchDenormalized 读取引用文档并返回需要嵌入的字段，文档不存在且 IgnoreMissing 时返回 nil。
+// 引用文档按索引中存储的原始 _source 读取，不经过 ReadTransformer，避免解密或脱敏后的字段被复制到其他索引
+func (c *ElasticsearchClient) fetchDenormalized(ctx context.Context, spec *DenormalizeSpec, refID string) (map[string]interface{}, error) {
+	doc, err := c.getRaw(ctx, c.resolveIndex(ctx, spec.RefIndex), refID)
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
+	if err != nil || !doc.Found {
+		if spec.IgnoreMissing {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("referenced document %s/%s not found", spec.RefIndex, refID)
+	}
+
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(doc.Source, &source); err != nil {
+		return nil, fmt.Errorf("failed to decode referenced document: %w", err)
+	}
+	embedded := make(map[string]interface{}, len(source))
+	if len(spec.Fields) == 0 {
+		for field, value := range source {
+			embedded[field] = value
+		}
+		return embedded, nil
+	}
+	for _, field := range spec.Fields {
+		if value, ok := source[field]; ok {
+			embedded[field] = value
+		}
+	}
+	return embedded, nil
+}
+
+// ResyncDenormalized 引用文档变化后，通过 UpdateByQuery 刷新所有引用它的文档中嵌入的字段，
+// 返回更新的文档数。引用文档已删除时移除嵌入的字段
+func (c *ElasticsearchClient) ResyncDenormalized(ctx context.Context, refIndex string, refID string) (int64, error) {
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	for i := range c.denormalizeSpecs {
+		spec := c.denormalizeSpecs[i]
+		if spec.RefIndex != refIndex {
+			continue
+		}
+		spec.IgnoreMissing = true
+		embedded, err := c.fetchDenormalized(ctx, &spec, refID)
+		if err != nil {
+			return updated, err
+		}
+
+		script := map[string]interface{}{
+			"source": "if (params.value == null) { ctx._source.remove(params.target) } else { ctx._source[params.target] = params.value }",
+			"lang":   "painless",
+			"params": map[string]interface{}{"target": spec.Target, "value": embedded},
+		}
+		query := map[string]interface{}{"term": map[string]interface{}{spec.RefField: refID}}
+		result, err := c.UpdateByQuery(ctx, spec.Index, query, script)
+		if err != nil {
+			return updated, fmt.Errorf("failed to resync %s: %w", spec.Index, err)
+		}
+		if n, ok := result["updated"].(float64); ok {
+			updated += int64(n)
+		}
+	}
+	return updated, nil
+}
@@ -0,0 +1,77 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDenormalize(t *testing.T) {
+	var indexed, resync map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.URL.Path == "/customers/_doc/c1":
+			w.Write([]byte(`{"_id":"c1","_index":"customers","found":true,"_source":{"name":"Alice","tier":"gold","email":"a@example.com"}}`))
+		case r.URL.Path == "/customers/_doc/c2":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"_id":"c2","_index":"customers","found":false}`))
+		case strings.HasPrefix(r.URL.Path, "/orders/_doc/"), strings.HasPrefix(r.URL.Path, "/orders/_create/"):
+			json.Unmarshal(body, &indexed)
+			w.Write([]byte(`{"_id":"o1","result":"created"}`))
+		case strings.HasPrefix(r.URL.Path, "/orders/_update_by_query"):
+			json.Unmarshal(body, &resync)
+			w.Write([]byte(`{"updated":3}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}, func(o *Options) {
+		o.Denormalize = []DenormalizeSpec{{
+			Index:    "orders",
+			RefField: "customer_id",
+			RefIndex: "customers",
+			Fields:   []string{"name", "tier"},
+			Target:   "customer",
+		}}
+		o.ReadTransformer = func(index string, source json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`{"name":"***","tier":"***"}`), nil
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.Index(ctx, "orders", "o1", map[string]interface{}{"customer_id": "c1", "amount": 10}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	customer, _ := indexed["customer"].(map[string]interface{})
+	if customer["name"] != "Alice" || customer["tier"] != "gold" || customer["email"] != nil {
+		t.Errorf("indexed document = %v, want embedded customer name and tier", indexed)
+	}
+
+	indexed = nil
+	if _, err := client.PutIfAbsent(ctx, "orders", "o3", map[string]interface{}{"customer_id": "c1"}); err != nil {
+		t.Fatalf("PutIfAbsent() error = %v", err)
+	}
+	if customer, _ := indexed["customer"].(map[string]interface{}); customer["name"] != "Alice" {
+		t.Errorf("PutIfAbsent() document = %v, want embedded customer", indexed)
+	}
+
+	if err := client.Index(ctx, "orders", "o2", map[string]interface{}{"customer_id": "c2"}); err == nil {
+		t.Error("Index() with missing referenced document should fail")
+	}
+
+	updated, err := client.ResyncDenormalized(ctx, "customers", "c1")
+	if err != nil || updated != 3 {
+		t.Fatalf("ResyncDenormalized() = %d, %v", updated, err)
+	}
+	params := resync["script"].(map[string]interface{})["params"].(map[string]interface{})
+	if params["target"] != "customer" || params["value"].(map[string]interface{})["name"] != "Alice" {
+		t.Errorf("resync script params = %v", params)
+	}
+	term := resync["query"].(map[string]interface{})["term"].(map[string]interface{})
+	if term["customer_id"] != "c1" {
+		t.Errorf("resync query = %v", resync["query"])
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+const (
+	// DeprecationCritical 升级前必须处理的问题，否则升级后集群或索引无法正常工作
+	DeprecationCritical = "critical"
+	// DeprecationWarning 建议处理的问题，升级后行为可能发生变化
+	DeprecationWarning = "warning"
+)
+
+// Deprecation 单条弃用警告
+type Deprecation struct {
+	Level                       string                 `json:"level"` // critical 或 warning
+	Message                     string                 `json:"message"`
+	URL                         string                 `json:"url"`
+	Details                     string                 `json:"details"`
+	ResolveDuringRollingUpgrade bool                   `json:"resolve_during_rolling_upgrade"`
+	Meta                        map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// DeprecationInfo 集群升级前检查结果，按资源分组的弃用警告
+type DeprecationInfo struct {
+	Cluster     []Deprecation            `json:"cluster_settings"`
+	Node        []Deprecation            `json:"node_settings"`
+	ML          []Deprecation            `json:"ml_settings"`
+	Index       map[string][]Deprecation `json:"index_settings"` // 键为索引名
+	DataStreams map[string][]Deprecation `json:"data_streams"`   // 键为数据流名
+	Templates   map[string][]Deprecation `json:"templates"`      // 键为模板名
+	ILMPolicies map[string][]Deprecation `json:"ilm_policies"`   // 键为生命周期策略名
+}
+
+// All 返回所有弃用警告
+func (d *DeprecationInfo) All() []Deprecation {
+	all := make([]Deprecation, 0, len(d.Cluster)+len(d.Node)+len(d.ML))
+	all = append(all, d.Cluster...)
+	all = append(all, d.Node...)
+	all = append(all, d.ML...)
+	for _, group := range []map[string][]Deprecation{d.Index, d.DataStreams, d.Templates, d.ILMPolicies} {
+		for _, deprecations := range group {
+			all = append(all, deprecations...)
+		}
+	}
+	return all
+}
+
+// Critical 返回升级前必须处理的弃用警告
+func (d *DeprecationInfo) Critical() []Deprecation {
+	var critical []Deprecation
+	for _, deprecation := range d.All() {
+		if deprecation.Level == DeprecationCritical {
+			critical = append(critical, deprecation)
+		}
+	}
+	return critical
+}
+
+// ReadyToUpgrade 没有 critical 级别的弃用警告时返回 true
+func (d *DeprecationInfo) ReadyToUpgrade() bool {
+	return len(d.Critical()) == 0
+}
+
+// DeprecationInfo 返回集群、节点和索引级别的弃用警告，用于升级到下一个大版本前的自动化检查
+func (c *ElasticsearchClient) DeprecationInfo(ctx context.Context) (*DeprecationInfo, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var info DeprecationInfo
+	err := executeWithTrace(
+		ctx,
+		"deprecation_info",
+		"",
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			req := esapi.MigrationDeprecationsRequest{}
+			res, err := req.Do(ctx, c.client)
+			if err != nil {
+				return c.requestError("deprecation info", err)
+			}
+			defer res.Body.Close()
+
+			if res.IsError() {
+				return c.responseError("deprecation info", res)
+			}
+
+			if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
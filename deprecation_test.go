@@ -0,0 +1,43 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDeprecationInfo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_migration/deprecations" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"cluster_settings":[{"level":"warning","message":"legacy setting","url":"https://example.com/a"}],
+			"node_settings":[],
+			"ml_settings":[],
+			"index_settings":{"logs-2019":[{"level":"critical","message":"index created in 6.x","url":"https://example.com/b","resolve_during_rolling_upgrade":false}]},
+			"data_streams":{},
+			"templates":{},
+			"ilm_policies":{}
+		}`))
+	})
+
+	info, err := client.DeprecationInfo(context.Background())
+	if err != nil {
+		t.Fatalf("DeprecationInfo() error = %v", err)
+	}
+	if len(info.Cluster) != 1 || len(info.Index["logs-2019"]) != 1 {
+		t.Fatalf("DeprecationInfo() = %+v", info)
+	}
+	if len(info.All()) != 2 {
+		t.Errorf("All() = %v", info.All())
+	}
+	critical := info.Critical()
+	if len(critical) != 1 || critical[0].Message != "index created in 6.x" {
+		t.Errorf("Critical() = %v", critical)
+	}
+	if info.ReadyToUpgrade() {
+		t.Error("ReadyToUpgrade() = true, want false")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimingSummary 一类网络阶段的耗时统计
+type TimingSummary struct {
+	Count uint64  `json:"count"`
+	AvgMs float64 `json:"avg_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+// HostDiagnostics 单个节点的连接诊断信息
+type HostDiagnostics struct {
+	Host         string            `json:"host"`
+	Requests     uint64            `json:"requests"`      // 已获取连接的请求数
+	Reused       uint64            `json:"reused"`        // 复用空闲连接的请求数
+	ReuseRatio   float64           `json:"reuse_ratio"`   // 连接复用比例
+	Protocols    map[string]uint64 `json:"protocols"`     // 按响应协议（HTTP/1.1、HTTP/2.0）统计的请求数
+	DNS          TimingSummary     `json:"dns"`           // DNS 解析耗时
+	Connect      TimingSummary     `json:"connect"`       // TCP 建连耗时
+	TLSHandshake TimingSummary     `json:"tls_handshake"` // TLS 握手耗时
+}
+
+// timingStats 耗时累计值
+type timingStats struct {
+	count uint64
+	total time.Duration
+	max   time.Duration
+}
+
+// add 记录一次耗时
+func (s *timingStats) add(d time.Duration) {
+	s.count++
+	s.total += d
+	if d > s.max {
+		s.max = d
+	}
+}
+
+// summary 转换为对外的统计
+func (s timingStats) summary() TimingSummary {
+	if s.count == 0 {
+		return TimingSummary{}
+	}
+	return TimingSummary{
+		Count: s.count,
+		AvgMs: float64(s.total) / float64(s.count) / float64(time.Millisecond),
+		MaxMs: float64(s.max) / float64(time.Millisecond),
+	}
+}
+
+// hostStats 单个节点的累计值
+type hostStats struct {
+	requests  uint64
+	reused    uint64
+	protocols map[string]uint64
+	dns       timingStats
+	connect   timingStats
+	tls       timingStats
+}
+
+// connDiagnostics 通过 httptrace 按节点统计协议、连接复用和建连耗时
+type connDiagnostics struct {
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	hosts map[string]*hostStats
+}
+
+// newConnDiagnostics 创建连接诊断 Transport
+func newConnDiagnostics(next http.RoundTripper) *connDiagnostics {
+	return &connDiagnostics{next: next, hosts: make(map[string]*hostStats)}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (d *connDiagnostics) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	var (
+		dnsStart, tlsStart time.Time
+		connectStarts      sync.Map // 双栈拨号时可能并发建立多个连接，按地址记录开始时间
+	)
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			d.update(host, func(s *hostStats) { s.dns.add(time.Since(dnsStart)) })
+		},
+		ConnectStart: func(network, addr string) { connectStarts.Store(network+"/"+addr, time.Now()) },
+		ConnectDone: func(network, addr string, err error) {
+			start, ok := connectStarts.Load(network + "/" + addr)
+			if err == nil && ok {
+				d.update(host, func(s *hostStats) { s.connect.add(time.Since(start.(time.Time))) })
+			}
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				d.update(host, func(s *hostStats) { s.tls.add(time.Since(tlsStart)) })
+			}
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			d.update(host, func(s *hostStats) {
+				s.requests++
+				if info.Reused {
+					s.reused++
+				}
+			})
+		},
+	}
+
+	res, err := d.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err == nil {
+		d.update(host, func(s *hostStats) { s.protocols[res.Proto]++ })
+	}
+	return res, err
+}
+
+// update 在锁内更新节点的统计
+func (d *connDiagnostics) update(host string, fn func(s *hostStats)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.hosts[host]
+	if !ok {
+		s = &hostStats{protocols: make(map[string]uint64)}
+		d.hosts[host] = s
+	}
+	fn(s)
+}
+
+// snapshot 返回按节点排序的诊断信息
+func (d *connDiagnostics) snapshot() []HostDiagnostics {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]HostDiagnostics, 0, len(d.hosts))
+	for host, s := range d.hosts {
+		item := HostDiagnostics{
+			Host:         host,
+			Requests:     s.requests,
+			Reused:       s.reused,
+			Protocols:    make(map[string]uint64, len(s.protocols)),
+			DNS:          s.dns.summary(),
+			Connect:      s.connect.summary(),
+			TLSHandshake: s.tls.summary(),
+		}
+		if s.requests > 0 {
+			item.ReuseRatio = float64(s.reused) / float64(s.requests)
+		}
+		for proto, n := range s.protocols {
+			item.Protocols[proto] = n
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Host < result[j].Host })
+	return result
+}
+
+// ConnectionDiagnostics 返回按节点汇总的协议、连接复用比例和 DNS/建连/TLS 握手耗时，
+// 用于排查长尾延迟。未配置 ConnDiagnostics 时返回 nil
+func (c *ElasticsearchClient) ConnectionDiagnostics() []HostDiagnostics {
+	if c == nil {
+		return nil
+	}
+	return c.diagnostics.snapshot()
+}
@@ -0,0 +1,68 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectionDiagnostics(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count":1}`))
+	}, func(opts *Options) {
+		opts.ConnDiagnostics = true
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Count(context.Background(), "orders", nil); err != nil {
+			t.Fatalf("Count() error = %v", err)
+		}
+	}
+
+	diags := client.ConnectionDiagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("ConnectionDiagnostics() = %+v, want one host", diags)
+	}
+	d := diags[0]
+	if !strings.HasPrefix(d.Host, "127.0.0.1:") || d.Requests < 3 || d.Reused == 0 || d.ReuseRatio <= 0 {
+		t.Errorf("diagnostics = %+v", d)
+	}
+	if d.Protocols["HTTP/1.1"] < 3 || d.Connect.Count == 0 {
+		t.Errorf("Protocols = %v, Connect = %+v", d.Protocols, d.Connect)
+	}
+}
+
+func TestConnectionDiagnosticsDisabled(t *testing.T) {
+	client := newTestClient(t, nil)
+	if diags := client.ConnectionDiagnostics(); diags != nil {
+		t.Errorf("ConnectionDiagnostics() = %v, want nil", diags)
+	}
+}
+
+func TestDisableHTTP2(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	for _, disable := range []bool{false, true} {
+		transport, err := newTransport(&Options{DisableHTTP2: disable, DialTimeout: 5 * time.Second})
+		if err != nil {
+			t.Fatalf("newTransport() error = %v", err)
+		}
+		transport.TLSClientConfig = ts.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+		res, err := (&http.Client{Transport: transport}).Get(ts.URL)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		res.Body.Close()
+		if want := map[bool]int{false: 2, true: 1}[disable]; res.ProtoMajor != want {
+			t.Errorf("DisableHTTP2 = %t, protocol = %s", disable, res.Proto)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+// HitChange 同时出现在两个索引结果中的文档的排名和得分变化，排名从 0 开始
+type HitChange struct {
+	ID     string
+	RankA  int
+	RankB  int
+	ScoreA float64
+	ScoreB float64
+}
+
+// Moved 排名是否变化
+func (h HitChange) Moved() bool {
+	return h.RankA != h.RankB
+}
+
+// ScoreDelta 索引 B 相对索引 A 的得分变化
+func (h HitChange) ScoreDelta() float64 {
+	return h.ScoreB - h.ScoreA
+}
+
+// SearchDiff 同一查询在两个索引上的结果差异
+type SearchDiff struct {
+	TotalA  int64
+	TotalB  int64
+	Added   []string    // 只出现在索引 B 结果中的文档 ID，按 B 中的排名排序
+	Removed []string    // 只出现在索引 A 结果中的文档 ID，按 A 中的排名排序
+	Common  []HitChange // 两边都出现的文档，按 A 中的排名排序
+}
+
+// Moved 返回排名发生变化的文档
+func (d *SearchDiff) Moved() []HitChange {
+	var moved []HitChange
+	for _, change := range d.Common {
+		if change.Moved() {
+			moved = append(moved, change)
+		}
+	}
+	return moved
+}
+
+// Identical 两个索引返回的命中总数、文档和排名是否完全一致（不比较得分）
+func (d *SearchDiff) Identical() bool {
+	return d.TotalA == d.TotalB && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved()) == 0
+}
+
+// DiffSearch 在一次 msearch 请求中对两个索引执行同一查询并比较结果，
+// 返回新增、移除和排名变化的文档及得分差异，用于在切换别名前验证重建索引或分词器修改的效果。
+// 只比较查询返回的一页结果，可通过查询中的 size 控制比较范围
+func (c *ElasticsearchClient) DiffSearch(ctx context.Context, indexA string, indexB string, query map[string]interface{}) (*SearchDiff, error) {
+	results, err := c.MSearch(ctx, []MSearchItem{
+		{Index: indexA, Query: query},
+		{Index: indexB, Query: query},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var typed [2]*SearchResult
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to search %s: %w", []string{indexA, indexB}[i], result.Err)
+		}
+		if typed[i], err = SearchResultFromMap(result.Result); err != nil {
+			return nil, err
+		}
+	}
+	return diffSearchResults(typed[0], typed[1]), nil
+}
+
+// diffSearchResults 比较两个搜索结果
+func diffSearchResults(a, b *SearchResult) *SearchDiff {
+	diff := &SearchDiff{TotalA: a.Total, TotalB: b.Total}
+
+	ranksB := make(map[string]int, len(b.Hits))
+	for i, hit := range b.Hits {
+		if _, ok := ranksB[hit.ID]; !ok {
+			ranksB[hit.ID] = i
+		}
+	}
+	seenA := make(map[string]struct{}, len(a.Hits))
+	for i, hit := range a.Hits {
+		if _, ok := seenA[hit.ID]; ok {
+			continue
+		}
+		seenA[hit.ID] = struct{}{}
+		rankB, ok := ranksB[hit.ID]
+		if !ok {
+			diff.Removed = append(diff.Removed, hit.ID)
+			continue
+		}
+		diff.Common = append(diff.Common, HitChange{
+			ID:     hit.ID,
+			RankA:  i,
+			RankB:  rankB,
+			ScoreA: hit.Score,
+			ScoreB: b.Hits[rankB].Score,
+		})
+	}
+	for _, hit := range b.Hits {
+		if _, ok := seenA[hit.ID]; !ok {
+			diff.Added = append(diff.Added, hit.ID)
+			seenA[hit.ID] = struct{}{}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,31 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDiffSearch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"responses":[
+			{"hits":{"total":{"value":3},"hits":[{"_id":"a","_score":3},{"_id":"b","_score":2},{"_id":"c","_score":1}]}},
+			{"hits":{"total":{"value":4},"hits":[{"_id":"b","_score":2.5},{"_id":"a","_score":2},{"_id":"d","_score":1}]}}
+		]}`))
+	})
+
+	diff, err := client.DiffSearch(context.Background(), "products-v1", "products-v2", map[string]interface{}{"query": Match("title", "phone").Source()})
+	if err != nil {
+		t.Fatalf("DiffSearch() error = %v", err)
+	}
+	if diff.TotalA != 3 || diff.TotalB != 4 || diff.Identical() {
+		t.Errorf("totals = %d/%d, identical = %v", diff.TotalA, diff.TotalB, diff.Identical())
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "d" || len(diff.Removed) != 1 || diff.Removed[0] != "c" {
+		t.Errorf("Added = %v, Removed = %v", diff.Added, diff.Removed)
+	}
+	moved := diff.Moved()
+	if len(moved) != 2 || moved[0].ID != "a" || moved[0].RankB != 1 || moved[0].ScoreDelta() != -1 || moved[1].ScoreDelta() != 0.5 {
+		t.Errorf("Moved() = %+v", moved)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+// distinctAggregationName 去重相关便捷方法内部使用的聚合名称
+const distinctAggregationName = "distinct"
+
+// TermBucket terms 聚合的单个分桶
+type TermBucket struct {
+	Key         interface{} `json:"key"`           // 字段值，数值字段时为数字
+	KeyAsString string      `json:"key_as_string"` // 日期、布尔等字段格式化后的值
+	DocCount    int64       `json:"doc_count"`     // 包含该值的文档数
+
+	Aggregations Aggregations `json:"-"` // 分桶内的子聚合
+}
+
+// UnmarshalJSON 解析分桶及其子聚合
+func (b *TermBucket) UnmarshalJSON(data []byte) error {
+	aggs, err := decodeBucket(data, map[string]interface{}{
+		"key":           &b.Key,
+		"key_as_string": &b.KeyAsString,
+		"doc_count":     &b.DocCount,
+	})
+	b.Aggregations = aggs
+	return err
+}
+
+// Terms 返回 terms 聚合的分桶
+func (a Aggregations) Terms(name string) ([]TermBucket, error) {
+	var result struct {
+		Buckets []TermBucket `json:"buckets"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
+
+// Value 返回单值指标聚合（如 cardinality、avg、sum）的 value，无数据时返回 0
+func (a Aggregations) Value(name string) (float64, error) {
+	var result struct {
+		Value *float64 `json:"value"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return 0, err
+	}
+	if result.Value == nil {
+		return 0, nil
+	}
+	return *result.Value, nil
+}
+
+// DistinctValues 返回字段出现次数最多的 size 个不同值及其文档数，按文档数降序
+func (c *ElasticsearchClient) DistinctValues(ctx context.Context, index string, field string, size int) ([]TermBucket, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("size must be positive")
+	}
+	aggs, err := c.searchAggregations(ctx, index, nil, map[string]interface{}{
+		distinctAggregationName: map[string]interface{}{
+			"terms": map[string]interface{}{"field": field, "size": size},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aggs.Terms(distinctAggregationName)
+}
+
+// Cardinality 返回查询匹配文档中字段不同值的近似数量（HyperLogLog++，
+// 小基数时精确，大基数时误差约在 1% 以内）
+func (c *ElasticsearchClient) Cardinality(ctx context.Context, index string, field string, query map[string]interface{}) (int64, error) {
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	aggs, err := c.searchAggregations(ctx, index, query, map[string]interface{}{
+		distinctAggregationName: map[string]interface{}{
+			"cardinality": map[string]interface{}{"field": field},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	value, err := aggs.Value(distinctAggregationName)
+	if err != nil {
+		return 0, err
+	}
+	return int64(value), nil
+}
@@ -0,0 +1,51 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDistinctValues(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"aggregations":{"distinct":{"buckets":[{"key":"paid","doc_count":7},{"key":"new","doc_count":3}]}}}`))
+	})
+
+	buckets, err := client.DistinctValues(context.Background(), "orders", "status", 2)
+	if err != nil {
+		t.Fatalf("DistinctValues() error = %v", err)
+	}
+	if len(buckets) != 2 || buckets[0].Key != "paid" || buckets[0].DocCount != 7 {
+		t.Errorf("DistinctValues() = %+v", buckets)
+	}
+	terms := body["aggregations"].(map[string]interface{})["distinct"].(map[string]interface{})["terms"].(map[string]interface{})
+	if terms["field"] != "status" || terms["size"] != float64(2) || body["size"] != float64(0) {
+		t.Errorf("request body = %v", body)
+	}
+
+	if _, err := client.DistinctValues(context.Background(), "orders", "status", 0); err == nil {
+		t.Error("DistinctValues(size 0) error = nil, want error")
+	}
+}
+
+func TestCardinality(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"aggregations":{"distinct":{"value":42}}}`))
+	})
+
+	count, err := client.Cardinality(context.Background(), "orders", "user_id", SearchBody(Term("status", "paid")))
+	if err != nil {
+		t.Fatalf("Cardinality() error = %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Cardinality() = %d, want 42", count)
+	}
+	if body["query"] == nil {
+		t.Errorf("request body = %v, want query", body)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// marshalDocument 将文档转换为 JSON，string 和 []byte 视为已编码的 JSON
+func marshalDocument(body interface{}) ([]byte, error) {
+	switch v := body.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	default:
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal document: %w", err)
+		}
+		return bodyBytes, nil
+	}
+}
+
+// documentMap 将文档转换为 map，便于在写入前读取或补充字段；
+// 传入 map[string]interface{} 时返回其浅拷贝，不会修改调用方的数据。
+// 其他类型经 JSON 转换，数字保留为 json.Number，避免超过 2^53 的整数失去精度
+func documentMap(body interface{}) (map[string]interface{}, error) {
+	if m, ok := body.(map[string]interface{}); ok {
+		doc := make(map[string]interface{}, len(m)+1)
+		for k, v := range m {
+			doc[k] = v
+		}
+		return doc, nil
+	}
+
+	bodyBytes, err := marshalDocument(body)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(bodyBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("document must be a JSON object: %w", err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+	return doc, nil
+}
+
+// WriteResult 单个文档写入（索引、删除）的结果
+type WriteResult struct {
+	ID          string    `json:"_id"`
+	Index       string    `json:"_index"`
+	Version     int64     `json:"_version"`
+	Result      string    `json:"result"` // created、updated、deleted、not_found、noop
+	SeqNo       int64     `json:"_seq_no"`
+	PrimaryTerm int64     `json:"_primary_term"`
+	Shards      ShardInfo `json:"_shards"`
+}
+
+// Created 写入是否创建了新文档（未指定 ID 或 ID 之前不存在）
+func (r *WriteResult) Created() bool {
+	return r.Result == "created"
+}
+
+// Noop 返回写入是否为空操作（部分更新的内容与现有文档相同，文档未被修改），
+// 调用方可据此跳过缓存失效或下游事件
+func (r *WriteResult) Noop() bool {
+	return r.Result == "noop"
+}
+
+// ShardInfo 写入操作在主分片和副本分片上的执行情况
+type ShardInfo struct {
+	Total      int `json:"total"`      // 需要写入的分片副本数
+	Successful int `json:"successful"` // 写入成功的分片副本数
+	Failed     int `json:"failed"`     // 写入失败的分片副本数
+}
+
+// PutIfAbsent 仅在文档不存在时写入（op_type=create，自动处理追踪），
+// 文档已存在时返回 created=false 且不视为错误，适用于幂等插入
+func (c *ElasticsearchClient) PutIfAbsent(ctx context.Context, index string, documentID string, body interface{}) (bool, error) {
+	if err := c.ready(); err != nil {
+		return false, err
+	}
+
+	body, err := c.denormalize(ctx, index, body)
+	if err != nil {
+		return false, err
+	}
+	index = c.resolveIndex(ctx, index)
+
+	var created bool
+	err = executeWithTrace(
+		ctx,
+		"put_if_absent",
+		index,
+		documentID,
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			created, err = c.putIfAbsent(ctx, index, documentID, body)
+			return err
+		},
+	)
+	return created, err
+}
+
+// putIfAbsent 内部幂等插入方法
+func (c *ElasticsearchClient) putIfAbsent(ctx context.Context, index string, documentID string, body interface{}) (bool, error) {
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return false, err
+	}
+
+	bodyBytes, err := marshalDocument(body)
+	if err != nil {
+		return false, err
+	}
+	refresh, err := c.refreshPolicy(ctx, "")
+	if err != nil {
+		return false, err
+	}
+
+	req := esapi.CreateRequest{
+		Index:      index,
+		DocumentID: idPath,
+		Body:       strings.NewReader(string(bodyBytes)),
+		Refresh:    refresh,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return false, c.requestError("create document", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == http.StatusConflict {
+			return false, nil
+		}
+		return false, c.responseError("create", res)
+	}
+
+	return true, nil
+}
+
+// DeleteOption 删除文档或索引的选项
+type DeleteOption func(*deleteConfig)
+
+// deleteConfig 单次删除的配置
+type deleteConfig struct {
+	ignoreNotFound bool
+	refresh        RefreshPolicy
+	concurrencyControl
+}
+
+// newDeleteConfig 应用删除选项
+func newDeleteConfig(opts []DeleteOption) *deleteConfig {
+	cfg := &deleteConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithIgnoreNotFound 目标文档或索引不存在时不返回错误，便于编写幂等的清理逻辑
+func WithIgnoreNotFound() DeleteOption {
+	return func(cfg *deleteConfig) {
+		cfg.ignoreNotFound = true
+	}
+}
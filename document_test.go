@@ -0,0 +1,219 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPutIfAbsent(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		switch r.URL.Path {
+		case "/orders/_create/1":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"result":"created"}`))
+		case "/orders/_create/2":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"type":"version_conflict_engine_exception"},"status":409}`))
+		case "/orders/_create/3":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"type":"mapper_parsing_exception"},"status":400}`))
+		}
+	})
+
+	tests := []struct {
+		id          string
+		wantCreated bool
+		wantErr     bool
+	}{
+		{"1", true, false},
+		{"2", false, false},
+		{"3", false, true},
+	}
+	for _, tt := range tests {
+		created, err := client.PutIfAbsent(context.Background(), "orders", tt.id, map[string]interface{}{"status": "new"})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("PutIfAbsent(%s) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+		}
+		if created != tt.wantCreated {
+			t.Errorf("PutIfAbsent(%s) created = %v, want %v", tt.id, created, tt.wantCreated)
+		}
+	}
+
+	if _, err := client.PutIfAbsent(context.Background(), "orders", "", map[string]interface{}{}); err == nil {
+		t.Error("PutIfAbsent() with empty ID should return error")
+	}
+}
+
+func TestDeleteIgnoreNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.URL.Path == "/gone" {
+			w.Write([]byte(`{"error":{"type":"index_not_found_exception"},"status":404}`))
+			return
+		}
+		w.Write([]byte(`{"result":"not_found"}`))
+	})
+	ctx := context.Background()
+
+	if err := client.Delete(ctx, "orders", "1"); err == nil {
+		t.Error("Delete() error = nil, want not found")
+	}
+	if err := client.Delete(ctx, "orders", "1", WithIgnoreNotFound()); err != nil {
+		t.Errorf("Delete(WithIgnoreNotFound) error = %v", err)
+	}
+	if err := client.DeleteIndex(ctx, "gone"); err == nil {
+		t.Error("DeleteIndex() error = nil, want not found")
+	}
+	if err := client.DeleteIndex(ctx, "gone", WithIgnoreNotFound()); err != nil {
+		t.Errorf("DeleteIndex(WithIgnoreNotFound) error = %v", err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		switch r.URL.Path {
+		case "/orders/_doc/1":
+			w.WriteHeader(http.StatusOK)
+		case "/orders/_doc/2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+
+	ctx := context.Background()
+	if exists, err := client.Exists(ctx, "orders", "1"); err != nil || !exists {
+		t.Errorf("Exists(1) = %t, %v, want true", exists, err)
+	}
+	if exists, err := client.Exists(ctx, "orders", "2"); err != nil || exists {
+		t.Errorf("Exists(2) = %t, %v, want false", exists, err)
+	}
+	if _, err := client.Exists(ctx, "secret", "1"); err == nil {
+		t.Error("Exists() with 403 should return error")
+	}
+}
+
+func TestOptimisticConcurrency(t *testing.T) {
+	var params []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		params = append(params, r.URL.Query())
+		if r.URL.Query().Get("if_seq_no") == "1" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"type":"version_conflict_engine_exception"},"status":409}`))
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/orders/_update/"):
+			w.Write([]byte(`{"_id":"1","_index":"orders","_version":3,"result":"updated","_seq_no":8,"_primary_term":2}`))
+		case r.Method == http.MethodDelete:
+			w.Write([]byte(`{"_id":"1","_index":"orders","_version":4,"result":"deleted","_seq_no":9,"_primary_term":2}`))
+		default:
+			w.Write([]byte(`{"_id":"1","_index":"orders","_version":2,"result":"updated","_seq_no":7,"_primary_term":2}`))
+		}
+	})
+	ctx := context.Background()
+
+	result, err := client.IndexWithResult(ctx, "orders", "1", map[string]interface{}{"status": "paid"}, WithIfSeqNo(5, 2))
+	if err != nil || result.SeqNo != 7 || result.PrimaryTerm != 2 || result.Version != 2 {
+		t.Fatalf("IndexWithResult() = %+v, %v", result, err)
+	}
+	if params[0].Get("if_seq_no") != "5" || params[0].Get("if_primary_term") != "2" {
+		t.Errorf("index params = %v", params[0])
+	}
+
+	err = client.Index(ctx, "orders", "1", map[string]interface{}{"status": "paid"}, WithIfSeqNo(1, 2))
+	var esErr *Error
+	if !errors.As(err, &esErr) || esErr.StatusCode != http.StatusConflict {
+		t.Errorf("Index() with stale seq_no error = %v, want version conflict", err)
+	}
+
+	if err := client.Index(ctx, "orders", "1", map[string]interface{}{}, WithVersion(42, VersionTypeExternalGte)); err != nil {
+		t.Fatal(err)
+	}
+	if p := params[2]; p.Get("version") != "42" || p.Get("version_type") != "external_gte" {
+		t.Errorf("external version params = %v", p)
+	}
+
+	updated, err := client.UpdateWithResult(ctx, "orders", "1", map[string]interface{}{"status": "shipped"}, WithUpdateIfSeqNo(7, 2))
+	if err != nil || updated.SeqNo != 8 || params[3].Get("if_seq_no") != "7" {
+		t.Errorf("UpdateWithResult() = %+v, %v, params = %v", updated, err, params[3])
+	}
+
+	deleted, err := client.DeleteWithResult(ctx, "orders", "1", WithDeleteIfSeqNo(8, 2))
+	if err != nil || deleted.Result != "deleted" || params[4].Get("if_primary_term") != "2" {
+		t.Errorf("DeleteWithResult() = %+v, %v, params = %v", deleted, err, params[4])
+	}
+}
+
+func TestIndexWithResult(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"_id":"1","_index":"orders","_version":1,"result":"created","_seq_no":0,"_primary_term":1,"_shards":{"total":2,"successful":1,"failed":0}}`))
+	})
+
+	result, err := client.IndexWithResult(context.Background(), "orders", "1", map[string]interface{}{"status": "new"})
+	if err != nil {
+		t.Fatalf("IndexWithResult() error = %v", err)
+	}
+	if !result.Created() || result.ID != "1" || result.Index != "orders" || result.Version != 1 {
+		t.Errorf("IndexWithResult() = %+v", result)
+	}
+	if result.Shards.Total != 2 || result.Shards.Successful != 1 || result.Shards.Failed != 0 {
+		t.Errorf("Shards = %+v", result.Shards)
+	}
+}
+
+func TestIndexAutoID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/events/_doc" {
+			t.Errorf("request = %s %s, want POST /events/_doc", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"_id":"V4pXH5ABc1","_index":"events","_version":1,"result":"created"}`))
+	})
+
+	id, err := client.IndexAutoID(context.Background(), "events", map[string]interface{}{"type": "login"})
+	if err != nil || id != "V4pXH5ABc1" {
+		t.Errorf("IndexAutoID() = %q, %v", id, err)
+	}
+	result, err := client.IndexWithResult(context.Background(), "events", "", map[string]interface{}{"type": "logout"})
+	if err != nil || result.ID != "V4pXH5ABc1" || !result.Created() {
+		t.Errorf("IndexWithResult() with empty ID = %+v, %v", result, err)
+	}
+}
+
+func TestDocumentMapPreservesLargeIntegers(t *testing.T) {
+	doc, err := documentMap(struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	}{ID: 9007199254740993, Title: "go"})
+	if err != nil {
+		t.Fatalf("documentMap() error = %v", err)
+	}
+	data, _ := json.Marshal(doc)
+	if want := `{"id":9007199254740993,"title":"go"}`; string(data) != want {
+		t.Errorf("documentMap() = %s, want %s", data, want)
+	}
+
+	dedup := &ContentDedup{Fields: []string{"id"}}
+	a, _ := dedup.Hash(struct {
+		ID int64 `json:"id"`
+	}{9007199254740993})
+	b, _ := dedup.Hash(struct {
+		ID int64 `json:"id"`
+	}{9007199254740992})
+	if a == b {
+		t.Error("Hash() should differ for int64 values above 2^53")
+	}
+}
@@ -19,7 +19,9 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -30,7 +32,49 @@ import (
 // ElasticsearchClient Elasticsearch 客户端接口
 type ElasticsearchClient struct {
 	client      *elasticsearch.Client
-	EnableTrace bool // 是否启用追踪
+	EnableTrace bool     // 是否启用追踪
+	secrets     []string // 需要从错误信息中剔除的敏感值
+
+	serverVersion string // 连接时集群返回的版本号，未知时为空
+
+	maxErrorBody int // 错误信息中保留的响应体最大长度
+
+	allowPartialResults *bool // 搜索请求默认的 allow_partial_search_results，nil 时使用服务端默认值
+
+	refresh RefreshPolicy // 写入操作默认的刷新策略，为空时使用 DefaultRefreshPolicy
+
+	maxResultWindow int         // 分页深度上限
+	limits          queryLimits // 查询复杂度限制
+
+	health healthCache // IsConnected 结果缓存
+
+	indexResolver  IndexResolver  // 逻辑索引名到物理索引名的解析函数
+	documentFilter DocumentFilter // 文档级安全过滤条件提取函数
+
+	auditHook  AuditHook  // 破坏性操作审计回调
+	auditActor AuditActor // 审计事件中的调用方身份提取函数
+
+	softDeleteFieldName string // 软删除时间字段
+
+	historyIndexSuffix string // 历史版本索引后缀，为空时不记录历史
+
+	readTransformer ReadTransformer // 读取文档时的 _source 转换函数
+
+	lifecycle lifecycle // 生命周期状态和 Start/Close 管理的后台资源
+
+	latency *latencyRecorder // 按操作和索引的延迟统计及错误预算，均未启用时为 nil
+
+	diagnostics *connDiagnostics // 按节点的连接诊断，未启用时为 nil
+
+	searchCache *searchCache // stale-while-revalidate 搜索缓存，未启用时为 nil
+
+	shadow *shadowTraffic // 影子流量，未启用时为 nil
+
+	experiments experimentRegistry // 已注册的搜索实验
+
+	denormalizeSpecs []DenormalizeSpec // 写入时嵌入引用文档字段的规则
+
+	destructiveOpsToken string // 集群清理操作的安全令牌，为空时禁用
 }
 
 // NewElasticsearch 根据给定的选项创建一个新的 Elasticsearch 客户端实例
@@ -39,10 +83,23 @@ func NewElasticsearch(opts *Options) (*ElasticsearchClient, error) {
 		return nil, fmt.Errorf("elasticsearch options cannot be nil")
 	}
 
-	if len(opts.Addresses) == 0 {
-		return nil, fmt.Errorf("elasticsearch addresses cannot be empty")
+	// 填充默认值并校验，不修改调用方的选项
+	o := *opts
+	opts = &o
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.LatencyExpvar != "" {
+		if err := checkLatencyExpvar(opts.LatencyExpvar); err != nil {
+			return nil, err
+		}
 	}
 
+	// 收集敏感信息，确保错误信息中不会泄露凭据
+	secrets := collectSecrets(opts)
+
 	// 构建配置
 	cfg := elasticsearch.Config{
 		Addresses: opts.Addresses,
@@ -61,14 +118,45 @@ func NewElasticsearch(opts *Options) (*ElasticsearchClient, error) {
 		cfg.CloudID = opts.CloudID
 	}
 
-	// 注意：elasticsearch 客户端的超时配置需要通过 Transport 设置
-	// 这里我们使用默认的 Transport，超时配置在请求级别处理
+	// 服务标识请求头，便于集群运维按服务区分流量
+	cfg.Header = clientHeader(opts)
+
+	// 输出 HTTP 往返调试日志
+	if opts.DebugHTTP {
+		cfg.Logger = newDebugHTTPLogger(opts.DebugHTTPMaxBodyBytes, secrets)
+	}
+
+	// 超时和连接池通过 Transport 设置，客户端关闭后由 guard 拒绝新的请求
+	transport, err := newTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+	var next http.RoundTripper = transport
+	var diagnostics *connDiagnostics
+	if opts.ConnDiagnostics {
+		diagnostics = newConnDiagnostics(transport)
+		next = diagnostics
+	}
+	if opts.LatencyAwareSelection {
+		selector := newLatencySelector(opts.LatencyProbeInterval)
+		cfg.Selector = selector
+		next = &latencyObserverTransport{next: next, selector: selector}
+	}
+	guard := &closeGuardTransport{next: withUserAgentSuffix(next, opts.UserAgentSuffix)}
+	cfg.Transport = guard
+	cfg.RetryOnError = func(_ *http.Request, err error) bool {
+		return !errors.Is(err, ErrClientClosed)
+	}
+	cfg.CompressRequestBody = opts.CompressRequestBody
+
+	// 连接池指标，用于 HealthCheck 报告各节点连接状态
+	cfg.EnableMetrics = true
 
 	// 设置最大重试次数
 	if opts.MaxRetries > 0 {
 		cfg.MaxRetries = opts.MaxRetries
 	} else {
-		cfg.MaxRetries = 3 // 默认重试 3 次
+		cfg.MaxRetries = DefaultMaxRetries
 	}
 
 	// 如果启用了追踪，则添加追踪功能
@@ -78,7 +166,7 @@ func NewElasticsearch(opts *Options) (*ElasticsearchClient, error) {
 	// 创建客户端
 	client, err := elasticsearch.NewClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+		return nil, redactError(fmt.Errorf("failed to create elasticsearch client: %w", err), secrets)
 	}
 
 	// 测试连接
@@ -86,120 +174,239 @@ func NewElasticsearch(opts *Options) (*ElasticsearchClient, error) {
 	defer cancel()
 	res, err := client.Info(client.Info.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to elasticsearch: %w", err)
+		return nil, redactError(fmt.Errorf("failed to connect to elasticsearch: %w", err), secrets)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("elasticsearch info error: %s", res.String())
+		return nil, redactError(fmt.Errorf("elasticsearch info error: %s", res.String()), secrets)
+	}
+	var info struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	// 版本号仅用于选择分页策略，解析失败时按未知版本处理
+	_ = json.NewDecoder(res.Body).Decode(&info)
+
+	// 发布延迟统计
+	var latency *latencyRecorder
+	if opts.LatencyExpvar != "" {
+		if latency, err = publishLatencyExpvar(opts.LatencyExpvar); err != nil {
+			return nil, err
+		}
+	}
+	if budget := newErrorBudget(opts.ErrorBudgetWindow, opts.ErrorBudgetThreshold, opts.ErrorBudgetMinRequests, opts.ErrorBudgetHook); budget != nil {
+		if latency == nil {
+			latency = &latencyRecorder{}
+		}
+		latency.budget = budget
 	}
 
 	esClient := &ElasticsearchClient{
 		client:      client,
 		EnableTrace: opts.EnableTrace,
+		secrets:     secrets,
+
+		serverVersion: info.Version.Number,
+
+		maxErrorBody: opts.MaxErrorBodyBytes,
+
+		allowPartialResults: opts.AllowPartialSearchResults,
+
+		refresh: opts.Refresh,
+
+		maxResultWindow: opts.MaxResultWindow,
+		limits: queryLimits{
+			maxBytes:            opts.MaxQueryBytes,
+			maxBoolClauses:      opts.MaxBoolClauses,
+			maxAggregationDepth: opts.MaxAggregationDepth,
+		},
+		health:         healthCache{ttl: opts.HealthCacheTTL},
+		indexResolver:  opts.IndexResolver,
+		documentFilter: opts.DocumentFilter,
+		auditHook:      opts.AuditHook,
+		auditActor:     opts.AuditActor,
+
+		softDeleteFieldName: opts.SoftDeleteField,
+
+		historyIndexSuffix: opts.HistoryIndexSuffix,
+
+		readTransformer: opts.ReadTransformer,
+
+		latency: latency,
+
+		diagnostics: diagnostics,
+
+		searchCache: newSearchCache(opts.SearchCacheSize),
+
+		shadow: newShadowTraffic(opts),
+
+		denormalizeSpecs: opts.Denormalize,
+
+		destructiveOpsToken: opts.DestructiveOpsToken,
+	}
+	guard.lifecycle = &esClient.lifecycle
+	if latency != nil {
+		esClient.lifecycle.track("metrics", func(context.Context) error {
+			latency.close()
+			return nil
+		})
+	}
+	if esClient.searchCache != nil {
+		esClient.lifecycle.track("search cache", esClient.searchCache.wait)
+	}
+	if esClient.shadow != nil {
+		esClient.lifecycle.track("shadow traffic", esClient.shadow.wait)
 	}
 
 	return esClient, nil
 }
 
-// Close 关闭 Elasticsearch 客户端连接
-func (c *ElasticsearchClient) Close() error {
-	// Elasticsearch 客户端不需要显式关闭
-	// 但我们可以在这里做一些清理工作
-	return nil
-}
-
 // GetClient 获取原生客户端（用于高级操作）
 func (c *ElasticsearchClient) GetClient() *elasticsearch.Client {
+	if c == nil {
+		return nil
+	}
 	return c.client
 }
 
-// IsConnected 检查连接是否正常
+// IsConnected 检查连接是否正常，配置 HealthCacheTTL 后在缓存窗口内直接返回上次结果
 func (c *ElasticsearchClient) IsConnected() bool {
-	if c.client == nil {
+	if c.ready() != nil {
 		return false
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	return c.Ping(ctx) == nil
+	if connected, ok := c.health.get(); ok {
+		return connected
+	}
+	return c.ForceHealthCheck()
 }
 
 // Ping 检查连接是否正常
 func (c *ElasticsearchClient) Ping(ctx context.Context) error {
-	if c.client == nil {
-		return fmt.Errorf("elasticsearch client is not initialized")
+	if err := c.ready(); err != nil {
+		return err
 	}
 	res, err := c.client.Ping(c.client.Ping.WithContext(ctx))
 	if err != nil {
-		return fmt.Errorf("failed to ping elasticsearch: %w", err)
+		return c.requestError("ping elasticsearch", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch ping error: %s", res.String())
+		return c.responseError("ping", res)
 	}
 
 	return nil
 }
 
-// Index 索引文档（自动处理追踪）
-func (c *ElasticsearchClient) Index(ctx context.Context, index string, documentID string, body interface{}) error {
-	return executeWithTrace(
+// Index 索引文档（自动处理追踪），documentID 为空时由服务端生成 ID
+func (c *ElasticsearchClient) Index(ctx context.Context, index string, documentID string, body interface{}, opts ...IndexOption) error {
+	_, err := c.IndexWithResult(ctx, index, documentID, body, opts...)
+	return err
+}
+
+// IndexWithResult 索引文档并返回写入结果（自动处理追踪），documentID 为空时结果中的 ID 为服务端生成的 ID。
+// 结果中的 SeqNo 和 PrimaryTerm 可用于下一次写入的 WithIfSeqNo 条件
+func (c *ElasticsearchClient) IndexWithResult(ctx context.Context, index string, documentID string, body interface{}, opts ...IndexOption) (*WriteResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	body, err := c.denormalize(ctx, index, body)
+	if err != nil {
+		return nil, err
+	}
+	index = c.resolveIndex(ctx, index)
+	cfg := newIndexConfig(opts)
+
+	var result *WriteResult
+	err = executeWithTrace(
 		ctx,
 		"index",
 		index,
 		documentID,
 		c.EnableTrace,
+		c.latency,
 		func(ctx context.Context) error {
-			return c.index(ctx, index, documentID, body)
+			var err error
+			result, err = c.index(ctx, index, documentID, body, cfg)
+			return err
 		},
 	)
+	return result, err
+}
+
+// IndexAutoID 使用服务端生成的 ID（POST /{index}/_doc）索引文档，返回分配的 ID（自动处理追踪）
+func (c *ElasticsearchClient) IndexAutoID(ctx context.Context, index string, body interface{}, opts ...IndexOption) (string, error) {
+	result, err := c.IndexWithResult(ctx, index, "", body, opts...)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, nil
 }
 
 // index 内部索引文档方法
-func (c *ElasticsearchClient) index(ctx context.Context, index string, documentID string, body interface{}) error {
-	var bodyBytes []byte
-	var err error
-
-	switch v := body.(type) {
-	case string:
-		bodyBytes = []byte(v)
-	case []byte:
-		bodyBytes = v
-	default:
-		bodyBytes, err = json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal document: %w", err)
+func (c *ElasticsearchClient) index(ctx context.Context, index string, documentID string, body interface{}, cfg *indexConfig) (*WriteResult, error) {
+	// 未指定 ID 时由服务端生成
+	if documentID != "" {
+		var err error
+		if documentID, err = documentIDPath(documentID); err != nil {
+			return nil, err
 		}
 	}
 
+	bodyBytes, err := marshalDocument(body)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := c.refreshPolicy(ctx, cfg.refresh)
+	if err != nil {
+		return nil, err
+	}
+
 	req := esapi.IndexRequest{
-		Index:      index,
-		DocumentID: documentID,
-		Body:       strings.NewReader(string(bodyBytes)),
-		Refresh:    "true",
+		Index:         index,
+		DocumentID:    documentID,
+		Body:          strings.NewReader(string(bodyBytes)),
+		Refresh:       refresh,
+		IfSeqNo:       cfg.ifSeqNo,
+		IfPrimaryTerm: cfg.ifPrimaryTerm,
+		Version:       cfg.version,
+		VersionType:   cfg.versionType,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to index document: %w", err)
+		return nil, c.requestError("index document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch index error: %s", res.String())
+		return nil, c.responseError("index", res)
 	}
 
-	return nil
+	var result WriteResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
 }
 
 // Get 获取文档（自动处理追踪）
 func (c *ElasticsearchClient) Get(ctx context.Context, index string, documentID string) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
 	return queryWithTrace(
 		ctx,
 		"get",
 		index,
 		c.EnableTrace,
+		c.latency,
 		func(ctx context.Context) (map[string]interface{}, error) {
 			return c.get(ctx, index, documentID)
 		},
@@ -208,104 +415,306 @@ func (c *ElasticsearchClient) Get(ctx context.Context, index string, documentID
 
 // get 内部获取文档方法
 func (c *ElasticsearchClient) get(ctx context.Context, index string, documentID string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := c.getDocument(ctx, index, documentID, &result); err != nil {
+		return nil, err
+	}
+
+	if c.readTransformer != nil {
+		if err := c.transformSource(index, result, false); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// rawDocument 未经 ReadTransformer 处理的文档，Source 为索引中存储的原始字节
+type rawDocument struct {
+	Index       string          `json:"_index"`
+	ID          string          `json:"_id"`
+	Version     int64           `json:"_version"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	Found       bool            `json:"found"`
+	Source      json.RawMessage `json:"_source"`
+}
+
+// getRaw 获取文档的原始 _source，不经过 ReadTransformer，用于把文档复制到其他索引
+func (c *ElasticsearchClient) getRaw(ctx context.Context, index string, documentID string) (*rawDocument, error) {
+	var doc rawDocument
+	if err := c.getDocument(ctx, index, documentID, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// getDocument 发送 GET 请求并将响应解析到 out
+func (c *ElasticsearchClient) getDocument(ctx context.Context, index string, documentID string, out interface{}) error {
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return err
+	}
+
 	req := esapi.GetRequest{
 		Index:      index,
-		DocumentID: documentID,
+		DocumentID: idPath,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get document: %w", err)
+		return c.requestError("get document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 404 {
-			return nil, fmt.Errorf("document not found")
+			return fmt.Errorf("document %w", ErrNotFound)
 		}
-		return nil, fmt.Errorf("elasticsearch get error: %s", res.String())
+		return c.responseError("get", res)
 	}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// Exists 检查文档是否存在，使用 HEAD 请求，不获取文档内容（自动处理追踪）
+func (c *ElasticsearchClient) Exists(ctx context.Context, index string, documentID string) (bool, error) {
+	if err := c.ready(); err != nil {
+		return false, err
 	}
 
-	return result, nil
+	index = c.resolveIndex(ctx, index)
+
+	var exists bool
+	err := executeWithTrace(
+		ctx,
+		"exists",
+		index,
+		documentID,
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			exists, err = c.exists(ctx, index, documentID)
+			return err
+		},
+	)
+	return exists, err
+}
+
+// exists 内部检查文档是否存在方法
+func (c *ElasticsearchClient) exists(ctx context.Context, index string, documentID string) (bool, error) {
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return false, err
+	}
+
+	req := esapi.ExistsRequest{
+		Index:      index,
+		DocumentID: idPath,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return false, c.requestError("check document", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return false, nil
+	}
+
+	if res.IsError() {
+		return false, c.responseError("exists", res)
+	}
+
+	return true, nil
 }
 
 // Delete 删除文档（自动处理追踪）
-func (c *ElasticsearchClient) Delete(ctx context.Context, index string, documentID string) error {
-	return executeWithTrace(
+func (c *ElasticsearchClient) Delete(ctx context.Context, index string, documentID string, opts ...DeleteOption) error {
+	_, err := c.DeleteWithResult(ctx, index, documentID, opts...)
+	return err
+}
+
+// DeleteWithResult 删除文档并返回写入结果（自动处理追踪），
+// 使用 WithIgnoreNotFound 且文档不存在时返回 Result 为 not_found 的结果
+func (c *ElasticsearchClient) DeleteWithResult(ctx context.Context, index string, documentID string, opts ...DeleteOption) (*WriteResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var result *WriteResult
+	err := executeWithTrace(
 		ctx,
 		"delete",
 		index,
 		documentID,
 		c.EnableTrace,
+		c.latency,
 		func(ctx context.Context) error {
-			return c.delete(ctx, index, documentID)
+			var err error
+			result, err = c.delete(ctx, index, documentID, newDeleteConfig(opts))
+			return err
 		},
 	)
+	return result, err
 }
 
 // delete 内部删除文档方法
-func (c *ElasticsearchClient) delete(ctx context.Context, index string, documentID string) error {
+func (c *ElasticsearchClient) delete(ctx context.Context, index string, documentID string, cfg *deleteConfig) (*WriteResult, error) {
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := c.refreshPolicy(ctx, cfg.refresh)
+	if err != nil {
+		return nil, err
+	}
+
 	req := esapi.DeleteRequest{
-		Index:      index,
-		DocumentID: documentID,
-		Refresh:    "true",
+		Index:         index,
+		DocumentID:    idPath,
+		Refresh:       refresh,
+		IfSeqNo:       cfg.ifSeqNo,
+		IfPrimaryTerm: cfg.ifPrimaryTerm,
+		Version:       cfg.version,
+		VersionType:   cfg.versionType,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to delete document: %w", err)
+		return nil, c.requestError("delete document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 404 {
-			return fmt.Errorf("document not found")
+			if cfg.ignoreNotFound {
+				return &WriteResult{ID: documentID, Result: "not_found"}, nil
+			}
+			return nil, fmt.Errorf("document %w", ErrNotFound)
 		}
-		return fmt.Errorf("elasticsearch delete error: %s", res.String())
+		return nil, c.responseError("delete", res)
 	}
 
-	return nil
+	var result WriteResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
 }
 
-// Search 搜索文档（自动处理追踪）
-func (c *ElasticsearchClient) Search(ctx context.Context, index string, query map[string]interface{}) (map[string]interface{}, error) {
-	return queryWithTrace(
+// Search 搜索文档（自动处理追踪），opts 设置分页、排序、_source 过滤等请求参数
+func (c *ElasticsearchClient) Search(ctx context.Context, index string, query map[string]interface{}, opts ...SearchOption) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.searchResolved(ctx, index, c.resolveIndex(ctx, index), query, newSearchConfig(opts))
+}
+
+// searchResolved 执行搜索，rawIndex 为解析前的索引表达式，index 为已解析的索引表达式
+func (c *ElasticsearchClient) searchResolved(ctx context.Context, rawIndex string, index string, query map[string]interface{}, cfg *searchConfig) (map[string]interface{}, error) {
+	ctx, query, run := c.applyExperiment(ctx, query)
+
+	load := func(ctx context.Context) (map[string]interface{}, error) {
+		start := time.Now()
+		result, err := queryWithTrace(
+			ctx,
+			"search",
+			index,
+			c.EnableTrace,
+			c.latency,
+			func(ctx context.Context) (map[string]interface{}, error) {
+				return c.search(ctx, index, query, cfg)
+			},
+		)
+		run.record(result, time.Since(start), err)
+		if err == nil {
+			c.mirrorSearch(ctx, rawIndex, query, cfg, result, time.Since(start))
+		}
+		return result, err
+	}
+	if policy, ok := ctx.Value(searchCachePolicyKey{}).(searchCachePolicy); ok && c.searchCache != nil {
+		return c.cachedSearch(ctx, index, query, cfg, policy, load)
+	}
+	return load(ctx)
+}
+
+// resourceRequest 执行针对命名资源（如 ML 任务、推理端点、模板）的请求（自动处理追踪），out 不为 nil 时解析响应
+func (c *ElasticsearchClient) resourceRequest(ctx context.Context, operation string, id string, req esapi.Request, out interface{}) error {
+	if id == "" {
+		return fmt.Errorf("%s requires a non-empty ID", operation)
+	}
+	return executeWithTrace(
 		ctx,
-		"search",
-		index,
+		strings.ReplaceAll(operation, " ", "_"),
+		"",
+		id,
 		c.EnableTrace,
-		func(ctx context.Context) (map[string]interface{}, error) {
-			return c.search(ctx, index, query)
+		c.latency,
+		func(ctx context.Context) error {
+			res, err := req.Do(ctx, c.client)
+			if err != nil {
+				return c.requestError(operation, err)
+			}
+			defer res.Body.Close()
+
+			if res.IsError() {
+				return c.responseError(operation, res)
+			}
+			if out == nil {
+				return nil
+			}
+			if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
 		},
 	)
 }
 
-// executeQueryRequest 执行查询请求的通用方法
-func (c *ElasticsearchClient) executeQueryRequest(ctx context.Context, index string, query map[string]interface{}, reqFunc func([]string, *strings.Reader) esapi.Request, operation string) (map[string]interface{}, error) {
+// executeQueryRequest 执行查询请求的通用方法，useNumber 为 true 时以 json.Number 解析响应中的数字
+func (c *ElasticsearchClient) executeQueryRequest(ctx context.Context, index string, query map[string]interface{}, reqFunc func([]string, *strings.Reader) esapi.Request, operation string, useNumber bool) (map[string]interface{}, error) {
+	query, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.limits.checkQuery(query); err != nil {
+		return nil, err
+	}
+
 	queryBytes, err := json.Marshal(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
+	if err := c.limits.checkBytes(len(queryBytes)); err != nil {
+		return nil, err
+	}
 
 	req := reqFunc([]string{index}, strings.NewReader(string(queryBytes)))
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to %s: %w", operation, err)
+		return nil, c.requestError(operation, err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("elasticsearch %s error: %s", operation, res.String())
+		return nil, c.responseError(operation, res)
 	}
 
 	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+	decoder := json.NewDecoder(res.Body)
+	if useNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -313,88 +722,160 @@ func (c *ElasticsearchClient) executeQueryRequest(ctx context.Context, index str
 }
 
 // search 内部搜索文档方法
-func (c *ElasticsearchClient) search(ctx context.Context, index string, query map[string]interface{}) (map[string]interface{}, error) {
-	return c.executeQueryRequest(ctx, index, query, func(indices []string, body *strings.Reader) esapi.Request {
-		return esapi.SearchRequest{
-			Index: indices,
-			Body:  body,
+func (c *ElasticsearchClient) search(ctx context.Context, index string, query map[string]interface{}, cfg *searchConfig) (map[string]interface{}, error) {
+	if err := c.checkResultWindow(query, cfg); err != nil {
+		return nil, err
+	}
+
+	result, err := c.executeQueryRequest(ctx, index, query, func(indices []string, body *strings.Reader) esapi.Request {
+		req := esapi.SearchRequest{
+			Index:                     indices,
+			Body:                      body,
+			AllowPartialSearchResults: c.allowPartialSearchResults(ctx),
 		}
-	}, "search")
+		cfg.apply(&req)
+		return req
+	}, "search", cfg.useNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.transformSearchResult(index, result, cfg.useNumber); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-// Bulk 批量操作（自动处理追踪）
-func (c *ElasticsearchClient) Bulk(ctx context.Context, body string) error {
-	return executeWithTrace(
+// Bulk 批量操作（自动处理追踪）。默认只检查 HTTP 状态，
+// 使用 WithFailOnItemErrors 时任一操作失败返回 *BulkError
+func (c *ElasticsearchClient) Bulk(ctx context.Context, body string, opts ...BulkOption) error {
+	_, err := c.BulkWithResult(ctx, body, opts...)
+	return err
+}
+
+// BulkWithResult 批量操作并返回每个操作的结果（自动处理追踪）
+func (c *ElasticsearchClient) BulkWithResult(ctx context.Context, body string, opts ...BulkOption) (*BulkResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	cfg := newBulkConfig(opts)
+	var result *BulkResult
+	err := executeWithTrace(
 		ctx,
 		"bulk",
 		"",
 		"",
 		c.EnableTrace,
+		c.latency,
 		func(ctx context.Context) error {
-			return c.bulk(ctx, body)
+			var err error
+			result, err = c.bulk(ctx, body)
+			if err != nil {
+				return err
+			}
+			if cfg.failOnItemErrors && result.Errors {
+				return newBulkError(result)
+			}
+			return nil
 		},
 	)
+	return result, err
 }
 
 // bulk 内部批量操作方法
-func (c *ElasticsearchClient) bulk(ctx context.Context, body string) error {
+func (c *ElasticsearchClient) bulk(ctx context.Context, body string) (*BulkResult, error) {
+	refresh, err := c.refreshPolicy(ctx, "")
+	if err != nil {
+		return nil, err
+	}
 	req := esapi.BulkRequest{
 		Body:    strings.NewReader(body),
-		Refresh: "true",
+		Refresh: refresh,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to bulk: %w", err)
+		return nil, c.requestError("bulk", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch bulk error: %s", res.String())
+		return nil, c.responseError("bulk", res)
 	}
 
-	return nil
+	return decodeBulkResult(res.Body)
 }
 
-// CreateIndex 创建索引
-func (c *ElasticsearchClient) CreateIndex(ctx context.Context, index string, settings map[string]interface{}) error {
-	settingsBytes, err := json.Marshal(settings)
+// CreateIndex 根据索引定义创建索引，spec 为 nil 时使用集群默认设置；索引已存在时返回的错误满足 errors.Is(err, ErrIndexAlreadyExists)
+func (c *ElasticsearchClient) CreateIndex(ctx context.Context, index string, spec *IndexSpec) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	name := c.resolveIndexName(ctx, index)
+	if err := ValidateIndexName(name); err != nil {
+		return err
+	}
+	index = escapeIndexPath(name)
+
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	specBytes, err := json.Marshal(spec.body())
 	if err != nil {
-		return fmt.Errorf("failed to marshal settings: %w", err)
+		return fmt.Errorf("failed to marshal index spec: %w", err)
 	}
 
 	req := esapi.IndicesCreateRequest{
 		Index: index,
-		Body:  strings.NewReader(string(settingsBytes)),
+		Body:  strings.NewReader(string(specBytes)),
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to create index: %w", err)
+		return c.requestError("create index", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch create index error: %s", res.String())
+		return c.responseError("create index", res)
 	}
 
 	return nil
 }
 
 // DeleteIndex 删除索引
-func (c *ElasticsearchClient) DeleteIndex(ctx context.Context, index string) error {
+func (c *ElasticsearchClient) DeleteIndex(ctx context.Context, index string, opts ...DeleteOption) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	return c.audit(ctx, "delete_index", index, nil, func(ctx context.Context) error {
+		return c.deleteIndex(ctx, index, newDeleteConfig(opts))
+	})
+}
+
+// deleteIndex 内部删除索引方法
+func (c *ElasticsearchClient) deleteIndex(ctx context.Context, index string, cfg *deleteConfig) error {
 	req := esapi.IndicesDeleteRequest{
 		Index: []string{index},
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to delete index: %w", err)
+		return c.requestError("delete index", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch delete index error: %s", res.String())
+		if res.StatusCode == 404 && cfg.ignoreNotFound {
+			return nil
+		}
+		return c.responseError("delete index", res)
 	}
 
 	return nil
@@ -402,13 +883,19 @@ func (c *ElasticsearchClient) DeleteIndex(ctx context.Context, index string) err
 
 // ExistsIndex 检查索引是否存在
 func (c *ElasticsearchClient) ExistsIndex(ctx context.Context, index string) (bool, error) {
+	if err := c.ready(); err != nil {
+		return false, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
 	req := esapi.IndicesExistsRequest{
 		Index: []string{index},
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return false, fmt.Errorf("failed to check index: %w", err)
+		return false, c.requestError("check index", err)
 	}
 	defer res.Body.Close()
 
@@ -417,63 +904,117 @@ func (c *ElasticsearchClient) ExistsIndex(ctx context.Context, index string) (bo
 	}
 
 	if res.IsError() {
-		return false, fmt.Errorf("elasticsearch exists index error: %s", res.String())
+		return false, c.responseError("exists index", res)
 	}
 
 	return true, nil
 }
 
 // Update 更新文档
-func (c *ElasticsearchClient) Update(ctx context.Context, index string, documentID string, body interface{}) error {
-	var bodyBytes []byte
-	var err error
-
-	switch v := body.(type) {
-	case string:
-		bodyBytes = []byte(v)
-	case []byte:
-		bodyBytes = v
-	default:
-		bodyBytes, err = json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal document: %w", err)
-		}
+func (c *ElasticsearchClient) Update(ctx context.Context, index string, documentID string, body interface{}, opts ...UpdateOption) error {
+	if err := c.ready(); err != nil {
+		return err
 	}
 
-	// 构建更新请求体（需要包装在 doc 字段中）
-	updateBody := map[string]interface{}{
-		"doc": json.RawMessage(bodyBytes),
+	_, err := c.UpdateWithResult(ctx, index, documentID, body, opts...)
+	return err
+}
+
+// UpdateWithResult 更新文档并返回更新结果，可通过 UpdateResult.Noop 判断文档是否实际发生变化。
+// body 为部分文档；使用 WithScript 时 body 须为 nil，可配合 WithUpsert、WithDocAsUpsert 实现 upsert
+func (c *ElasticsearchClient) UpdateWithResult(ctx context.Context, index string, documentID string, body interface{}, opts ...UpdateOption) (*UpdateResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
 	}
+
+	indexName := c.resolveIndexName(ctx, index)
+	index = escapeIndexPath(indexName)
+	cfg := newUpdateConfig(opts)
+
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	updateBody, err := cfg.body(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 开启历史记录时，先保存更新前的版本；文档不存在时没有旧版本可记录，
+	// upsert 继续创建文档，其余情况由更新请求返回 ErrNotFound。
+	// 调用方未指定并发控制时，更新以记录的版本为条件，避免两者之间的并发写入遗漏历史版本
+	if c.historyIndexSuffix != "" {
+		current, err := c.recordHistory(ctx, indexName, documentID)
+		if err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+		if current != nil && cfg.ifSeqNo == nil && cfg.ifPrimaryTerm == nil && cfg.retryOnConflict == nil {
+			seqNo, primaryTerm := int(current.SeqNo), int(current.PrimaryTerm)
+			cfg.ifSeqNo, cfg.ifPrimaryTerm = &seqNo, &primaryTerm
+		}
+	}
+
 	updateBodyBytes, err := json.Marshal(updateBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal update body: %w", err)
+		return nil, fmt.Errorf("failed to marshal update body: %w", err)
+	}
+	refresh, err := c.refreshPolicy(ctx, cfg.refresh)
+	if err != nil {
+		return nil, err
 	}
 
 	req := esapi.UpdateRequest{
-		Index:      index,
-		DocumentID: documentID,
-		Body:       strings.NewReader(string(updateBodyBytes)),
-		Refresh:    "true",
+		Index:           index,
+		DocumentID:      idPath,
+		Body:            strings.NewReader(string(updateBodyBytes)),
+		Refresh:         refresh,
+		IfSeqNo:         cfg.ifSeqNo,
+		IfPrimaryTerm:   cfg.ifPrimaryTerm,
+		RetryOnConflict: cfg.retryOnConflict,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to update document: %w", err)
+		return nil, c.requestError("update document", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 404 {
-			return fmt.Errorf("document not found")
+			return nil, fmt.Errorf("document %w", ErrNotFound)
 		}
-		return fmt.Errorf("elasticsearch update error: %s", res.String())
+		return nil, c.responseError("update", res)
 	}
 
-	return nil
+	var result UpdateResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
 }
 
 // UpdateByQuery 根据查询更新文档
 func (c *ElasticsearchClient) UpdateByQuery(ctx context.Context, index string, query map[string]interface{}, script map[string]interface{}) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var result map[string]interface{}
+	details := map[string]interface{}{"query": query, "script": script}
+	err := c.audit(ctx, "update_by_query", index, details, func(ctx context.Context) error {
+		var err error
+		result, err = c.updateByQuery(ctx, index, query, script)
+		return err
+	})
+	return result, err
+}
+
+// updateByQuery 内部根据查询更新文档方法
+func (c *ElasticsearchClient) updateByQuery(ctx context.Context, index string, query map[string]interface{}, script map[string]interface{}) (map[string]interface{}, error) {
 	// 构建更新查询请求体
 	updateQuery := map[string]interface{}{
 		"query": query,
@@ -482,10 +1023,21 @@ func (c *ElasticsearchClient) UpdateByQuery(ctx context.Context, index string, q
 		updateQuery["script"] = script
 	}
 
+	updateQuery, err := c.applyDocumentFilter(ctx, updateQuery)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.limits.checkQuery(updateQuery); err != nil {
+		return nil, err
+	}
+
 	queryBytes, err := json.Marshal(updateQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal update query: %w", err)
 	}
+	if err := c.limits.checkBytes(len(queryBytes)); err != nil {
+		return nil, err
+	}
 
 	req := esapi.UpdateByQueryRequest{
 		Index: []string{index},
@@ -494,12 +1046,12 @@ func (c *ElasticsearchClient) UpdateByQuery(ctx context.Context, index string, q
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update by query: %w", err)
+		return nil, c.requestError("update by query", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("elasticsearch update by query error: %s", res.String())
+		return nil, c.responseError("update by query", res)
 	}
 
 	var result map[string]interface{}
@@ -512,18 +1064,39 @@ func (c *ElasticsearchClient) UpdateByQuery(ctx context.Context, index string, q
 
 // Count 统计文档数量
 func (c *ElasticsearchClient) Count(ctx context.Context, index string, query map[string]interface{}) (int64, error) {
-	var queryBytes []byte
-	var err error
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	return c.count(ctx, c.resolveIndex(ctx, index), query, newSearchConfig(nil))
+}
+
+// count 内部统计文档数量方法，index 为已解析的索引表达式
+func (c *ElasticsearchClient) count(ctx context.Context, index string, query map[string]interface{}, cfg *searchConfig) (int64, error) {
+	query, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		return 0, err
+	}
 
+	var queryBytes []byte
 	if query != nil {
+		if err := c.limits.checkQuery(query); err != nil {
+			return 0, err
+		}
 		queryBytes, err = json.Marshal(query)
 		if err != nil {
 			return 0, fmt.Errorf("failed to marshal query: %w", err)
 		}
+		if err := c.limits.checkBytes(len(queryBytes)); err != nil {
+			return 0, err
+		}
 	}
 
 	req := esapi.CountRequest{
-		Index: []string{index},
+		Index:             []string{index},
+		IgnoreUnavailable: cfg.ignoreUnavailable,
+		AllowNoIndices:    cfg.allowNoIndices,
+		Preference:        cfg.preference,
 	}
 	if len(queryBytes) > 0 {
 		req.Body = strings.NewReader(string(queryBytes))
@@ -531,12 +1104,12 @@ func (c *ElasticsearchClient) Count(ctx context.Context, index string, query map
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count: %w", err)
+		return 0, c.requestError("count", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return 0, fmt.Errorf("elasticsearch count error: %s", res.String())
+		return 0, c.responseError("count", res)
 	}
 
 	var result map[string]interface{}
@@ -554,10 +1127,27 @@ func (c *ElasticsearchClient) Count(ctx context.Context, index string, query map
 
 // DeleteByQuery 根据查询删除文档
 func (c *ElasticsearchClient) DeleteByQuery(ctx context.Context, index string, query map[string]interface{}) (map[string]interface{}, error) {
-	return c.executeQueryRequest(ctx, index, query, func(indices []string, body *strings.Reader) esapi.Request {
-		return esapi.DeleteByQueryRequest{
-			Index: indices,
-			Body:  body,
-		}
-	}, "delete by query")
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.deleteByQuery(ctx, c.resolveIndex(ctx, index), query, newSearchConfig(nil))
+}
+
+// deleteByQuery 内部按查询删除方法，index 为已解析的索引表达式
+func (c *ElasticsearchClient) deleteByQuery(ctx context.Context, index string, query map[string]interface{}, cfg *searchConfig) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.audit(ctx, "delete_by_query", index, map[string]interface{}{"query": query}, func(ctx context.Context) error {
+		var err error
+		result, err = c.executeQueryRequest(ctx, index, query, func(indices []string, body *strings.Reader) esapi.Request {
+			return esapi.DeleteByQueryRequest{
+				Index:             indices,
+				Body:              body,
+				IgnoreUnavailable: cfg.ignoreUnavailable,
+				AllowNoIndices:    cfg.allowNoIndices,
+			}
+		}, "delete by query", false)
+		return err
+	})
+	return result, err
 }
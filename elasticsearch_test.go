@@ -3,6 +3,7 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -404,9 +405,8 @@ func TestCreateIndex_JSONMarshalError(t *testing.T) {
 		t.Fatalf("NewElasticsearch() error = %v", err)
 	}
 
-	ch := make(map[string]interface{})
-	ch["settings"] = make(chan int)
-	err = client.CreateIndex(context.Background(), "test-index", ch)
+	spec := &IndexSpec{Settings: map[string]interface{}{"number_of_shards": make(chan int)}}
+	err = client.CreateIndex(context.Background(), "test-index", spec)
 	if err == nil {
 		t.Error("CreateIndex() with unmarshallable settings should return error")
 	}
@@ -608,6 +608,39 @@ func TestUpdateByQuery_InvalidScript(t *testing.T) {
 	}
 }
 
+func TestElasticsearchClient_NotInitializedGuards(t *testing.T) {
+	ctx := context.Background()
+	for _, client := range []*ElasticsearchClient{nil, {}} {
+		if _, err := client.Search(ctx, "orders", nil); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("Search() error = %v, want ErrNotInitialized", err)
+		}
+		if err := client.Index(ctx, "orders", "1", map[string]interface{}{}); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("Index() error = %v, want ErrNotInitialized", err)
+		}
+		if _, err := client.Count(ctx, "orders", nil); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("Count() error = %v, want ErrNotInitialized", err)
+		}
+		if err := client.Start(ctx); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("Start() error = %v, want ErrNotInitialized", err)
+		}
+		if client.IsConnected() || client.GetClient() != nil {
+			t.Error("IsConnected()/GetClient() should report no client")
+		}
+		bucketer := func(context.Context, []string) string { return ControlVariant }
+		variants := map[string]QueryVariant{"b": func(q map[string]interface{}) map[string]interface{} { return q }}
+		if err := client.RegisterExperiment("ranking", bucketer, variants); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("RegisterExperiment() error = %v, want ErrNotInitialized", err)
+		}
+		client.UnregisterExperiment("ranking")
+		if client == nil && client.HistoryIndexName("orders") != "" {
+			t.Error("HistoryIndexName() with nil client should return empty")
+		}
+		if variant := client.ExperimentVariant(ctx, "ranking"); variant != "" {
+			t.Errorf("ExperimentVariant() = %q, want empty", variant)
+		}
+	}
+}
+
 func TestClose_NilClient(t *testing.T) {
 	client := &ElasticsearchClient{}
 
@@ -924,13 +957,13 @@ func TestCreateIndex_Success(t *testing.T) {
 		t.Fatalf("NewElasticsearch() error = %v", err)
 	}
 
-	settings := map[string]interface{}{
-		"settings": map[string]interface{}{
+	spec := &IndexSpec{
+		Settings: map[string]interface{}{
 			"number_of_shards":   1,
 			"number_of_replicas": 0,
 		},
 	}
-	err = client.CreateIndex(context.Background(), "test-index", settings)
+	err = client.CreateIndex(context.Background(), "test-index", spec)
 	if err != nil {
 		t.Errorf("CreateIndex() error = %v", err)
 	}
@@ -1333,3 +1366,96 @@ func TestNewElasticsearch_WithMultipleAddresses(t *testing.T) {
 		t.Error("NewElasticsearch() with multiple addresses returned nil client")
 	}
 }
+
+// testInfoResponse 测试服务器在根路径返回的集群信息
+const testInfoResponse = `{"name":"test-node","cluster_name":"test-cluster","version":{"number":"8.0.0","build_date":"2023-01-01T00:00:00.000000000Z","build_snapshot":false,"lucene_version":"9.0.0"}}`
+
+// newTestClient 创建连接到测试服务器的客户端，根路径的 Info 请求由服务器自动处理
+func newTestClient(t *testing.T, handler http.HandlerFunc, configure ...func(*Options)) *ElasticsearchClient {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if r.URL.Path == "/" && (r.Method == "GET" || r.Method == "HEAD") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(testInfoResponse))
+			return
+		}
+		if handler == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	opts := &Options{
+		Addresses:   []string{ts.URL},
+		DialTimeout: 10 * time.Second,
+	}
+	for _, fn := range configure {
+		fn(opts)
+	}
+
+	client, err := NewElasticsearch(opts)
+	if err != nil {
+		t.Fatalf("NewElasticsearch() error = %v", err)
+	}
+	return client
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *Options
+		wantErr bool
+	}{
+		{"nil", nil, true},
+		{"valid", &Options{Addresses: []string{"http://localhost:9200"}}, false},
+		{"empty address", &Options{Addresses: []string{""}}, true},
+		{"api key with username", &Options{Addresses: []string{"http://localhost:9200"}, APIKey: "key", Username: "elastic", Password: "secret"}, true},
+		{"username without password", &Options{Addresses: []string{"http://localhost:9200"}, Username: "elastic"}, true},
+		{"tls with http address", &Options{Addresses: []string{"https://a:9200", "http://b:9200"}, EnableTLS: true}, true},
+		{"ca cert with https address", &Options{Addresses: []string{"https://localhost:9200"}, CACert: "/path/to/ca.crt"}, false},
+		{"negative timeout", &Options{Addresses: []string{"http://localhost:9200"}, ReadTimeout: -time.Second}, true},
+		{"threshold out of range", &Options{Addresses: []string{"http://localhost:9200"}, ErrorBudgetThreshold: 1.5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_ApplyDefaults(t *testing.T) {
+	opts := &Options{Addresses: []string{"http://localhost:9200"}, ReadTimeout: 5 * time.Second}
+	opts.ApplyDefaults()
+
+	if opts.DialTimeout != DefaultDialTimeout || opts.WriteTimeout != DefaultWriteTimeout {
+		t.Errorf("timeouts = %s/%s, want defaults", opts.DialTimeout, opts.WriteTimeout)
+	}
+	if opts.ReadTimeout != 5*time.Second {
+		t.Errorf("ReadTimeout = %s, want 5s", opts.ReadTimeout)
+	}
+	if opts.MaxRetries != DefaultMaxRetries || opts.MaxErrorBodyBytes != DefaultMaxErrorBodyBytes || opts.SoftDeleteField != DefaultSoftDeleteField {
+		t.Errorf("ApplyDefaults() = %+v", opts)
+	}
+}
+
+func TestNewElasticsearch_InvalidOptions(t *testing.T) {
+	opts := &Options{
+		Addresses: []string{"http://localhost:9200"},
+		EnableTLS: true,
+	}
+
+	if _, err := NewElasticsearch(opts); err == nil {
+		t.Fatal("NewElasticsearch with TLS and http address should return error")
+	}
+	if opts.DialTimeout != 0 {
+		t.Errorf("NewElasticsearch() modified caller options: DialTimeout = %s", opts.DialTimeout)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+// embeddingProbeInput 探测向量维度时使用的输入
+const embeddingProbeInput = "dimension probe"
+
+// EmbeddingPipeline 写入时生成向量的 ingest pipeline
+type EmbeddingPipeline struct {
+	Pipeline    string // pipeline 名称
+	Model       string // text_embedding 推理端点 ID
+	SourceField string // 生成向量的文本字段
+	VectorField string // 写入向量的 dense_vector 字段
+	Dims        int    // 向量维度
+}
+
+// SetupEmbeddingPipeline 创建在写入时由推理端点为 sourceField 生成向量并写入 targetVectorField 的 ingest pipeline。
+// model 为 text_embedding 推理端点 ID（见 PutInferenceEndpoint），向量维度通过一次推理探测得到；
+// 配合 CreateEmbeddingIndex 创建以该 pipeline 为默认 pipeline 的索引，即可直接写入原始文档
+func (c *ElasticsearchClient) SetupEmbeddingPipeline(ctx context.Context, model string, sourceField string, targetVectorField string) (*EmbeddingPipeline, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if model == "" || sourceField == "" || targetVectorField == "" {
+		return nil, fmt.Errorf("embedding pipeline requires a model, source field and target vector field")
+	}
+
+	embeddings, err := c.TextEmbeddings(ctx, model, []string{embeddingProbeInput})
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe embedding dimensions: %w", err)
+	}
+	if len(embeddings[0]) == 0 {
+		return nil, fmt.Errorf("inference endpoint %s returned an empty embedding", model)
+	}
+
+	pipeline := &EmbeddingPipeline{
+		Pipeline:    fmt.Sprintf("embedding-%s-%s", model, targetVectorField),
+		Model:       model,
+		SourceField: sourceField,
+		VectorField: targetVectorField,
+		Dims:        len(embeddings[0]),
+	}
+	if err := c.PutIngestPipeline(ctx, pipeline.Pipeline, pipeline.body()); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// body 返回 pipeline 定义，源字段缺失的文档不生成向量
+func (p *EmbeddingPipeline) body() map[string]interface{} {
+	return map[string]interface{}{
+		"description": fmt.Sprintf("generate %s from %s with %s", p.VectorField, p.SourceField, p.Model),
+		"processors": []interface{}{
+			map[string]interface{}{
+				"inference": map[string]interface{}{
+					"model_id": p.Model,
+					"input_output": []interface{}{
+						map[string]interface{}{"input_field": p.SourceField, "output_field": p.VectorField},
+					},
+					"ignore_missing": true,
+				},
+			},
+		},
+	}
+}
+
+// VectorMapping 返回向量字段的 dense_vector 映射，similarity 为空时使用 cosine
+func (p *EmbeddingPipeline) VectorMapping(similarity string) map[string]interface{} {
+	if similarity == "" {
+		similarity = "cosine"
+	}
+	return map[string]interface{}{
+		"type":       "dense_vector",
+		"dims":       p.Dims,
+		"index":      true,
+		"similarity": similarity,
+	}
+}
+
+// CreateEmbeddingIndex 创建以 pipeline 为默认 ingest pipeline 的索引，并在 spec 的映射中加入 dense_vector 向量字段。
+// spec 可为 nil，不支持 RawSettings 和 RawMappings
+func (c *ElasticsearchClient) CreateEmbeddingIndex(ctx context.Context, index string, pipeline *EmbeddingPipeline, spec *IndexSpec) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if pipeline == nil {
+		return fmt.Errorf("embedding pipeline cannot be nil")
+	}
+	if spec != nil && (len(spec.RawSettings) > 0 || len(spec.RawMappings) > 0) {
+		return fmt.Errorf("embedding index spec cannot use raw settings or mappings")
+	}
+
+	merged := &IndexSpec{
+		Settings: map[string]interface{}{},
+		Mappings: map[string]interface{}{},
+	}
+	properties := map[string]interface{}{}
+	if spec != nil {
+		for k, v := range spec.Settings {
+			merged.Settings[k] = v
+		}
+		for k, v := range spec.Mappings {
+			merged.Mappings[k] = v
+		}
+		if existing, ok := spec.Mappings["properties"].(map[string]interface{}); ok {
+			for k, v := range existing {
+				properties[k] = v
+			}
+		}
+		merged.Aliases = spec.Aliases
+		merged.RawAliases = spec.RawAliases
+	}
+	properties[pipeline.VectorField] = pipeline.VectorMapping("")
+	merged.Mappings["properties"] = properties
+	merged.Settings["index.default_pipeline"] = pipeline.Pipeline
+
+	return c.CreateIndex(ctx, index, merged)
+}
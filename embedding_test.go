@@ -0,0 +1,67 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSetupEmbeddingPipeline(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.Method+" "+r.URL.Path] = body
+		switch r.URL.Path {
+		case "/_inference/text_embedding/e5":
+			w.Write([]byte(`{"text_embedding":[{"embedding":[0.1,0.2,0.3]}]}`))
+		default:
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+	ctx := context.Background()
+
+	pipeline, err := client.SetupEmbeddingPipeline(ctx, "e5", "title", "title_vector")
+	if err != nil {
+		t.Fatalf("SetupEmbeddingPipeline() error = %v", err)
+	}
+	if pipeline.Pipeline != "embedding-e5-title_vector" || pipeline.Dims != 3 {
+		t.Errorf("pipeline = %+v", pipeline)
+	}
+	put := bodies["PUT /_ingest/pipeline/embedding-e5-title_vector"]
+	processors, _ := put["processors"].([]interface{})
+	if len(processors) != 1 {
+		t.Fatalf("pipeline body = %v", put)
+	}
+	inference := processors[0].(map[string]interface{})["inference"].(map[string]interface{})
+	field := inference["input_output"].([]interface{})[0].(map[string]interface{})
+	if inference["model_id"] != "e5" || field["input_field"] != "title" || field["output_field"] != "title_vector" {
+		t.Errorf("inference processor = %v", inference)
+	}
+
+	err = client.CreateEmbeddingIndex(ctx, "articles", pipeline, &IndexSpec{
+		Settings: map[string]interface{}{"number_of_shards": 1},
+		Mappings: map[string]interface{}{"properties": map[string]interface{}{"title": map[string]interface{}{"type": "text"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddingIndex() error = %v", err)
+	}
+	index := bodies["PUT /articles"]
+	settings := index["settings"].(map[string]interface{})
+	properties := index["mappings"].(map[string]interface{})["properties"].(map[string]interface{})
+	if settings["index.default_pipeline"] != pipeline.Pipeline || settings["number_of_shards"] == nil {
+		t.Errorf("settings = %v", settings)
+	}
+	vector, _ := properties["title_vector"].(map[string]interface{})
+	if properties["title"] == nil || vector["type"] != "dense_vector" || vector["dims"] != float64(3) || vector["similarity"] != "cosine" {
+		t.Errorf("properties = %v", properties)
+	}
+
+	if _, err := client.SetupEmbeddingPipeline(ctx, "e5", "", "title_vector"); err == nil {
+		t.Error("SetupEmbeddingPipeline() without source field should fail")
+	}
+	if err := client.CreateEmbeddingIndex(ctx, "articles", pipeline, &IndexSpec{RawMappings: json.RawMessage(`{}`)}); err == nil {
+		t.Error("CreateEmbeddingIndex() with raw mappings should fail")
+	}
+}
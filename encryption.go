@@ -0,0 +1,236 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// encryptedValuePrefix 加密字段值的前缀，格式为 enc:v1:<keyID>:<base64(nonce+密文)>
+const encryptedValuePrefix = "enc:v1:"
+
+// DefaultHashSuffix 默认的哈希字段后缀，哈希字段与加密字段并存，用于精确匹配查询
+const DefaultHashSuffix = "_hash"
+
+// KeyProvider 字段加密的密钥提供者，支持密钥轮换
+type KeyProvider interface {
+	// CurrentKey 返回用于加密新数据的密钥及其 ID，密钥长度为 16、24 或 32 字节
+	CurrentKey() (keyID string, key []byte, err error)
+	// Key 按 ID 返回密钥，用于解密历史数据
+	Key(keyID string) ([]byte, error)
+	// HashKey 返回计算哈希字段的 HMAC 密钥，轮换加密密钥时应保持不变，否则需重建哈希字段
+	HashKey() ([]byte, error)
+}
+
+// StaticKeyProvider 使用固定密钥的 KeyProvider
+type StaticKeyProvider struct {
+	keyID   string
+	key     []byte
+	hashKey []byte
+}
+
+// NewStaticKeyProvider 创建使用固定密钥的 KeyProvider
+func NewStaticKeyProvider(keyID string, key []byte, hashKey []byte) *StaticKeyProvider {
+	return &StaticKeyProvider{keyID: keyID, key: key, hashKey: hashKey}
+}
+
+// CurrentKey 返回固定密钥
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	return p.keyID, p.key, nil
+}
+
+// Key 返回 ID 匹配的固定密钥
+func (p *StaticKeyProvider) Key(keyID string) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	return p.key, nil
+}
+
+// HashKey 返回固定的 HMAC 密钥
+func (p *StaticKeyProvider) HashKey() ([]byte, error) {
+	return p.hashKey, nil
+}
+
+// FieldEncryptor 字段级加密编解码器。
+// 写入前使用 AES-GCM 加密指定的顶层字段，并写入 <字段><HashSuffix> 的 HMAC-SHA256 哈希用于精确匹配；
+// 读取时通过 ReadTransformer 解密。哈希字段应映射为 keyword 类型
+type FieldEncryptor struct {
+	keys       KeyProvider
+	fields     map[string]bool
+	HashSuffix string // 哈希字段后缀，默认 _hash
+}
+
+// NewFieldEncryptor 创建字段加密编解码器
+func NewFieldEncryptor(keys KeyProvider, fields ...string) *FieldEncryptor {
+	e := &FieldEncryptor{
+		keys:       keys,
+		fields:     make(map[string]bool, len(fields)),
+		HashSuffix: DefaultHashSuffix,
+	}
+	for _, field := range fields {
+		e.fields[field] = true
+	}
+	return e
+}
+
+// Encrypt 返回加密后的文档，不修改调用方的数据
+func (e *FieldEncryptor) Encrypt(body interface{}) (map[string]interface{}, error) {
+	doc, err := documentMap(body)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, key, err := e.keys.CurrentKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	hashKey, err := e.keys.HashKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash key: %w", err)
+	}
+
+	for field := range e.fields {
+		value, ok := doc[field]
+		if !ok || value == nil {
+			continue
+		}
+		plaintext, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal field %s: %w", field, err)
+		}
+		encrypted, err := encryptValue(keyID, key, field, plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt field %s: %w", field, err)
+		}
+		doc[field] = encrypted
+		doc[field+e.HashSuffix] = hashValue(hashKey, plaintext)
+	}
+	return doc, nil
+}
+
+// Decrypt 解密文档中的加密字段，未加密的值原样保留
+func (e *FieldEncryptor) Decrypt(doc map[string]interface{}) error {
+	for field := range e.fields {
+		value, ok := doc[field].(string)
+		if !ok || !strings.HasPrefix(value, encryptedValuePrefix) {
+			continue
+		}
+		plaintext, err := e.decryptValue(field, value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt field %s: %w", field, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(plaintext, &decoded); err != nil {
+			return fmt.Errorf("failed to decode field %s: %w", field, err)
+		}
+		doc[field] = decoded
+	}
+	return nil
+}
+
+// ReadTransformer 返回解密 _source 的 ReadTransformer，可设置到 Options.ReadTransformer
+func (e *FieldEncryptor) ReadTransformer() ReadTransformer {
+	return func(index string, source json.RawMessage) (json.RawMessage, error) {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(source, &doc); err != nil {
+			return nil, err
+		}
+		if err := e.Decrypt(doc); err != nil {
+			return nil, err
+		}
+		return json.Marshal(doc)
+	}
+}
+
+// Term 返回按加密字段精确匹配的查询（匹配哈希字段）
+func (e *FieldEncryptor) Term(field string, value interface{}) (*TermQuery, error) {
+	hashKey, err := e.keys.HashKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash key: %w", err)
+	}
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal field %s: %w", field, err)
+	}
+	return Term(field+e.HashSuffix, hashValue(hashKey, plaintext)), nil
+}
+
+// decryptValue 解析并解密单个字段值
+func (e *FieldEncryptor) decryptValue(field string, value string) ([]byte, error) {
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+	key, err := e.keys.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, []byte(field))
+}
+
+// encryptValue 使用 AES-GCM 加密，字段名作为附加数据，防止密文被挪用到其他字段
+func encryptValue(keyID string, key []byte, field string, plaintext []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(field))
+	return encryptedValuePrefix + keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// newGCM 创建 AES-GCM 实例
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// hashValue 计算字段值的 HMAC-SHA256 哈希
+func hashValue(hashKey []byte, plaintext []byte) string {
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write(plaintext)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,74 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newTestEncryptor() *FieldEncryptor {
+	keys := NewStaticKeyProvider("k1", bytes.Repeat([]byte("k"), 32), []byte("hash-key"))
+	return NewFieldEncryptor(keys, "ssn", "card")
+}
+
+func TestFieldEncryptorRoundTrip(t *testing.T) {
+	enc := newTestEncryptor()
+	original := map[string]interface{}{"name": "alice", "ssn": "123-45-6789", "card": float64(4111)}
+
+	doc, err := enc.Encrypt(original)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if original["ssn"] != "123-45-6789" {
+		t.Error("Encrypt() modified caller's document")
+	}
+	ssn, _ := doc["ssn"].(string)
+	if !strings.HasPrefix(ssn, "enc:v1:k1:") {
+		t.Errorf("ssn = %v, want encrypted value", doc["ssn"])
+	}
+	if doc["name"] != "alice" {
+		t.Errorf("name = %v, want untouched", doc["name"])
+	}
+
+	term, err := enc.Term("ssn", "123-45-6789")
+	if err != nil {
+		t.Fatalf("Term() error = %v", err)
+	}
+	hash := term.Source()["term"].(map[string]interface{})["ssn_hash"]
+	if hash == nil || hash != doc["ssn_hash"] {
+		t.Errorf("Term() hash = %v, want %v", hash, doc["ssn_hash"])
+	}
+
+	if err := enc.Decrypt(doc); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if doc["ssn"] != "123-45-6789" || doc["card"] != float64(4111) {
+		t.Errorf("Decrypt() = %v", doc)
+	}
+}
+
+func TestFieldEncryptorRejectsSwappedField(t *testing.T) {
+	enc := newTestEncryptor()
+	doc, err := enc.Encrypt(map[string]interface{}{"ssn": "123", "card": "456"})
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	doc["ssn"], doc["card"] = doc["card"], doc["ssn"]
+	if err := enc.Decrypt(doc); err == nil {
+		t.Error("Decrypt() should reject ciphertext moved to another field")
+	}
+}
+
+func TestFieldEncryptorReadTransformer(t *testing.T) {
+	enc := newTestEncryptor()
+	doc, _ := enc.Encrypt(map[string]interface{}{"ssn": "123"})
+	source, _ := marshalDocument(doc)
+
+	out, err := enc.ReadTransformer()("users", source)
+	if err != nil {
+		t.Fatalf("ReadTransformer() error = %v", err)
+	}
+	if !strings.Contains(string(out), `"ssn":"123"`) {
+		t.Errorf("ReadTransformer() = %s", out)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// errorBudgetBuckets 滚动窗口划分的桶数，窗口按桶粒度滑动
+const errorBudgetBuckets = 10
+
+// DefaultErrorBudgetThreshold 默认的失败率告警阈值
+const DefaultErrorBudgetThreshold = 0.5
+
+// DefaultErrorBudgetMinRequests 默认的窗口内最少请求数，请求过少时失败率没有参考意义
+const DefaultErrorBudgetMinRequests = 10
+
+// ErrorBudgetAlert 某类操作在滚动窗口内的失败率超过阈值
+type ErrorBudgetAlert struct {
+	Operation   string        // 操作类型（如 search、index）
+	Window      time.Duration // 统计窗口
+	Requests    int           // 窗口内的请求数
+	Failures    int           // 窗口内的失败数
+	FailureRate float64       // 窗口内的失败率
+	Threshold   float64       // 配置的告警阈值
+}
+
+// ErrorBudgetHook 失败率告警回调，每类操作每个窗口最多调用一次。
+// 回调在操作所在的 goroutine 中同步执行，不应阻塞
+type ErrorBudgetHook func(alert ErrorBudgetAlert)
+
+// errorBudget 按操作类型统计滚动窗口内的失败率，nil 时不统计
+type errorBudget struct {
+	window      time.Duration
+	threshold   float64
+	minRequests int
+	hook        ErrorBudgetHook
+	now         func() time.Time
+
+	mu         sync.Mutex
+	operations map[string]*budgetWindow
+}
+
+// budgetWindow 单类操作的滚动窗口
+type budgetWindow struct {
+	buckets   [errorBudgetBuckets]budgetBucket
+	lastAlert time.Time
+}
+
+// budgetBucket 窗口中一个时间片的计数
+type budgetBucket struct {
+	start    time.Time
+	requests int
+	failures int
+}
+
+// newErrorBudget 创建错误预算统计，window 小于等于 0 时返回 nil
+func newErrorBudget(window time.Duration, threshold float64, minRequests int, hook ErrorBudgetHook) *errorBudget {
+	if window <= 0 {
+		return nil
+	}
+	if threshold <= 0 {
+		threshold = DefaultErrorBudgetThreshold
+	}
+	if minRequests <= 0 {
+		minRequests = DefaultErrorBudgetMinRequests
+	}
+	return &errorBudget{
+		window:      window,
+		threshold:   threshold,
+		minRequests: minRequests,
+		hook:        hook,
+		now:         time.Now,
+		operations:  make(map[string]*budgetWindow),
+	}
+}
+
+// observe 记录一次操作结果，失败率超过阈值时触发告警
+func (b *errorBudget) observe(operation string, err error) {
+	if b == nil {
+		return
+	}
+	alert, ok := b.add(operation, err != nil)
+	if !ok {
+		return
+	}
+	if b.hook != nil {
+		b.hook(alert)
+		return
+	}
+	log.FromContext(context.Background()).Error("Elasticsearch operation failure rate exceeded threshold",
+		zap.String("operation", alert.Operation),
+		zap.Duration("window", alert.Window),
+		zap.Int("requests", alert.Requests),
+		zap.Int("failures", alert.Failures),
+		zap.Float64("failure_rate", alert.FailureRate),
+		zap.Float64("threshold", alert.Threshold),
+	)
+}
+
+// add 更新计数并判断是否需要告警
+func (b *errorBudget) add(operation string, failed bool) (ErrorBudgetAlert, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	width := b.window / errorBudgetBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+
+	w, ok := b.operations[operation]
+	if !ok {
+		w = &budgetWindow{}
+		b.operations[operation] = w
+	}
+	bucket := &w.buckets[int(start.UnixNano()/int64(width))%errorBudgetBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = budgetBucket{start: start}
+	}
+	bucket.requests++
+	if failed {
+		bucket.failures++
+	}
+
+	alert := ErrorBudgetAlert{Operation: operation, Window: b.window, Threshold: b.threshold}
+	cutoff := now.Add(-b.window)
+	for _, bucket := range w.buckets {
+		if bucket.start.After(cutoff) {
+			alert.Requests += bucket.requests
+			alert.Failures += bucket.failures
+		}
+	}
+	if alert.Requests < b.minRequests {
+		return alert, false
+	}
+	alert.FailureRate = float64(alert.Failures) / float64(alert.Requests)
+	if alert.FailureRate < b.threshold {
+		return alert, false
+	}
+	if !w.lastAlert.IsZero() && now.Sub(w.lastAlert) < b.window {
+		return alert, false
+	}
+	w.lastAlert = now
+	return alert, true
+}
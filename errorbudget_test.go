@@ -0,0 +1,91 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestErrorBudgetAlertsOncePerWindow(t *testing.T) {
+	var alerts []ErrorBudgetAlert
+	budget := newErrorBudget(time.Minute, 0.5, 4, func(alert ErrorBudgetAlert) {
+		alerts = append(alerts, alert)
+	})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget.now = func() time.Time { return now }
+
+	failure := errors.New("boom")
+	budget.observe("search", nil)
+	budget.observe("search", failure)
+	budget.observe("search", failure)
+	if len(alerts) != 0 {
+		t.Fatalf("alerts before min requests = %d, want 0", len(alerts))
+	}
+	budget.observe("search", nil)
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %d, want 1", len(alerts))
+	}
+	if got := alerts[0]; got.Operation != "search" || got.Requests != 4 || got.Failures != 2 || got.FailureRate != 0.5 {
+		t.Errorf("alert = %+v", got)
+	}
+
+	// 同一窗口内不重复告警，其他操作类型独立统计
+	budget.observe("search", failure)
+	for i := 0; i < 4; i++ {
+		budget.observe("index", nil)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %d, want 1", len(alerts))
+	}
+
+	// 窗口滑过后旧的失败不再计入
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < 4; i++ {
+		budget.observe("search", nil)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("alerts after window = %d, want 1", len(alerts))
+	}
+	for i := 0; i < 4; i++ {
+		budget.observe("search", failure)
+	}
+	if len(alerts) != 2 || alerts[1].Requests != 8 || alerts[1].Failures != 4 {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+}
+
+func TestErrorBudgetDisabled(t *testing.T) {
+	if budget := newErrorBudget(0, 0.5, 1, nil); budget != nil {
+		t.Fatalf("newErrorBudget(0) = %v, want nil", budget)
+	}
+	var budget *errorBudget
+	budget.observe("search", errors.New("boom"))
+}
+
+func TestClientErrorBudgetHook(t *testing.T) {
+	var alerts []ErrorBudgetAlert
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"type":"exception"}}`))
+	}, func(opts *Options) {
+		opts.ErrorBudgetWindow = time.Minute
+		opts.ErrorBudgetMinRequests = 2
+		opts.ErrorBudgetHook = func(alert ErrorBudgetAlert) {
+			alerts = append(alerts, alert)
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Search(context.Background(), "orders", nil); err == nil {
+			t.Fatal("Search() error = nil, want error")
+		}
+	}
+	if len(alerts) != 1 || alerts[0].Operation != "search" || alerts[0].FailureRate != 1 {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+	if stats := client.LatencyStats(); stats != nil {
+		t.Errorf("LatencyStats() = %v, want nil without LatencyExpvar", stats)
+	}
+}
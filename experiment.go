@@ -0,0 +1,280 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ControlVariant 分桶结果不是已注册的变体时使用的变体 ID，查询保持不变
+const ControlVariant = "control"
+
+// 实验相关的业务标签，写入 span 属性和日志
+const (
+	experimentTag = "experiment"
+	variantTag    = "experiment_variant"
+)
+
+// QueryVariant 实验变体，基于原查询生成该变体实际执行的查询，不应修改传入的查询
+type QueryVariant func(query map[string]interface{}) map[string]interface{}
+
+// ExperimentBucketer 根据上下文（如用户 ID）为请求选择变体 ID，variants 为已注册变体 ID（已排序）。
+// 同一用户应始终返回相同的变体，返回未注册的 ID 时使用 ControlVariant
+type ExperimentBucketer func(ctx context.Context, variants []string) string
+
+// BucketByKey 按 key 的哈希值将请求均匀分配到各变体和对照组，key 为空时使用对照组
+func BucketByKey(key func(ctx context.Context) string) ExperimentBucketer {
+	return func(ctx context.Context, variants []string) string {
+		k := key(ctx)
+		if k == "" {
+			return ControlVariant
+		}
+		h := fnv.New32a()
+		h.Write([]byte(k))
+		i := int(h.Sum32() % uint32(len(variants)+1))
+		if i == len(variants) {
+			return ControlVariant
+		}
+		return variants[i]
+	}
+}
+
+// ExperimentSummary 单个实验变体的累计统计
+type ExperimentSummary struct {
+	Experiment string  `json:"experiment"`
+	Variant    string  `json:"variant"`
+	Requests   uint64  `json:"requests"`
+	Errors     uint64  `json:"errors"`
+	ZeroHits   uint64  `json:"zero_hits"` // 没有命中任何文档的成功请求数
+	AvgHits    float64 `json:"avg_hits"`  // 成功请求的平均命中总数
+	AvgMs      float64 `json:"avg_ms"`
+}
+
+// experiment 已注册的实验
+type experiment struct {
+	name     string
+	bucketer ExperimentBucketer
+	variants map[string]QueryVariant
+	ids      []string
+
+	mu    sync.Mutex
+	stats map[string]*variantStats
+}
+
+// variantStats 单个变体的累计值
+type variantStats struct {
+	requests  uint64
+	errors    uint64
+	zeroHits  uint64
+	totalHits int64
+	duration  time.Duration
+}
+
+// experimentRegistry 按名称保存实验
+type experimentRegistry struct {
+	mu          sync.RWMutex
+	experiments map[string]*experiment
+}
+
+// experimentKey 上下文中选择的实验名称
+type experimentKey struct{}
+
+// WithExperiment 使使用该上下文的 Search 参与指定实验，由实验的分桶函数选择变体
+func WithExperiment(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, experimentKey{}, name)
+}
+
+// RegisterExperiment 注册搜索实验，同名实验会被替换（统计清零）。
+// 通过 WithExperiment 参与实验的 Search 会按 bucketer 选择变体改写查询，
+// 并以 experiment 和 experiment_variant 标签记录到 span、日志和 ExperimentStats 中
+func (c *ElasticsearchClient) RegisterExperiment(name string, bucketer ExperimentBucketer, variants map[string]QueryVariant) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("experiment name cannot be empty")
+	}
+	if bucketer == nil {
+		return fmt.Errorf("experiment %s bucketer cannot be nil", name)
+	}
+	if len(variants) == 0 {
+		return fmt.Errorf("experiment %s must have at least one variant", name)
+	}
+	exp := &experiment{
+		name:     name,
+		bucketer: bucketer,
+		variants: make(map[string]QueryVariant, len(variants)),
+		stats:    make(map[string]*variantStats),
+	}
+	for id, variant := range variants {
+		if id == ControlVariant || variant == nil {
+			return fmt.Errorf("experiment %s has invalid variant %q", name, id)
+		}
+		exp.variants[id] = variant
+		exp.ids = append(exp.ids, id)
+	}
+	sort.Strings(exp.ids)
+
+	c.experiments.mu.Lock()
+	defer c.experiments.mu.Unlock()
+	if c.experiments.experiments == nil {
+		c.experiments.experiments = make(map[string]*experiment)
+	}
+	c.experiments.experiments[name] = exp
+	return nil
+}
+
+// UnregisterExperiment 移除实验，之后参与该实验的请求使用原查询
+func (c *ElasticsearchClient) UnregisterExperiment(name string) {
+	if c.ready() != nil {
+		return
+	}
+	c.experiments.mu.Lock()
+	defer c.experiments.mu.Unlock()
+	delete(c.experiments.experiments, name)
+}
+
+// lookup 返回上下文选择的实验，未选择或未注册时返回 nil
+func (r *experimentRegistry) lookup(ctx context.Context) *experiment {
+	name, ok := ctx.Value(experimentKey{}).(string)
+	if !ok {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.experiments[name]
+}
+
+// selectVariant 返回请求所属的变体 ID
+func (e *experiment) selectVariant(ctx context.Context) string {
+	id := e.bucketer(ctx, e.ids)
+	if _, ok := e.variants[id]; !ok {
+		return ControlVariant
+	}
+	return id
+}
+
+// ExperimentVariant 返回当前上下文在实验中分到的变体 ID，便于业务记录点击等后续指标；
+// 实验未注册或客户端不可用时返回空字符串
+func (c *ElasticsearchClient) ExperimentVariant(ctx context.Context, name string) string {
+	if c.ready() != nil {
+		return ""
+	}
+	exp := c.experiments.lookup(WithExperiment(ctx, name))
+	if exp == nil {
+		return ""
+	}
+	return exp.selectVariant(ctx)
+}
+
+// experimentRun 一次参与实验的搜索，nil 时不记录
+type experimentRun struct {
+	exp     *experiment
+	variant string
+}
+
+// applyExperiment 选择变体并改写查询，返回附加了实验标签的上下文
+func (c *ElasticsearchClient) applyExperiment(ctx context.Context, query map[string]interface{}) (context.Context, map[string]interface{}, *experimentRun) {
+	exp := c.experiments.lookup(ctx)
+	if exp == nil {
+		return ctx, query, nil
+	}
+	variant := exp.selectVariant(ctx)
+	if rewrite, ok := exp.variants[variant]; ok {
+		query = rewrite(query)
+	}
+	ctx = WithOperationTag(ctx, experimentTag, exp.name)
+	ctx = WithOperationTag(ctx, variantTag, variant)
+	return ctx, query, &experimentRun{exp: exp, variant: variant}
+}
+
+// record 记录变体的一次搜索结果
+func (r *experimentRun) record(result map[string]interface{}, duration time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	var total int64
+	if err == nil {
+		hits, _ := result["hits"].(map[string]interface{})
+		total, _ = decodeTotal(hits["total"])
+	}
+
+	r.exp.mu.Lock()
+	defer r.exp.mu.Unlock()
+	s, ok := r.exp.stats[r.variant]
+	if !ok {
+		s = &variantStats{}
+		r.exp.stats[r.variant] = s
+	}
+	s.requests++
+	s.duration += duration
+	if err != nil {
+		s.errors++
+		return
+	}
+	s.totalHits += total
+	if total == 0 {
+		s.zeroHits++
+	}
+}
+
+// ExperimentStats 返回所有实验变体的累计统计，按实验和变体排序
+func (c *ElasticsearchClient) ExperimentStats() []ExperimentSummary {
+	if c == nil {
+		return nil
+	}
+	c.experiments.mu.RLock()
+	experiments := make([]*experiment, 0, len(c.experiments.experiments))
+	for _, exp := range c.experiments.experiments {
+		experiments = append(experiments, exp)
+	}
+	c.experiments.mu.RUnlock()
+
+	var summaries []ExperimentSummary
+	for _, exp := range experiments {
+		exp.mu.Lock()
+		for variant, s := range exp.stats {
+			summary := ExperimentSummary{
+				Experiment: exp.name,
+				Variant:    variant,
+				Requests:   s.requests,
+				Errors:     s.errors,
+				ZeroHits:   s.zeroHits,
+			}
+			if s.requests > 0 {
+				summary.AvgMs = float64(s.duration) / float64(s.requests) / float64(time.Millisecond)
+			}
+			if succeeded := s.requests - s.errors; succeeded > 0 {
+				summary.AvgHits = float64(s.totalHits) / float64(succeeded)
+			}
+			summaries = append(summaries, summary)
+		}
+		exp.mu.Unlock()
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Experiment != summaries[j].Experiment {
+			return summaries[i].Experiment < summaries[j].Experiment
+		}
+		return summaries[i].Variant < summaries[j].Variant
+	})
+	return summaries
+}
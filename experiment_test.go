@@ -0,0 +1,109 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type experimentUserKey struct{}
+
+func TestSearchExperiment(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if strings.Contains(string(body), "title^3") {
+			w.Write([]byte(`{"hits":{"total":{"value":5},"hits":[]}}`))
+			return
+		}
+		w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+	})
+
+	boostTitle := func(query map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"query": MultiMatch("phone", "title^3", "body").Source()}
+	}
+	bucketer := func(ctx context.Context, variants []string) string {
+		user, _ := ctx.Value(experimentUserKey{}).(string)
+		if user == "alice" {
+			return "boost_title"
+		}
+		return "unknown"
+	}
+	if err := client.RegisterExperiment("ranking", bucketer, map[string]QueryVariant{"boost_title": boostTitle}); err != nil {
+		t.Fatalf("RegisterExperiment() error = %v", err)
+	}
+
+	query := map[string]interface{}{"query": Match("title", "phone").Source()}
+	alice := WithExperiment(context.WithValue(context.Background(), experimentUserKey{}, "alice"), "ranking")
+	bob := WithExperiment(context.WithValue(context.Background(), experimentUserKey{}, "bob"), "ranking")
+	for _, ctx := range []context.Context{alice, bob, bob} {
+		if _, err := client.Search(ctx, "products", query); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	}
+	if _, err := client.Search(context.Background(), "products", query); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(bodies[0], "title^3") || strings.Contains(bodies[1], "title^3") {
+		t.Errorf("bodies = %v, want variant query only for alice", bodies)
+	}
+	if got := client.ExperimentVariant(alice, "ranking"); got != "boost_title" {
+		t.Errorf("ExperimentVariant(alice) = %q", got)
+	}
+	if got := client.ExperimentVariant(bob, "ranking"); got != ControlVariant {
+		t.Errorf("ExperimentVariant(bob) = %q, want control", got)
+	}
+
+	stats := client.ExperimentStats()
+	data, _ := json.Marshal(stats)
+	if len(stats) != 2 || stats[0].Variant != "boost_title" || stats[0].Requests != 1 || stats[0].AvgHits != 5 ||
+		stats[1].Variant != ControlVariant || stats[1].Requests != 2 || stats[1].ZeroHits != 2 {
+		t.Errorf("ExperimentStats() = %s", data)
+	}
+
+	if err := client.RegisterExperiment("bad", bucketer, map[string]QueryVariant{ControlVariant: boostTitle}); err == nil {
+		t.Error("RegisterExperiment() should reject the control variant ID")
+	}
+}
+
+func TestBucketByKey(t *testing.T) {
+	bucketer := BucketByKey(func(ctx context.Context) string {
+		user, _ := ctx.Value(experimentUserKey{}).(string)
+		return user
+	})
+	variants := []string{"a", "b"}
+	seen := map[string]int{}
+	for _, user := range []string{"u1", "u2", "u3", "u4", "u5", "u6", "u7", "u8", "u9", "u10"} {
+		ctx := context.WithValue(context.Background(), experimentUserKey{}, user)
+		first := bucketer(ctx, variants)
+		if again := bucketer(ctx, variants); again != first {
+			t.Errorf("user %s bucket changed from %s to %s", user, first, again)
+		}
+		seen[first]++
+	}
+	if len(seen) < 2 {
+		t.Errorf("buckets = %v, want users spread across variants", seen)
+	}
+	if got := bucketer(context.Background(), variants); got != ControlVariant {
+		t.Errorf("empty key bucket = %q, want control", got)
+	}
+}
+
+func TestExperimentRecordReadsTotal(t *testing.T) {
+	exp := &experiment{name: "ranking", stats: make(map[string]*variantStats)}
+	run := &experimentRun{exp: exp, variant: "b"}
+	run.record(map[string]interface{}{"hits": map[string]interface{}{"total": map[string]interface{}{"value": float64(7)}}}, time.Millisecond, nil)
+	run.record(map[string]interface{}{"hits": map[string]interface{}{"total": float64(3), "hits": "unexpected"}}, time.Millisecond, nil)
+	run.record(map[string]interface{}{}, time.Millisecond, nil)
+
+	s := exp.stats["b"]
+	if s.requests != 3 || s.errors != 0 || s.totalHits != 10 || s.zeroHits != 1 {
+		t.Errorf("stats = %+v", *s)
+	}
+}
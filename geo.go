@@ -0,0 +1,176 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// geohashAlphabet geohash 使用的 base32 字符表
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeoPoint 地理坐标
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// GeoBounds 矩形地理范围
+type GeoBounds struct {
+	TopLeft     GeoPoint `json:"top_left"`
+	BottomRight GeoPoint `json:"bottom_right"`
+}
+
+// Center 返回范围的中心点
+func (b GeoBounds) Center() GeoPoint {
+	return GeoPoint{
+		Lat: (b.TopLeft.Lat + b.BottomRight.Lat) / 2,
+		Lon: (b.TopLeft.Lon + b.BottomRight.Lon) / 2,
+	}
+}
+
+// GeoGridBucket geohash_grid/geotile_grid 聚合的单个网格
+type GeoGridBucket struct {
+	Key      string `json:"key"`       // geohash（如 "u4pru"）或地图瓦片（如 "6/32/21"）
+	DocCount int64  `json:"doc_count"` // 网格内的文档数
+}
+
+// Bounds 返回网格覆盖的地理范围，可用于在地图上绘制聚合点
+func (b GeoGridBucket) Bounds() (GeoBounds, error) {
+	if strings.Contains(b.Key, "/") {
+		return geotileBounds(b.Key)
+	}
+	return geohashBounds(b.Key)
+}
+
+// GeohashGridAggregation 构建 geohash_grid 聚合，precision 为 geohash 长度（1~12）
+func GeohashGridAggregation(field string, precision int) map[string]interface{} {
+	agg := map[string]interface{}{"field": field}
+	if precision > 0 {
+		agg["precision"] = precision
+	}
+	return map[string]interface{}{"geohash_grid": agg}
+}
+
+// GeotileGridAggregation 构建 geotile_grid 聚合，precision 为地图缩放级别（0~29），
+// 网格与 Web 地图瓦片对齐，适合地图点聚合
+func GeotileGridAggregation(field string, precision int) map[string]interface{} {
+	agg := map[string]interface{}{"field": field}
+	if precision > 0 {
+		agg["precision"] = precision
+	}
+	return map[string]interface{}{"geotile_grid": agg}
+}
+
+// GeoBoundsAggregation 构建 geo_bounds 聚合，返回包含所有点的最小矩形，适合设置地图初始视野
+func GeoBoundsAggregation(field string) map[string]interface{} {
+	return map[string]interface{}{"geo_bounds": map[string]interface{}{"field": field}}
+}
+
+// GeoGrid 返回 geohash_grid/geotile_grid 聚合的网格
+func (a Aggregations) GeoGrid(name string) ([]GeoGridBucket, error) {
+	var result struct {
+		Buckets []GeoGridBucket `json:"buckets"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
+
+// GeoBounds 返回 geo_bounds 聚合的范围，没有匹配文档时返回 nil
+func (a Aggregations) GeoBounds(name string) (*GeoBounds, error) {
+	var result struct {
+		Bounds *GeoBounds `json:"bounds"`
+	}
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return result.Bounds, nil
+}
+
+// geohashBounds 解码 geohash 对应的地理范围
+func geohashBounds(hash string) (GeoBounds, error) {
+	if hash == "" {
+		return GeoBounds{}, fmt.Errorf("invalid geohash %q", hash)
+	}
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+	even := true
+	for _, ch := range hash {
+		value := strings.IndexRune(geohashAlphabet, ch)
+		if value < 0 {
+			return GeoBounds{}, fmt.Errorf("invalid geohash %q", hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := value&(1<<bit) != 0
+			if even {
+				mid := (minLon + maxLon) / 2
+				if set {
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if set {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return GeoBounds{
+		TopLeft:     GeoPoint{Lat: maxLat, Lon: minLon},
+		BottomRight: GeoPoint{Lat: minLat, Lon: maxLon},
+	}, nil
+}
+
+// geotileBounds 解码 "zoom/x/y" 格式地图瓦片对应的地理范围
+func geotileBounds(key string) (GeoBounds, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 {
+		return GeoBounds{}, fmt.Errorf("invalid geotile %q", key)
+	}
+	var values [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return GeoBounds{}, fmt.Errorf("invalid geotile %q", key)
+		}
+		values[i] = n
+	}
+	zoom, x, y := values[0], values[1], values[2]
+	tiles := math.Exp2(float64(zoom))
+	if float64(x) >= tiles || float64(y) >= tiles {
+		return GeoBounds{}, fmt.Errorf("invalid geotile %q", key)
+	}
+
+	tileLon := func(x int) float64 { return float64(x)/tiles*360 - 180 }
+	tileLat := func(y int) float64 {
+		return math.Atan(math.Sinh(math.Pi*(1-2*float64(y)/tiles))) * 180 / math.Pi
+	}
+	return GeoBounds{
+		TopLeft:     GeoPoint{Lat: tileLat(y), Lon: tileLon(x)},
+		BottomRight: GeoPoint{Lat: tileLat(y + 1), Lon: tileLon(x + 1)},
+	}, nil
+}
@@ -0,0 +1,59 @@
+package elasticsearch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoAggregations(t *testing.T) {
+	aggs := Aggregations{
+		"cells":  []byte(`{"buckets":[{"key":"u4pru","doc_count":3},{"key":"1/1/0","doc_count":1}]}`),
+		"bounds": []byte(`{"bounds":{"top_left":{"lat":48.86,"lon":2.32},"bottom_right":{"lat":48.84,"lon":2.36}}}`),
+		"empty":  []byte(`{}`),
+	}
+
+	cells, err := aggs.GeoGrid("cells")
+	if err != nil {
+		t.Fatalf("GeoGrid() error = %v", err)
+	}
+	if len(cells) != 2 || cells[0].Key != "u4pru" || cells[0].DocCount != 3 {
+		t.Errorf("GeoGrid() = %+v", cells)
+	}
+
+	bounds, err := aggs.GeoBounds("bounds")
+	if err != nil || bounds == nil {
+		t.Fatalf("GeoBounds() = %v, %v", bounds, err)
+	}
+	if center := bounds.Center(); math.Abs(center.Lat-48.85) > 1e-9 || math.Abs(center.Lon-2.34) > 1e-9 {
+		t.Errorf("Center() = %+v", center)
+	}
+	if empty, err := aggs.GeoBounds("empty"); err != nil || empty != nil {
+		t.Errorf("GeoBounds(empty) = %v, %v, want nil", empty, err)
+	}
+}
+
+func TestGeoGridBucketBounds(t *testing.T) {
+	// geohash "u4pru" 覆盖丹麦日德兰半岛北部的一小块区域
+	bounds, err := GeoGridBucket{Key: "u4pru"}.Bounds()
+	if err != nil {
+		t.Fatalf("Bounds(geohash) error = %v", err)
+	}
+	center := bounds.Center()
+	if math.Abs(center.Lat-57.65) > 0.05 || math.Abs(center.Lon-10.41) > 0.05 {
+		t.Errorf("geohash center = %+v", center)
+	}
+
+	bounds, err = GeoGridBucket{Key: "1/1/0"}.Bounds()
+	if err != nil {
+		t.Fatalf("Bounds(geotile) error = %v", err)
+	}
+	if bounds.TopLeft.Lon != 0 || bounds.BottomRight.Lon != 180 || bounds.BottomRight.Lat != 0 || math.Abs(bounds.TopLeft.Lat-85.0511) > 1e-3 {
+		t.Errorf("geotile bounds = %+v", bounds)
+	}
+
+	for _, key := range []string{"", "u4pa", "1/2/0", "1/x/0"} {
+		if _, err := (GeoGridBucket{Key: key}).Bounds(); err == nil {
+			t.Errorf("Bounds(%q) error = nil, want error", key)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderServiceName 标识调用方服务名称的请求头
+	HeaderServiceName = "X-Service-Name"
+	// HeaderServiceVersion 标识调用方服务版本的请求头
+	HeaderServiceVersion = "X-Service-Version"
+)
+
+// clientHeader 根据选项构建附加到所有请求上的服务标识请求头，未设置时返回 nil
+func clientHeader(opts *Options) http.Header {
+	if opts.ServiceName == "" && opts.ServiceVersion == "" {
+		return nil
+	}
+	header := http.Header{}
+	if opts.ServiceName != "" {
+		header.Set(HeaderServiceName, opts.ServiceName)
+	}
+	if opts.ServiceVersion != "" {
+		header.Set(HeaderServiceVersion, opts.ServiceVersion)
+	}
+	return header
+}
+
+// userAgentTransport 在客户端默认的 User-Agent 后追加后缀，便于在代理和审计日志中区分调用方
+type userAgentTransport struct {
+	next   http.RoundTripper
+	suffix string
+}
+
+// withUserAgentSuffix 为 Transport 追加 User-Agent 后缀，后缀为空时原样返回
+func withUserAgentSuffix(next http.RoundTripper, suffix string) http.RoundTripper {
+	suffix = strings.TrimSpace(suffix)
+	if suffix == "" {
+		return next
+	}
+	return &userAgentTransport{next: next, suffix: suffix}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	userAgent := t.suffix
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		userAgent = ua + " " + t.suffix
+	}
+	req.Header.Set("User-Agent", userAgent)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,41 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClientIdentificationHeaders(t *testing.T) {
+	var header http.Header
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{"count":1}`))
+	}, func(opts *Options) {
+		opts.UserAgentSuffix = "orders-api/1.4.0"
+		opts.ServiceName = "orders-api"
+		opts.ServiceVersion = "1.4.0"
+	})
+
+	if _, err := client.Count(context.Background(), "orders", nil); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	ua := header.Get("User-Agent")
+	if !strings.HasPrefix(ua, "go-elasticsearch/") || !strings.HasSuffix(ua, " orders-api/1.4.0") {
+		t.Errorf("User-Agent = %q, want default agent with suffix", ua)
+	}
+	if header.Get(HeaderServiceName) != "orders-api" || header.Get(HeaderServiceVersion) != "1.4.0" {
+		t.Errorf("service headers = %q %q", header.Get(HeaderServiceName), header.Get(HeaderServiceVersion))
+	}
+}
+
+func TestClientHeaderEmpty(t *testing.T) {
+	if h := clientHeader(&Options{}); h != nil {
+		t.Errorf("clientHeader() = %v, want nil", h)
+	}
+	transport := http.DefaultTransport
+	if withUserAgentSuffix(transport, " ") != transport {
+		t.Error("withUserAgentSuffix() with empty suffix should return the transport unchanged")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// healthCache 缓存连接检查结果，避免热路径上每次调用 IsConnected 都发送 Ping
+type healthCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	checkedAt time.Time
+	connected bool
+}
+
+// get 返回缓存窗口内的检查结果，ok 为 false 表示需要重新检查
+func (h *healthCache) get() (connected bool, ok bool) {
+	if h.ttl <= 0 {
+		return false, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.checkedAt.IsZero() || time.Since(h.checkedAt) >= h.ttl {
+		return false, false
+	}
+	return h.connected, true
+}
+
+// set 记录最新的检查结果
+func (h *healthCache) set(connected bool) {
+	if h.ttl <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.connected = connected
+	h.checkedAt = time.Now()
+}
+
+// ForceHealthCheck 忽略缓存立即发送 Ping 检查连接，并刷新 IsConnected 的缓存结果
+func (c *ElasticsearchClient) ForceHealthCheck() bool {
+	if c.ready() != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connected := c.Ping(ctx) == nil
+	c.health.set(connected)
+	return connected
+}
+
+// HealthChecker 可注册到应用健康检查的组件，返回 error 表示组件未就绪
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) (HealthStatus, error)
+}
+
+var _ HealthChecker = (*ElasticsearchClient)(nil)
+
+// NodeConnection 连接池中单个节点连接的状态
+type NodeConnection struct {
+	URL       string     // 节点地址，已遮蔽用户信息
+	Dead      bool       // 是否被标记为不可用
+	Failures  int        // 连续失败次数
+	DeadSince *time.Time // 被标记为不可用的时间
+}
+
+// HealthStatus 客户端健康检查结果
+type HealthStatus struct {
+	Live          bool             // 存活：客户端已初始化且未关闭
+	Ready         bool             // 就绪：Ping 成功、集群状态不为 red 且至少有一个可用连接
+	Reachable     bool             // Ping 是否成功
+	Latency       time.Duration    // Ping 耗时
+	ClusterName   string           // 集群名称，获取失败时为空
+	ClusterStatus HealthColor      // 集群健康状态，获取失败时为空
+	Connections   []NodeConnection // 连接池中各节点的连接状态，按地址排序
+	Reason        string           // 未就绪的原因，就绪时为空
+	CheckedAt     time.Time        // 检查时间
+}
+
+// AliveConnections 返回可用的连接数
+func (s HealthStatus) AliveConnections() int {
+	alive := 0
+	for _, conn := range s.Connections {
+		if !conn.Dead {
+			alive++
+		}
+	}
+	return alive
+}
+
+// HealthCheck 汇总 Ping、集群健康状态和连接池状态，用于就绪/存活探针。
+// 集群为 yellow 时仍视为就绪；未就绪时返回描述原因的错误，同时刷新 IsConnected 的缓存结果
+func (c *ElasticsearchClient) HealthCheck(ctx context.Context) (HealthStatus, error) {
+	status := HealthStatus{CheckedAt: time.Now()}
+	if err := c.ready(); err != nil {
+		status.Reason = err.Error()
+		return status, err
+	}
+	status.Live = true
+
+	var reasons []string
+	start := time.Now()
+	pingErr := c.Ping(ctx)
+	status.Latency = time.Since(start)
+	status.Reachable = pingErr == nil
+	c.health.set(status.Reachable)
+	if pingErr != nil {
+		reasons = append(reasons, pingErr.Error())
+	}
+
+	if status.Reachable {
+		name, color, err := c.clusterHealth(ctx)
+		if err != nil {
+			reasons = append(reasons, err.Error())
+		}
+		status.ClusterName, status.ClusterStatus = name, color
+		if color == HealthRed {
+			reasons = append(reasons, "cluster status is red")
+		}
+	}
+
+	status.Connections = c.nodeConnections()
+	if len(status.Connections) > 0 && status.AliveConnections() == 0 {
+		reasons = append(reasons, "no alive connections in pool")
+	}
+
+	if len(reasons) > 0 {
+		status.Reason = strings.Join(reasons, "; ")
+		return status, fmt.Errorf("elasticsearch is not ready: %s", status.Reason)
+	}
+	status.Ready = true
+	return status, nil
+}
+
+// clusterHealth 返回集群名称和健康状态
+func (c *ElasticsearchClient) clusterHealth(ctx context.Context) (string, HealthColor, error) {
+	req := esapi.ClusterHealthRequest{}
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return "", "", c.requestError("get cluster health", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", "", c.responseError("cluster health", res)
+	}
+
+	var result struct {
+		ClusterName string      `json:"cluster_name"`
+		Status      HealthColor `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.ClusterName, result.Status, nil
+}
+
+// nodeConnections 返回连接池中各节点的连接状态，指标不可用时返回 nil
+func (c *ElasticsearchClient) nodeConnections() []NodeConnection {
+	metrics, err := c.client.Metrics()
+	if err != nil {
+		return nil
+	}
+	conns := make([]NodeConnection, 0, len(metrics.Connections))
+	for _, stringer := range metrics.Connections {
+		metric, ok := stringer.(elastictransport.ConnectionMetric)
+		if !ok {
+			continue
+		}
+		conns = append(conns, NodeConnection{
+			URL:       redactURL(metric.URL),
+			Dead:      metric.IsDead,
+			Failures:  metric.Failures,
+			DeadSince: metric.DeadSince,
+		})
+	}
+	sort.Slice(conns, func(i, j int) bool { return conns[i].URL < conns[j].URL })
+	return conns
+}
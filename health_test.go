@@ -0,0 +1,106 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsConnected_HealthCache(t *testing.T) {
+	var pings int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if r.Method == "HEAD" && r.URL.Path == "/" {
+			atomic.AddInt32(&pings, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testInfoResponse))
+	}))
+	defer ts.Close()
+
+	client, err := NewElasticsearch(&Options{
+		Addresses:      []string{ts.URL},
+		DialTimeout:    10 * time.Second,
+		HealthCacheTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewElasticsearch() error = %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if !client.IsConnected() {
+			t.Fatal("IsConnected() = false, want true")
+		}
+	}
+	if got := atomic.LoadInt32(&pings); got != 1 {
+		t.Errorf("pings = %d, want 1 within cache window", got)
+	}
+
+	if !client.ForceHealthCheck() {
+		t.Error("ForceHealthCheck() = false, want true")
+	}
+	if got := atomic.LoadInt32(&pings); got != 2 {
+		t.Errorf("pings = %d, want 2 after ForceHealthCheck", got)
+	}
+}
+
+func TestHealthCache_Expiry(t *testing.T) {
+	h := healthCache{ttl: 10 * time.Millisecond}
+	if _, ok := h.get(); ok {
+		t.Error("get() on empty cache should miss")
+	}
+	h.set(true)
+	if connected, ok := h.get(); !ok || !connected {
+		t.Errorf("get() = %v, %v, want true, true", connected, ok)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := h.get(); ok {
+		t.Error("get() after ttl should miss")
+	}
+
+	disabled := healthCache{}
+	disabled.set(true)
+	if _, ok := disabled.get(); ok {
+		t.Error("get() with zero ttl should always miss")
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	status := "yellow"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cluster/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"cluster_name":"test","status":"` + status + `"}`))
+	})
+	ctx := context.Background()
+
+	health, err := client.HealthCheck(ctx)
+	if err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if !health.Live || !health.Ready || !health.Reachable || health.ClusterName != "test" || health.ClusterStatus != HealthYellow {
+		t.Errorf("HealthCheck() = %+v", health)
+	}
+	if len(health.Connections) != 1 || health.AliveConnections() != 1 {
+		t.Errorf("HealthCheck() connections = %+v", health.Connections)
+	}
+
+	status = "red"
+	health, err = client.HealthCheck(ctx)
+	if err == nil || health.Ready || !health.Live || health.Reason != "cluster status is red" {
+		t.Errorf("HealthCheck(red) = %+v, %v", health, err)
+	}
+
+	client.Close()
+	health, err = client.HealthCheck(ctx)
+	if !errors.Is(err, ErrClientClosed) || health.Live {
+		t.Errorf("HealthCheck(closed) = %+v, %v", health, err)
+	}
+}
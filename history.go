@@ -0,0 +1,64 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HistoryIndexName 返回索引对应的历史版本索引名称，日期数学表达式的后缀加在尖括号内，
+// 如 <logs-{now/d}> 对应 <logs-{now/d}-history>；客户端为 nil 时返回空字符串
+func (c *ElasticsearchClient) HistoryIndexName(index string) string {
+	if c == nil {
+		return ""
+	}
+	if isDateMathIndex(index) {
+		return strings.TrimSuffix(index, ">") + c.historyIndexSuffix + ">"
+	}
+	return index + c.historyIndexSuffix
+}
+
+// recordHistory 将文档更新前的版本写入历史索引，记录时间和调用方身份（取自 AuditActor）。
+// 历史版本保存索引中存储的原始 _source，不经过 ReadTransformer，避免解密后的字段以明文写入历史索引。
+// index 为解析后未编码的索引名，返回记录的版本，供更新请求做并发控制
+func (c *ElasticsearchClient) recordHistory(ctx context.Context, index string, documentID string) (*rawDocument, error) {
+	current, err := c.getRaw(ctx, escapeIndexPath(index), documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := map[string]interface{}{
+		"index":        index,
+		"document_id":  documentID,
+		"version":      current.Version,
+		"seq_no":       current.SeqNo,
+		"primary_term": current.PrimaryTerm,
+		"source":       current.Source,
+		"recorded_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if c.auditActor != nil {
+		entry["actor"] = c.auditActor(ctx)
+	}
+
+	if _, err := c.index(ctx, escapeIndexPath(c.HistoryIndexName(index)), "", entry, newIndexConfig(nil)); err != nil {
+		return nil, fmt.Errorf("failed to record document history: %w", err)
+	}
+	return current, nil
+}
@@ -0,0 +1,158 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateRecordsHistory(t *testing.T) {
+	var calls []string
+	var entry map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/orders/_doc/1":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"_id":"1","_version":3,"_seq_no":5,"_primary_term":1,"_source":{"status":"new"}}`))
+		case r.Method == "POST" && r.URL.Path == "/orders-history/_doc":
+			json.NewDecoder(r.Body).Decode(&entry)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"result":"created"}`))
+		case r.Method == "POST" && r.URL.Path == "/orders/_update/1":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"result":"updated"}`))
+		}
+	}, func(opts *Options) {
+		opts.HistoryIndexSuffix = "-history"
+		opts.AuditActor = func(ctx context.Context) string { return "alice" }
+	})
+
+	if err := client.Update(context.Background(), "orders", "1", map[string]interface{}{"status": "paid"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	want := []string{"GET /orders/_doc/1", "POST /orders-history/_doc", "POST /orders/_update/1"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("calls[%d] = %s, want %s", i, calls[i], want[i])
+		}
+	}
+	if entry["document_id"] != "1" || entry["actor"] != "alice" || entry["version"] != float64(3) {
+		t.Errorf("history entry = %v", entry)
+	}
+	if source, _ := entry["source"].(map[string]interface{}); source["status"] != "new" {
+		t.Errorf("history source = %v, want previous version", entry["source"])
+	}
+}
+
+func TestUpdateWithoutHistory(t *testing.T) {
+	var calls []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result":"updated"}`))
+	})
+
+	if err := client.Update(context.Background(), "orders", "1", map[string]interface{}{"status": "paid"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "POST /orders/_update/1" {
+		t.Errorf("calls = %v, want only update request", calls)
+	}
+}
+
+func TestUpdateHistoryStoresRawSource(t *testing.T) {
+	var entry map[string]json.RawMessage
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/orders/_doc/1":
+			w.Write([]byte(`{"_id":"1","_version":3,"_source":{"email":"enc:v1:abc"}}`))
+		case r.Method == "POST" && r.URL.Path == "/orders-history/_doc":
+			json.NewDecoder(r.Body).Decode(&entry)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"result":"created"}`))
+		default:
+			w.Write([]byte(`{"result":"updated"}`))
+		}
+	}, func(opts *Options) {
+		opts.HistoryIndexSuffix = "-history"
+		opts.ReadTransformer = func(index string, source json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`{"email":"alice@example.com"}`), nil
+		}
+	})
+
+	if err := client.Update(context.Background(), "orders", "1", map[string]interface{}{"status": "paid"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := string(entry["source"]); got != `{"email":"enc:v1:abc"}` {
+		t.Errorf("history source = %s, want stored bytes", got)
+	}
+}
+
+func TestUpsertWithHistoryCreatesMissingDocument(t *testing.T) {
+	var calls []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/orders/_doc/1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"_id":"1","found":false}`))
+		case r.Method == "POST" && r.URL.Path == "/orders/_update/1":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"_id":"1","result":"created"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}, func(opts *Options) {
+		opts.HistoryIndexSuffix = "-history"
+	})
+
+	result, err := client.UpdateWithResult(context.Background(), "orders", "1", map[string]interface{}{"status": "new"}, WithDocAsUpsert())
+	if err != nil {
+		t.Fatalf("UpdateWithResult() error = %v", err)
+	}
+	if !result.Created() || len(calls) != 2 {
+		t.Errorf("UpdateWithResult() = %+v, calls = %v", result, calls)
+	}
+}
+
+func TestUpdateHistoryDateMathAndConditionalUpdate(t *testing.T) {
+	var entry map[string]interface{}
+	var update *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.RawPath == "/%3Clogs-%7Bnow%2Fd%7D%3E/_doc/1":
+			w.Write([]byte(`{"_id":"1","_version":3,"_seq_no":5,"_primary_term":2,"found":true,"_source":{"status":"new"}}`))
+		case r.Method == "POST" && r.URL.RawPath == "/%3Clogs-%7Bnow%2Fd%7D-history%3E/_doc":
+			json.NewDecoder(r.Body).Decode(&entry)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"result":"created"}`))
+		case r.Method == "POST" && r.URL.RawPath == "/%3Clogs-%7Bnow%2Fd%7D%3E/_update/1":
+			update = r
+			w.Write([]byte(`{"result":"updated"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.RawPath)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}, func(opts *Options) {
+		opts.HistoryIndexSuffix = "-history"
+	})
+
+	if err := client.Update(context.Background(), "<logs-{now/d}>", "1", map[string]interface{}{"status": "paid"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if entry["index"] != "<logs-{now/d}>" {
+		t.Errorf("history index = %v, want unescaped index name", entry["index"])
+	}
+	if update == nil || update.URL.Query().Get("if_seq_no") != "5" || update.URL.Query().Get("if_primary_term") != "2" {
+		t.Errorf("update should be conditional on the recorded version, got %v", update)
+	}
+	if got := client.HistoryIndexName("<logs-{now/d}>"); got != "<logs-{now/d}-history>" {
+		t.Errorf("HistoryIndexName() = %s", got)
+	}
+}
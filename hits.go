@@ -0,0 +1,58 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Hit 搜索命中的文档
+type Hit struct {
+	ID     string          `json:"_id"`
+	Index  string          `json:"_index"`
+	Score  float64         `json:"_score"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// DecodeSource 将 _source 解析到 v
+func (h *Hit) DecodeSource(v interface{}) error {
+	if len(h.Source) == 0 {
+		return fmt.Errorf("hit %s has no _source", h.ID)
+	}
+	if err := json.Unmarshal(h.Source, v); err != nil {
+		return fmt.Errorf("failed to decode source: %w", err)
+	}
+	return nil
+}
+
+// decodeHits 将 hits 结构（{"hits": [...]}）中的命中转换为 Hit 列表
+func decodeHits(hits interface{}) ([]Hit, error) {
+	container, ok := hits.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(container["hits"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hits: %w", err)
+	}
+	var result []Hit
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode hits: %w", err)
+	}
+	return result, nil
+}
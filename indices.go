@@ -0,0 +1,427 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// mappingTopLevelKeys mappings 顶层允许出现的字段，字段定义必须放在 properties 中
+var mappingTopLevelKeys = map[string]bool{
+	"properties":             true,
+	"dynamic":                true,
+	"dynamic_templates":      true,
+	"dynamic_date_formats":   true,
+	"date_detection":         true,
+	"numeric_detection":      true,
+	"runtime":                true,
+	"subobjects":             true,
+	"enabled":                true,
+	"_source":                true,
+	"_routing":               true,
+	"_meta":                  true,
+	"_field_names":           true,
+	"_data_stream_timestamp": true,
+}
+
+// IndexSpec 索引定义，包含设置、映射和别名，创建时一次性原子提交。
+// Raw* 字段用于直接传入 JSON，与对应的 map 字段互斥
+type IndexSpec struct {
+	Settings map[string]interface{} // 索引设置（如 number_of_shards、analysis）
+	Mappings map[string]interface{} // 字段映射（字段定义放在 properties 中）
+	Aliases  map[string]interface{} // 别名名称到别名配置（filter、routing、is_write_index 等）
+
+	RawSettings json.RawMessage // 原始 JSON 格式的索引设置
+	RawMappings json.RawMessage // 原始 JSON 格式的字段映射
+	RawAliases  json.RawMessage // 原始 JSON 格式的别名
+}
+
+// IndexSpecFromMap 将 {"settings":..., "mappings":..., "aliases":...} 格式的请求体转换为 IndexSpec，
+// 出现其他顶层字段时返回错误
+func IndexSpecFromMap(body map[string]interface{}) (*IndexSpec, error) {
+	spec := &IndexSpec{}
+	for key, value := range body {
+		section, ok := value.(map[string]interface{})
+		if !ok && value != nil {
+			return nil, fmt.Errorf("index spec %s must be an object, got %T", key, value)
+		}
+		switch key {
+		case "settings":
+			spec.Settings = section
+		case "mappings":
+			spec.Mappings = section
+		case "aliases":
+			spec.Aliases = section
+		default:
+			return nil, fmt.Errorf("unknown index spec key %q, expected settings, mappings or aliases", key)
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Validate 在客户端检查索引定义的结构，避免字段错放导致的服务端错误
+func (s *IndexSpec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Settings != nil && len(s.RawSettings) > 0 {
+		return fmt.Errorf("index spec cannot set both Settings and RawSettings")
+	}
+	if s.Mappings != nil && len(s.RawMappings) > 0 {
+		return fmt.Errorf("index spec cannot set both Mappings and RawMappings")
+	}
+	if s.Aliases != nil && len(s.RawAliases) > 0 {
+		return fmt.Errorf("index spec cannot set both Aliases and RawAliases")
+	}
+
+	for key := range s.Settings {
+		switch key {
+		case "settings", "mappings", "aliases":
+			return fmt.Errorf("index spec settings cannot contain %q, use the corresponding IndexSpec field", key)
+		}
+	}
+	for key := range s.Mappings {
+		if !mappingTopLevelKeys[key] {
+			return fmt.Errorf("unknown mappings key %q, field definitions must be placed under properties", key)
+		}
+	}
+	for name, alias := range s.Aliases {
+		if alias == nil {
+			continue
+		}
+		if _, ok := alias.(map[string]interface{}); !ok {
+			return fmt.Errorf("alias %s config must be an object, got %T", name, alias)
+		}
+	}
+
+	for name, raw := range map[string]json.RawMessage{"settings": s.RawSettings, "mappings": s.RawMappings, "aliases": s.RawAliases} {
+		if len(raw) == 0 {
+			continue
+		}
+		var section map[string]interface{}
+		if err := json.Unmarshal(raw, &section); err != nil {
+			return fmt.Errorf("raw %s must be a JSON object: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// body 构建创建索引的请求体
+func (s *IndexSpec) body() map[string]interface{} {
+	body := make(map[string]interface{})
+	if s == nil {
+		return body
+	}
+	sections := []struct {
+		name  string
+		value map[string]interface{}
+		raw   json.RawMessage
+	}{
+		{"settings", s.Settings, s.RawSettings},
+		{"mappings", s.Mappings, s.RawMappings},
+		{"aliases", s.Aliases, s.RawAliases},
+	}
+	for _, section := range sections {
+		switch {
+		case len(section.raw) > 0:
+			body[section.name] = section.raw
+		case section.value != nil:
+			body[section.name] = section.value
+		}
+	}
+	return body
+}
+
+// readOnlyIndexSettings 由集群生成、创建索引时不能指定的设置项
+var readOnlyIndexSettings = []string{"uuid", "creation_date", "provided_name", "version", "routing", "history_uuid"}
+
+// IndexDefinition 索引的完整定义
+type IndexDefinition struct {
+	Name     string                 `json:"-"`
+	Settings map[string]interface{} `json:"settings"`
+	Mappings map[string]interface{} `json:"mappings"`
+	Aliases  map[string]interface{} `json:"aliases"`
+}
+
+// Spec 将索引定义转换为可用于 CreateIndex 的 IndexSpec，
+// 会去除 uuid、creation_date 等由集群生成的只读设置，适用于备份和重建索引
+func (d *IndexDefinition) Spec() *IndexSpec {
+	spec := &IndexSpec{
+		Mappings: d.Mappings,
+		Aliases:  d.Aliases,
+	}
+	if d.Settings == nil {
+		return spec
+	}
+
+	spec.Settings = make(map[string]interface{}, len(d.Settings))
+	for key, value := range d.Settings {
+		spec.Settings[key] = value
+	}
+	if indexSettings, ok := d.Settings["index"].(map[string]interface{}); ok {
+		cleaned := make(map[string]interface{}, len(indexSettings))
+		for key, value := range indexSettings {
+			cleaned[key] = value
+		}
+		for _, key := range readOnlyIndexSettings {
+			delete(cleaned, key)
+		}
+		spec.Settings["index"] = cleaned
+	}
+	return spec
+}
+
+// GetIndex 获取索引的完整定义（设置、映射和别名）。
+// index 为别名或通配符时可能返回多个索引，此时返回第一个匹配 index 名称的定义，
+// 需要全部结果时使用 GetIndices
+func (c *ElasticsearchClient) GetIndex(ctx context.Context, index string) (*IndexDefinition, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	definitions, err := c.getIndices(ctx, []string{index})
+	if err != nil {
+		return nil, err
+	}
+	if definition, ok := definitions[index]; ok {
+		return definition, nil
+	}
+	for _, definition := range definitions {
+		return definition, nil
+	}
+	return nil, fmt.Errorf("index %w", ErrNotFound)
+}
+
+// GetIndices 获取匹配表达式的所有索引定义，键为具体索引名称
+func (c *ElasticsearchClient) GetIndices(ctx context.Context, indices ...string) (map[string]*IndexDefinition, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.getIndices(ctx, c.resolveIndices(ctx, indices))
+}
+
+// getIndices 内部获取索引定义方法
+func (c *ElasticsearchClient) getIndices(ctx context.Context, indices []string) (map[string]*IndexDefinition, error) {
+	req := esapi.IndicesGetRequest{
+		Index: indices,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("get index", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("index %w", ErrNotFound)
+		}
+		return nil, c.responseError("get index", res)
+	}
+
+	var result map[string]*IndexDefinition
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	for name, definition := range result {
+		definition.Name = name
+	}
+
+	return result, nil
+}
+
+// ResolvedIndex 解析得到的具体索引
+type ResolvedIndex struct {
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`     // 指向该索引的别名
+	Attributes []string `json:"attributes"`  // 索引状态（如 open、closed、hidden、frozen）
+	DataStream string   `json:"data_stream"` // 索引所属的数据流，普通索引为空
+}
+
+// ResolvedAlias 解析得到的别名
+type ResolvedAlias struct {
+	Name    string   `json:"name"`
+	Indices []string `json:"indices"` // 别名指向的索引
+}
+
+// ResolvedDataStream 解析得到的数据流
+type ResolvedDataStream struct {
+	Name           string   `json:"name"`
+	BackingIndices []string `json:"backing_indices"` // 数据流的后备索引
+	TimestampField string   `json:"timestamp_field"`
+}
+
+// ResolvedIndices 索引表达式的解析结果
+type ResolvedIndices struct {
+	Indices     []ResolvedIndex      `json:"indices"`
+	Aliases     []ResolvedAlias      `json:"aliases"`
+	DataStreams []ResolvedDataStream `json:"data_streams"`
+}
+
+// ResolveIndices 列出与索引表达式（名称、通配符或逗号分隔的列表）匹配的具体索引、别名和数据流，
+// 包括隐藏和已关闭的索引；表达式中包含不存在的具体名称时返回错误
+func (c *ElasticsearchClient) ResolveIndices(ctx context.Context, expression string) (*ResolvedIndices, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	if expression == "" {
+		return nil, fmt.Errorf("index expression cannot be empty")
+	}
+	expression = c.resolveIndex(ctx, expression)
+
+	req := esapi.IndicesResolveIndexRequest{
+		Name:            []string{expression},
+		ExpandWildcards: "all",
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("resolve index", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("index %w", ErrNotFound)
+		}
+		return nil, c.responseError("resolve index", res)
+	}
+
+	var result ResolvedIndices
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// HealthColor 集群或索引的健康状态
+type HealthColor string
+
+const (
+	HealthGreen  HealthColor = "green"  // 所有分片均已分配
+	HealthYellow HealthColor = "yellow" // 主分片已分配，部分副本未分配
+	HealthRed    HealthColor = "red"    // 存在未分配的主分片
+)
+
+// healthPollInterval 单次等待健康状态的最长服务端等待时间
+const healthPollInterval = 5 * time.Second
+
+// healthRetryDelay 未达到目标状态时再次检查前的等待时间
+const healthRetryDelay = 100 * time.Millisecond
+
+// CreateIndexAndWait 创建索引并等待其达到指定的健康状态，避免创建后立即写入与分片分配产生竞争
+func (c *ElasticsearchClient) CreateIndexAndWait(ctx context.Context, index string, spec *IndexSpec, waitFor HealthColor, timeout time.Duration) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	if err := c.CreateIndex(ctx, index, spec); err != nil {
+		return err
+	}
+	return c.WaitForIndexHealth(ctx, index, waitFor, timeout)
+}
+
+// WaitForIndexHealth 轮询索引的健康状态，直到达到 waitFor 或超时
+func (c *ElasticsearchClient) WaitForIndexHealth(ctx context.Context, index string, waitFor HealthColor, timeout time.Duration) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	if waitFor == "" {
+		waitFor = HealthGreen
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		wait := healthPollInterval
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < wait {
+				wait = remaining
+			}
+		}
+		if wait <= 0 {
+			return fmt.Errorf("timed out waiting for index %s to become %s", index, waitFor)
+		}
+
+		status, reached, err := c.indexHealth(ctx, index, waitFor, wait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out waiting for index %s to become %s: %w", index, waitFor, ctx.Err())
+			}
+			return err
+		}
+		if reached {
+			return nil
+		}
+
+		// 避免服务端立即返回时频繁请求
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for index %s to become %s (current: %s)", index, waitFor, status)
+		case <-time.After(healthRetryDelay):
+		}
+	}
+}
+
+// indexHealth 请求索引健康状态，服务端最多等待 wait 时长
+func (c *ElasticsearchClient) indexHealth(ctx context.Context, index string, waitFor HealthColor, wait time.Duration) (HealthColor, bool, error) {
+	req := esapi.ClusterHealthRequest{
+		Index:         []string{index},
+		WaitForStatus: string(waitFor),
+		Timeout:       wait,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return "", false, c.requestError("get cluster health", err)
+	}
+	defer res.Body.Close()
+
+	// 等待超时时服务端返回 408，响应体中仍包含当前状态
+	if res.IsError() && res.StatusCode != http.StatusRequestTimeout {
+		return "", false, c.responseError("cluster health", res)
+	}
+
+	var result struct {
+		Status   HealthColor `json:"status"`
+		TimedOut bool        `json:"timed_out"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Status, !result.TimedOut && res.StatusCode != http.StatusRequestTimeout, nil
+}
@@ -0,0 +1,201 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCreateIndexAndWait(t *testing.T) {
+	var polls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/test-index":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == "GET" && r.URL.Path == "/_cluster/health/test-index":
+			if r.URL.Query().Get("wait_for_status") != "green" {
+				t.Errorf("wait_for_status = %q, want green", r.URL.Query().Get("wait_for_status"))
+			}
+			if atomic.AddInt32(&polls, 1) == 1 {
+				w.WriteHeader(http.StatusRequestTimeout)
+				w.Write([]byte(`{"status":"yellow","timed_out":true}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"status":"green","timed_out":false}`))
+		}
+	})
+
+	err := client.CreateIndexAndWait(context.Background(), "test-index", nil, HealthGreen, 5*time.Second)
+	if err != nil {
+		t.Fatalf("CreateIndexAndWait() error = %v", err)
+	}
+	if atomic.LoadInt32(&polls) != 2 {
+		t.Errorf("polls = %d, want 2", polls)
+	}
+}
+
+func TestWaitForIndexHealth_Timeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_cluster/health/test-index" {
+			w.WriteHeader(http.StatusRequestTimeout)
+			w.Write([]byte(`{"status":"red","timed_out":true}`))
+		}
+	})
+
+	err := client.WaitForIndexHealth(context.Background(), "test-index", HealthYellow, 100*time.Millisecond)
+	if err == nil {
+		t.Error("WaitForIndexHealth() should return error on timeout")
+	}
+}
+
+func TestIndexSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *IndexSpec
+		wantErr bool
+	}{
+		{"nil spec", nil, false},
+		{"valid", &IndexSpec{
+			Settings: map[string]interface{}{"number_of_shards": 1},
+			Mappings: map[string]interface{}{"dynamic": "strict", "properties": map[string]interface{}{"title": map[string]interface{}{"type": "text"}}},
+			Aliases:  map[string]interface{}{"current": map[string]interface{}{"is_write_index": true}},
+		}, false},
+		{"mappings nested in settings", &IndexSpec{Settings: map[string]interface{}{"mappings": map[string]interface{}{}}}, true},
+		{"field outside properties", &IndexSpec{Mappings: map[string]interface{}{"title": map[string]interface{}{"type": "text"}}}, true},
+		{"alias not object", &IndexSpec{Aliases: map[string]interface{}{"current": "index"}}, true},
+		{"map and raw", &IndexSpec{Settings: map[string]interface{}{}, RawSettings: json.RawMessage(`{}`)}, true},
+		{"invalid raw", &IndexSpec{RawMappings: json.RawMessage(`[1]`)}, true},
+		{"valid raw", &IndexSpec{RawMappings: json.RawMessage(`{"properties":{}}`)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIndexSpecFromMap(t *testing.T) {
+	spec, err := IndexSpecFromMap(map[string]interface{}{
+		"settings": map[string]interface{}{"number_of_replicas": 0},
+		"mappings": map[string]interface{}{"properties": map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("IndexSpecFromMap() error = %v", err)
+	}
+	if spec.Settings["number_of_replicas"] != 0 || spec.Mappings == nil {
+		t.Errorf("IndexSpecFromMap() = %+v", spec)
+	}
+
+	if _, err := IndexSpecFromMap(map[string]interface{}{"number_of_shards": 1}); err == nil {
+		t.Error("IndexSpecFromMap() with misplaced key should return error")
+	}
+}
+
+func TestCreateIndex_SpecBody(t *testing.T) {
+	var got map[string]json.RawMessage
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/test-index" {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+
+	spec := &IndexSpec{
+		Settings:    map[string]interface{}{"number_of_shards": 1},
+		RawMappings: json.RawMessage(`{"properties":{"title":{"type":"text"}}}`),
+	}
+	if err := client.CreateIndex(context.Background(), "test-index", spec); err != nil {
+		t.Fatalf("CreateIndex() error = %v", err)
+	}
+	if string(got["mappings"]) != `{"properties":{"title":{"type":"text"}}}` || got["settings"] == nil || got["aliases"] != nil {
+		t.Errorf("CreateIndex() body = %v", got)
+	}
+}
+
+func TestGetIndex(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/test-index":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"test-index":{"aliases":{"current":{}},"mappings":{"properties":{"title":{"type":"text"}}},"settings":{"index":{"number_of_shards":"1","uuid":"abc","creation_date":"1700000000000","provided_name":"test-index","version":{"created":"8000099"}}}}}`))
+		case r.Method == "GET" && r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"index_not_found_exception"},"status":404}`))
+		}
+	})
+
+	def, err := client.GetIndex(context.Background(), "test-index")
+	if err != nil {
+		t.Fatalf("GetIndex() error = %v", err)
+	}
+	if def.Name != "test-index" || def.Aliases["current"] == nil || def.Mappings["properties"] == nil {
+		t.Errorf("GetIndex() = %+v", def)
+	}
+
+	spec := def.Spec()
+	if err := spec.Validate(); err != nil {
+		t.Errorf("Spec().Validate() error = %v", err)
+	}
+	indexSettings := spec.Settings["index"].(map[string]interface{})
+	if indexSettings["number_of_shards"] != "1" {
+		t.Errorf("Spec() lost number_of_shards: %v", indexSettings)
+	}
+	for _, key := range []string{"uuid", "creation_date", "provided_name", "version"} {
+		if _, ok := indexSettings[key]; ok {
+			t.Errorf("Spec() should strip read-only setting %s", key)
+		}
+	}
+	if _, ok := def.Settings["index"].(map[string]interface{})["uuid"]; !ok {
+		t.Error("Spec() should not modify the original definition")
+	}
+
+	if _, err := client.GetIndex(context.Background(), "missing"); err == nil {
+		t.Error("GetIndex() for missing index should return error")
+	}
+}
+
+func TestResolveIndices(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_resolve/index/logs-*":
+			if r.URL.Query().Get("expand_wildcards") != "all" {
+				t.Errorf("expand_wildcards = %q, want all", r.URL.Query().Get("expand_wildcards"))
+			}
+			w.Write([]byte(`{
+				"indices":[{"name":".ds-logs-app-000001","attributes":["hidden","open"],"data_stream":"logs-app"},{"name":"logs-old","aliases":["logs"],"attributes":["closed"]}],
+				"aliases":[{"name":"logs","indices":["logs-old"]}],
+				"data_streams":[{"name":"logs-app","backing_indices":[".ds-logs-app-000001"],"timestamp_field":"@timestamp"}]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"index_not_found_exception"},"status":404}`))
+		}
+	})
+
+	resolved, err := client.ResolveIndices(context.Background(), "logs-*")
+	if err != nil {
+		t.Fatalf("ResolveIndices() error = %v", err)
+	}
+	if len(resolved.Indices) != 2 || resolved.Indices[0].DataStream != "logs-app" || resolved.Indices[1].Aliases[0] != "logs" {
+		t.Errorf("Indices = %+v", resolved.Indices)
+	}
+	if len(resolved.Aliases) != 1 || resolved.Aliases[0].Indices[0] != "logs-old" {
+		t.Errorf("Aliases = %+v", resolved.Aliases)
+	}
+	if len(resolved.DataStreams) != 1 || resolved.DataStreams[0].TimestampField != "@timestamp" {
+		t.Errorf("DataStreams = %+v", resolved.DataStreams)
+	}
+
+	if _, err := client.ResolveIndices(context.Background(), "missing"); err == nil || err.Error() != "index not found" {
+		t.Errorf("ResolveIndices(missing) error = %v, want index not found", err)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// 推理任务类型
+const (
+	InferenceTextEmbedding   = "text_embedding"
+	InferenceSparseEmbedding = "sparse_embedding"
+	InferenceRerank          = "rerank"
+	InferenceCompletion      = "completion"
+)
+
+// InferenceEndpoint 推理端点配置，如 {Service: "elasticsearch", ServiceSettings: {"model_id": ".multilingual-e5-small", "num_allocations": 1, "num_threads": 1}}
+type InferenceEndpoint struct {
+	Service         string                 `json:"service"`
+	ServiceSettings map[string]interface{} `json:"service_settings"`
+	TaskSettings    map[string]interface{} `json:"task_settings,omitempty"`
+}
+
+// PutInferenceEndpoint 创建推理端点，taskType 为 InferenceTextEmbedding 等任务类型
+func (c *ElasticsearchClient) PutInferenceEndpoint(ctx context.Context, taskType string, inferenceID string, endpoint InferenceEndpoint) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if endpoint.Service == "" {
+		return fmt.Errorf("inference endpoint %s requires a service", inferenceID)
+	}
+	body, err := json.Marshal(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inference endpoint: %w", err)
+	}
+	return c.resourceRequest(ctx, "inference put", inferenceID, esapi.InferencePutRequest{
+		TaskType:    taskType,
+		InferenceID: inferenceID,
+		Body:        strings.NewReader(string(body)),
+	}, nil)
+}
+
+// DeleteInferenceEndpoint 删除推理端点
+func (c *ElasticsearchClient) DeleteInferenceEndpoint(ctx context.Context, taskType string, inferenceID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "inference delete", inferenceID, esapi.InferenceDeleteRequest{
+		TaskType:    taskType,
+		InferenceID: inferenceID,
+	}, nil)
+}
+
+// Inference 使用推理端点处理输入，返回原始结果（结构随任务类型不同）
+func (c *ElasticsearchClient) Inference(ctx context.Context, taskType string, inferenceID string, input []string) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := c.inference(ctx, taskType, inferenceID, input, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TextEmbeddings 使用 text_embedding 推理端点生成稠密向量，顺序与 input 一致，可直接用于 kNN 检索
+func (c *ElasticsearchClient) TextEmbeddings(ctx context.Context, inferenceID string, input []string) ([][]float64, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if len(input) == 0 {
+		return [][]float64{}, nil
+	}
+
+	var result struct {
+		TextEmbedding []struct {
+			Embedding []float64 `json:"embedding"`
+		} `json:"text_embedding"`
+	}
+	if err := c.inference(ctx, InferenceTextEmbedding, inferenceID, input, &result); err != nil {
+		return nil, err
+	}
+	if len(result.TextEmbedding) != len(input) {
+		return nil, fmt.Errorf("inference returned %d embeddings for %d inputs", len(result.TextEmbedding), len(input))
+	}
+
+	embeddings := make([][]float64, len(result.TextEmbedding))
+	for i, item := range result.TextEmbedding {
+		embeddings[i] = item.Embedding
+	}
+	return embeddings, nil
+}
+
+// inference 内部执行推理请求
+func (c *ElasticsearchClient) inference(ctx context.Context, taskType string, inferenceID string, input []string, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return fmt.Errorf("failed to marshal inference input: %w", err)
+	}
+	return c.resourceRequest(ctx, "inference", inferenceID, esapi.InferenceInferenceRequest{
+		TaskType:    taskType,
+		InferenceID: inferenceID,
+		Body:        strings.NewReader(string(body)),
+	}, out)
+}
+
+// ModelDeployment 已训练模型的部署状态
+type ModelDeployment struct {
+	ModelID      string `json:"model_id"`
+	DeploymentID string `json:"deployment_id"`
+	State        string `json:"state"` // starting、started、stopping、failed
+	Reason       string `json:"reason"`
+	Allocation   struct {
+		State       string `json:"state"` // starting、started、fully_allocated
+		Count       int    `json:"allocation_count"`
+		TargetCount int    `json:"target_allocation_count"`
+	} `json:"allocation_status"`
+	InferenceCount int64 `json:"inference_count"`
+}
+
+// Ready 返回部署是否已启动并至少有一个分配可以处理推理请求
+func (d *ModelDeployment) Ready() bool {
+	return d.State == "started" && d.Allocation.Count > 0
+}
+
+// ModelDeployments 查询已训练模型的部署状态，modelID 支持通配符，未部署的模型不会出现在结果中
+func (c *ElasticsearchClient) ModelDeployments(ctx context.Context, modelID string) ([]ModelDeployment, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Stats []struct {
+			ModelID    string           `json:"model_id"`
+			Deployment *ModelDeployment `json:"deployment_stats"`
+		} `json:"trained_model_stats"`
+	}
+	if err := c.resourceRequest(ctx, "ml get trained models stats", modelID, esapi.MLGetTrainedModelsStatsRequest{ModelID: modelID}, &result); err != nil {
+		return nil, err
+	}
+
+	deployments := make([]ModelDeployment, 0, len(result.Stats))
+	for _, stats := range result.Stats {
+		if stats.Deployment == nil {
+			continue
+		}
+		deployment := *stats.Deployment
+		if deployment.ModelID == "" {
+			deployment.ModelID = stats.ModelID
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
@@ -0,0 +1,94 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestInference(t *testing.T) {
+	var calls []string
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/_inference/text_embedding/e5":
+			if r.Method == http.MethodPut {
+				w.Write([]byte(`{"inference_id":"e5"}`))
+				return
+			}
+			w.Write([]byte(`{"text_embedding":[{"embedding":[0.1,0.2]},{"embedding":[0.3,0.4]}]}`))
+		case "/_inference/rerank/ranker":
+			w.Write([]byte(`{"rerank":[{"index":1,"relevance_score":0.9}]}`))
+		default:
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+	ctx := context.Background()
+
+	err := client.PutInferenceEndpoint(ctx, InferenceTextEmbedding, "e5", InferenceEndpoint{
+		Service:         "elasticsearch",
+		ServiceSettings: map[string]interface{}{"model_id": ".multilingual-e5-small", "num_allocations": 1},
+	})
+	if err != nil {
+		t.Fatalf("PutInferenceEndpoint() error = %v", err)
+	}
+	if body["service"] != "elasticsearch" || body["service_settings"] == nil {
+		t.Errorf("endpoint body = %v", body)
+	}
+	if err := client.PutInferenceEndpoint(ctx, InferenceTextEmbedding, "e5", InferenceEndpoint{}); err == nil {
+		t.Error("PutInferenceEndpoint() without service should fail")
+	}
+
+	embeddings, err := client.TextEmbeddings(ctx, "e5", []string{"hello", "world"})
+	if err != nil {
+		t.Fatalf("TextEmbeddings() error = %v", err)
+	}
+	if len(embeddings) != 2 || embeddings[1][0] != 0.3 {
+		t.Errorf("embeddings = %v", embeddings)
+	}
+	if input, _ := body["input"].([]interface{}); len(input) != 2 {
+		t.Errorf("inference body = %v", body)
+	}
+	if _, err := client.TextEmbeddings(ctx, "e5", []string{"one"}); err == nil {
+		t.Error("TextEmbeddings() should fail when the result count does not match the input")
+	}
+
+	result, err := client.Inference(ctx, InferenceRerank, "ranker", []string{"a", "b"})
+	if err != nil || result["rerank"] == nil {
+		t.Errorf("Inference() = %v, %v", result, err)
+	}
+
+	if err := client.DeleteInferenceEndpoint(ctx, InferenceTextEmbedding, "e5"); err != nil {
+		t.Fatalf("DeleteInferenceEndpoint() error = %v", err)
+	}
+	if last := calls[len(calls)-1]; last != "DELETE /_inference/text_embedding/e5" {
+		t.Errorf("last call = %s", last)
+	}
+}
+
+func TestModelDeployments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_ml/trained_models/.multilingual-e5-small/_stats" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"count":2,"trained_model_stats":[
+			{"model_id":".multilingual-e5-small","deployment_stats":{"deployment_id":"e5","state":"started","allocation_status":{"state":"fully_allocated","allocation_count":1,"target_allocation_count":1}}},
+			{"model_id":".multilingual-e5-small"}
+		]}`))
+	})
+
+	deployments, err := client.ModelDeployments(context.Background(), ".multilingual-e5-small")
+	if err != nil {
+		t.Fatalf("ModelDeployments() error = %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].ModelID != ".multilingual-e5-small" || deployments[0].DeploymentID != "e5" {
+		t.Fatalf("deployments = %+v", deployments)
+	}
+	if !deployments[0].Ready() || deployments[0].Allocation.State != "fully_allocated" {
+		t.Errorf("deployment = %+v, want ready", deployments[0])
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// PutIngestPipeline 创建或更新 ingest pipeline，pipeline 为 {"description": ..., "processors": [...]} 格式
+func (c *ElasticsearchClient) PutIngestPipeline(ctx context.Context, id string, pipeline map[string]interface{}) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("pipeline ID cannot be empty")
+	}
+
+	body, err := json.Marshal(pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pipeline: %w", err)
+	}
+
+	return executeWithTrace(
+		ctx,
+		"put_pipeline",
+		"",
+		id,
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			req := esapi.IngestPutPipelineRequest{
+				PipelineID: id,
+				Body:       strings.NewReader(string(body)),
+			}
+			res, err := req.Do(ctx, c.client)
+			if err != nil {
+				return c.requestError("put pipeline", err)
+			}
+			defer res.Body.Close()
+
+			if res.IsError() {
+				return c.responseError("put pipeline", res)
+			}
+			return nil
+		},
+	)
+}
+
+// DeleteIngestPipeline 删除 ingest pipeline
+func (c *ElasticsearchClient) DeleteIngestPipeline(ctx context.Context, id string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("pipeline ID cannot be empty")
+	}
+
+	return executeWithTrace(
+		ctx,
+		"delete_pipeline",
+		"",
+		id,
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			res, err := esapi.IngestDeletePipelineRequest{PipelineID: id}.Do(ctx, c.client)
+			if err != nil {
+				return c.requestError("delete pipeline", err)
+			}
+			defer res.Body.Close()
+
+			if res.IsError() {
+				return c.responseError("delete pipeline", res)
+			}
+			return nil
+		},
+	)
+}
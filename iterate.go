@@ -0,0 +1,413 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// IterateStrategy 遍历查询结果使用的分页方式
+type IterateStrategy int
+
+const (
+	// IterateAuto 根据集群版本自动选择：7.12 及以上使用 PIT，否则使用 scroll
+	IterateAuto IterateStrategy = iota
+	// IteratePointInTime 使用 point in time + search_after 分页
+	IteratePointInTime
+	// IterateScroll 使用 scroll 分页
+	IterateScroll
+)
+
+// IterateOptions 遍历选项
+type IterateOptions struct {
+	BatchSize int             // 每次请求返回的文档数，默认 1000
+	KeepAlive time.Duration   // PIT 或 scroll 上下文的保持时间，默认 1 分钟
+	Strategy  IterateStrategy // 分页方式，默认自动选择
+}
+
+// withDefaults 返回填充默认值后的遍历选项
+func (o *IterateOptions) withDefaults() IterateOptions {
+	opts := IterateOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = time.Minute
+	}
+	return opts
+}
+
+// Iterator 查询结果迭代器，屏蔽 PIT 和 scroll 的分页细节。
+// 使用方式与 sql.Rows 相同：循环调用 Next，读取 Hit，结束后检查 Err 并调用 Close
+type Iterator struct {
+	client   *ElasticsearchClient
+	ctx      context.Context
+	index    string
+	query    map[string]interface{}
+	opts     IterateOptions
+	strategy IterateStrategy
+
+	hits    []Hit
+	pos     int
+	current Hit
+	err     error
+	started bool
+	last    bool // 当前批次是最后一批
+	closed  bool
+
+	pitID       string
+	scrollID    string
+	searchAfter interface{}
+}
+
+// Iterate 创建遍历查询匹配的全部文档的迭代器，按批次惰性请求。
+// 集群支持时使用 PIT + search_after，结果为一致的快照；否则回退为 scroll
+func (c *ElasticsearchClient) Iterate(ctx context.Context, index string, query map[string]interface{}, opts *IterateOptions) *Iterator {
+	it := &Iterator{client: c, ctx: ctx, opts: opts.withDefaults()}
+	if err := c.ready(); err != nil {
+		it.err = err
+		return it
+	}
+
+	it.index = c.resolveIndex(ctx, index)
+	it.strategy = it.opts.Strategy
+	if it.strategy == IterateAuto {
+		it.strategy = IterateScroll
+		if c.supportsPointInTime() {
+			it.strategy = IteratePointInTime
+		}
+	}
+
+	query, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		it.err = err
+		return it
+	}
+	if err := c.limits.checkQuery(query); err != nil {
+		it.err = err
+		return it
+	}
+	it.query = query
+	return it
+}
+
+// Strategy 返回迭代器实际使用的分页方式
+func (it *Iterator) Strategy() IterateStrategy {
+	return it.strategy
+}
+
+// Next 前进到下一个文档，没有更多文档或出错时返回 false
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.closed {
+		return false
+	}
+	if it.pos >= len(it.hits) {
+		if it.started && it.last {
+			it.release()
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			it.release()
+			return false
+		}
+		if len(it.hits) == 0 {
+			it.release()
+			return false
+		}
+	}
+	it.current = it.hits[it.pos]
+	it.pos++
+	return true
+}
+
+// Hit 返回当前文档
+func (it *Iterator) Hit() Hit {
+	return it.current
+}
+
+// Err 返回遍历过程中的错误
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close 释放服务端的 PIT 或 scroll 上下文，可重复调用
+func (it *Iterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	err := it.release()
+	it.closed = true
+	return err
+}
+
+// fetch 请求下一批文档
+func (it *Iterator) fetch() error {
+	c := it.client
+	return executeWithTrace(
+		it.ctx,
+		"iterate",
+		it.index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var (
+				hits []map[string]interface{}
+				err  error
+			)
+			if it.strategy == IteratePointInTime {
+				hits, err = it.fetchPointInTime(ctx)
+			} else {
+				hits, err = it.fetchScroll(ctx)
+			}
+			if err != nil {
+				return err
+			}
+			it.started = true
+			it.last = len(hits) < it.opts.BatchSize
+			return it.setHits(hits)
+		},
+	)
+}
+
+// setHits 应用读取转换并将本批命中转换为 Hit
+func (it *Iterator) setHits(hits []map[string]interface{}) error {
+	items := make([]interface{}, len(hits))
+	for i, hit := range hits {
+		items[i] = hit
+	}
+	if it.client.readTransformer != nil {
+		if err := it.client.transformHits(it.index, items, false); err != nil {
+			return err
+		}
+	}
+	decoded, err := decodeHits(map[string]interface{}{"hits": items})
+	if err != nil {
+		return err
+	}
+	it.hits = decoded
+	it.pos = 0
+	return nil
+}
+
+// fetchPointInTime 使用 PIT + search_after 请求下一批文档
+func (it *Iterator) fetchPointInTime(ctx context.Context) ([]map[string]interface{}, error) {
+	c := it.client
+	keepAlive := formatKeepAlive(it.opts.KeepAlive)
+	if it.pitID == "" {
+		id, err := c.openPointInTime(ctx, it.index, keepAlive)
+		if err != nil {
+			return nil, err
+		}
+		it.pitID = id
+	}
+
+	body := make(map[string]interface{}, len(it.query)+4)
+	for k, v := range it.query {
+		body[k] = v
+	}
+	if _, ok := body["sort"]; !ok {
+		body["sort"] = []interface{}{map[string]interface{}{"_shard_doc": "asc"}}
+	}
+	body["size"] = it.opts.BatchSize
+	body["pit"] = map[string]interface{}{"id": it.pitID, "keep_alive": keepAlive}
+	if it.searchAfter != nil {
+		body["search_after"] = it.searchAfter
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+	if err := c.limits.checkBytes(len(bodyBytes)); err != nil {
+		return nil, err
+	}
+
+	res, err := esapi.SearchRequest{
+		Body:                      strings.NewReader(string(bodyBytes)),
+		AllowPartialSearchResults: c.allowPartialSearchResults(ctx),
+	}.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("search", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("search", res)
+	}
+
+	var result struct {
+		PitID string `json:"pit_id"`
+		Hits  struct {
+			Hits []map[string]interface{} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.PitID != "" {
+		it.pitID = result.PitID
+	}
+	if n := len(result.Hits.Hits); n > 0 {
+		it.searchAfter = result.Hits.Hits[n-1]["sort"]
+	}
+	return result.Hits.Hits, nil
+}
+
+// fetchScroll 使用 scroll 请求下一批文档
+func (it *Iterator) fetchScroll(ctx context.Context) ([]map[string]interface{}, error) {
+	c := it.client
+	var (
+		res *esapi.Response
+		err error
+	)
+	if it.scrollID == "" {
+		body := make(map[string]interface{}, len(it.query)+1)
+		for k, v := range it.query {
+			body[k] = v
+		}
+		if _, ok := body["sort"]; !ok {
+			body["sort"] = []string{"_doc"}
+		}
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal query: %w", err)
+		}
+		if err := c.limits.checkBytes(len(bodyBytes)); err != nil {
+			return nil, err
+		}
+		size := it.opts.BatchSize
+		res, err = esapi.SearchRequest{
+			Index:  []string{it.index},
+			Body:   strings.NewReader(string(bodyBytes)),
+			Scroll: it.opts.KeepAlive,
+			Size:   &size,
+
+			AllowPartialSearchResults: c.allowPartialSearchResults(ctx),
+		}.Do(ctx, c.client)
+		if err != nil {
+			return nil, c.requestError("scroll", err)
+		}
+	} else {
+		res, err = c.scrollNext(ctx, it.scrollID, it.opts.KeepAlive)
+		if err != nil {
+			return nil, c.requestError("scroll", err)
+		}
+	}
+
+	id, hits, err := c.decodeScrollResponse(res)
+	if id != "" {
+		it.scrollID = id
+	}
+	return hits, err
+}
+
+// release 释放服务端上下文
+func (it *Iterator) release() error {
+	var err error
+	if it.pitID != "" {
+		err = it.client.closePointInTime(it.pitID)
+		it.pitID = ""
+	}
+	if it.scrollID != "" {
+		it.client.clearScroll(it.scrollID)
+		it.scrollID = ""
+	}
+	return err
+}
+
+// openPointInTime 在索引上打开 PIT，返回 PIT ID
+func (c *ElasticsearchClient) openPointInTime(ctx context.Context, index string, keepAlive string) (string, error) {
+	res, err := esapi.OpenPointInTimeRequest{
+		Index:     []string{index},
+		KeepAlive: keepAlive,
+	}.Do(ctx, c.client)
+	if err != nil {
+		return "", c.requestError("open point in time", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", c.responseError("open point in time", res)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.ID, nil
+}
+
+// closePointInTime 关闭 PIT，使用独立的 context 以便在调用方取消后仍能释放服务端资源
+func (c *ElasticsearchClient) closePointInTime(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]interface{}{"id": id})
+	if err != nil {
+		return fmt.Errorf("failed to marshal point in time id: %w", err)
+	}
+	res, err := esapi.ClosePointInTimeRequest{Body: strings.NewReader(string(body))}.Do(ctx, c.client)
+	if err != nil {
+		return c.requestError("close point in time", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return c.responseError("close point in time", res)
+	}
+	return nil
+}
+
+// supportsPointInTime 判断集群是否支持 PIT 和 _shard_doc 排序（7.12 及以上）
+func (c *ElasticsearchClient) supportsPointInTime() bool {
+	major, minor, ok := parseVersion(c.serverVersion)
+	if !ok {
+		return false
+	}
+	return major > 7 || (major == 7 && minor >= 12)
+}
+
+// parseVersion 解析 "8.11.1" 形式的版本号中的主版本和次版本
+func parseVersion(version string) (int, int, bool) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,107 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIteratePointInTime(t *testing.T) {
+	var searches []map[string]interface{}
+	var closed bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/orders/_pit":
+			if r.URL.Query().Get("keep_alive") != "60s" {
+				t.Errorf("keep_alive = %q, want 60s", r.URL.Query().Get("keep_alive"))
+			}
+			w.Write([]byte(`{"id":"pit-1"}`))
+		case r.URL.Path == "/_search":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			searches = append(searches, body)
+			if len(searches) == 1 {
+				w.Write([]byte(`{"pit_id":"pit-2","hits":{"hits":[{"_id":"1","_source":{"n":1},"sort":[1]},{"_id":"2","_source":{"n":2},"sort":[2]}]}}`))
+				return
+			}
+			w.Write([]byte(`{"pit_id":"pit-2","hits":{"hits":[{"_id":"3","_source":{"n":3},"sort":[3]}]}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/_pit":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			closed = body["id"] == "pit-2"
+			w.Write([]byte(`{"succeeded":true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	it := client.Iterate(context.Background(), "orders", SearchBody(Term("status", "paid")), &IterateOptions{BatchSize: 2})
+	defer it.Close()
+	if it.Strategy() != IteratePointInTime {
+		t.Fatalf("Strategy() = %v, want IteratePointInTime", it.Strategy())
+	}
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Hit().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(ids) != 3 || ids[2] != "3" {
+		t.Errorf("ids = %v, want [1 2 3]", ids)
+	}
+	if len(searches) != 2 {
+		t.Fatalf("searches = %d, want 2", len(searches))
+	}
+	if pit := searches[1]["pit"].(map[string]interface{}); pit["id"] != "pit-2" {
+		t.Errorf("second search pit = %v, want pit-2", pit)
+	}
+	if after := searches[1]["search_after"].([]interface{}); after[0] != float64(2) {
+		t.Errorf("search_after = %v, want [2]", after)
+	}
+	if !closed {
+		t.Error("point in time was not closed")
+	}
+}
+
+func TestIterateScroll(t *testing.T) {
+	var cleared bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/orders/_search":
+			w.Write([]byte(`{"_scroll_id":"s1","hits":{"hits":[{"_id":"1","_source":{}},{"_id":"2","_source":{}}]}}`))
+		case r.URL.Path == "/_search/scroll" && r.Method == "POST":
+			w.Write([]byte(`{"_scroll_id":"s1","hits":{"hits":[]}}`))
+		case r.URL.Path == "/_search/scroll" && r.Method == "DELETE":
+			cleared = true
+			w.Write([]byte(`{"succeeded":true}`))
+		}
+	})
+
+	it := client.Iterate(context.Background(), "orders", nil, &IterateOptions{BatchSize: 2, Strategy: IterateScroll})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err := it.Err(); err != nil || count != 2 {
+		t.Fatalf("count = %d, Err() = %v", count, err)
+	}
+	if err := it.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if !cleared {
+		t.Error("scroll was not cleared")
+	}
+}
+
+func TestSupportsPointInTime(t *testing.T) {
+	tests := map[string]bool{"8.11.1": true, "7.12.0": true, "7.10.2": false, "6.8.0": false, "": false}
+	for version, want := range tests {
+		client := &ElasticsearchClient{serverVersion: version}
+		if got := client.supportsPointInTime(); got != want {
+			t.Errorf("supportsPointInTime(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// maxNumCandidates 服务端允许的 num_candidates 上限
+const maxNumCandidates = 10000
+
+// VectorSearch 向量检索条件，支持两种多向量存储方式：
+// 设置 NestedPath 时向量存放在 nested 字段中（每个段落一个向量），服务端按父文档去重并通过 inner_hits 返回匹配段落；
+// 设置 ParentField 时每个分块为独立文档，结果按父文档 ID 在客户端去重
+type VectorSearch struct {
+	Field         string                 // 向量字段，nested 时为完整路径，如 passages.vector
+	Vector        []float64              // 查询向量
+	K             int                    // 返回的父文档数，默认 10
+	NumCandidates int                    // 每个分片的候选数，默认 max(10*K, 100)
+	Filter        map[string]interface{} // 预过滤条件，nested 时只能作用于父文档字段
+	NestedPath    string                 // nested 字段路径，如 passages
+	ParentField   string                 // 分块文档中存放父文档 ID 的字段
+	Passages      int                    // 每个父文档返回的最多段落数，默认 3
+}
+
+// VectorMatch 按父文档聚合的向量检索结果
+type VectorMatch struct {
+	ParentID string    // 父文档 ID
+	Score    float64   // 最佳段落的评分
+	Hit      Hit       // nested 时为父文档，分块文档时为评分最高的分块
+	Passages []Passage // 匹配的段落，按评分降序
+}
+
+// Passage 匹配的段落
+type Passage struct {
+	ID     string          // 分块文档 ID，nested 时为空
+	Offset int             // 段落在 nested 数组中的位置，分块文档时为 -1
+	Score  float64         // 相似度评分
+	Source json.RawMessage // 段落内容（nested 对象或分块文档的 _source）
+}
+
+// withDefaults 校验检索条件并返回填充默认值后的副本
+func (s *VectorSearch) withDefaults() (VectorSearch, error) {
+	v := *s
+	if v.Field == "" || len(v.Vector) == 0 {
+		return v, fmt.Errorf("vector search requires a field and a query vector")
+	}
+	if v.NestedPath != "" && v.ParentField != "" {
+		return v, fmt.Errorf("vector search cannot use both nested path and parent field")
+	}
+	if v.K <= 0 {
+		v.K = 10
+	}
+	if v.Passages <= 0 {
+		v.Passages = 3
+	}
+	return v, nil
+}
+
+// knn 构建 knn 子句，k 为需要召回的文档数
+func (s *VectorSearch) knn(k int) map[string]interface{} {
+	candidates := s.NumCandidates
+	if candidates <= 0 {
+		candidates = min(max(10*k, 100), maxNumCandidates)
+	}
+	knn := map[string]interface{}{
+		"field":          s.Field,
+		"query_vector":   s.Vector,
+		"k":              k,
+		"num_candidates": max(candidates, k),
+	}
+	if s.Filter != nil {
+		knn["filter"] = s.Filter
+	}
+	if s.NestedPath != "" {
+		knn["inner_hits"] = map[string]interface{}{"size": s.Passages}
+	}
+	return knn
+}
+
+// SearchVectors 执行多向量 kNN 检索并按父文档去重，适用于对分块文档做 RAG 召回
+func (c *ElasticsearchClient) SearchVectors(ctx context.Context, index string, search VectorSearch, opts ...SearchOption) ([]VectorMatch, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	s, err := search.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	// 分块文档需要多召回一些，去重后才能凑满 K 个父文档
+	k := s.K
+	if s.ParentField != "" {
+		k = min(s.K*s.Passages, maxNumCandidates)
+	}
+	body := map[string]interface{}{
+		"knn":  s.knn(k),
+		"size": k,
+	}
+
+	result, err := c.Search(ctx, index, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(result["hits"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hits: %w", err)
+	}
+	var hits struct {
+		Hits []vectorHit `json:"hits"`
+	}
+	if err := json.Unmarshal(data, &hits); err != nil {
+		return nil, fmt.Errorf("failed to decode hits: %w", err)
+	}
+
+	if s.ParentField != "" {
+		return groupChunks(hits.Hits, s.ParentField, s.K, s.Passages)
+	}
+	return nestedMatches(hits.Hits, s.NestedPath), nil
+}
+
+// vectorHit 包含 inner_hits 的命中文档
+type vectorHit struct {
+	Hit
+	InnerHits map[string]struct {
+		Hits struct {
+			Hits []struct {
+				Score  float64         `json:"_score"`
+				Source json.RawMessage `json:"_source"`
+				Nested struct {
+					Offset int `json:"offset"`
+				} `json:"_nested"`
+			} `json:"hits"`
+		} `json:"hits"`
+	} `json:"inner_hits"`
+}
+
+// nestedMatches 将 nested kNN 的命中转换为检索结果，服务端已按父文档去重
+func nestedMatches(hits []vectorHit, path string) []VectorMatch {
+	matches := make([]VectorMatch, 0, len(hits))
+	for _, hit := range hits {
+		match := VectorMatch{ParentID: hit.ID, Score: hit.Score, Hit: hit.Hit}
+		if path != "" {
+			for _, inner := range hit.InnerHits[path].Hits.Hits {
+				match.Passages = append(match.Passages, Passage{
+					Offset: inner.Nested.Offset,
+					Score:  inner.Score,
+					Source: inner.Source,
+				})
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// groupChunks 按父文档 ID 聚合分块命中，保留评分最高的 limit 个父文档，每个父文档最多 passages 个段落
+func groupChunks(hits []vectorHit, parentField string, limit int, passages int) ([]VectorMatch, error) {
+	var matches []VectorMatch
+	positions := make(map[string]int)
+	for _, hit := range hits {
+		var source map[string]interface{}
+		if err := hit.DecodeSource(&source); err != nil {
+			return nil, err
+		}
+		parent, ok := source[parentField]
+		if !ok {
+			return nil, fmt.Errorf("chunk %s has no %s field", hit.ID, parentField)
+		}
+		parentID := fmt.Sprint(parent)
+
+		passage := Passage{ID: hit.ID, Offset: -1, Score: hit.Score, Source: hit.Source}
+		pos, ok := positions[parentID]
+		if !ok {
+			positions[parentID] = len(matches)
+			matches = append(matches, VectorMatch{ParentID: parentID, Score: hit.Score, Hit: hit.Hit, Passages: []Passage{passage}})
+			continue
+		}
+		match := &matches[pos]
+		if len(match.Passages) < passages {
+			match.Passages = append(match.Passages, passage)
+		}
+		if hit.Score > match.Score {
+			match.Score = hit.Score
+			match.Hit = hit.Hit
+		}
+	}
+
+	for i := range matches {
+		sort.SliceStable(matches[i].Passages, func(a, b int) bool {
+			return matches[i].Passages[a].Score > matches[i].Passages[b].Score
+		})
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Score > matches[b].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// withKNNFilter 将过滤条件加入每个 knn 子句（单个对象或数组）的 filter，返回新的请求体。
+// 结构体等非 map 类型的子句先经 JSON 转换为 map，无法转换时返回错误，避免过滤条件被静默丢弃
+func withKNNFilter(body map[string]interface{}, knn interface{}, filter map[string]interface{}) (map[string]interface{}, error) {
+	addFilter := func(clause interface{}) (interface{}, error) {
+		m, err := documentMap(clause)
+		if err != nil {
+			return nil, fmt.Errorf("invalid knn clause: %w", err)
+		}
+		switch existing := m["filter"].(type) {
+		case nil:
+			m["filter"] = filter
+		case []interface{}:
+			m["filter"] = append(append([]interface{}{}, existing...), filter)
+		default:
+			m["filter"] = []interface{}{existing, filter}
+		}
+		return m, nil
+	}
+	addFilters := func(clauses []interface{}) (interface{}, error) {
+		wrapped := make([]interface{}, len(clauses))
+		for i, clause := range clauses {
+			var err error
+			if wrapped[i], err = addFilter(clause); err != nil {
+				return nil, err
+			}
+		}
+		return wrapped, nil
+	}
+
+	switch clauses := knn.(type) {
+	case map[string]interface{}, []interface{}:
+	case []map[string]interface{}:
+		list := make([]interface{}, len(clauses))
+		for i, clause := range clauses {
+			list[i] = clause
+		}
+		knn = list
+	default:
+		data, err := json.Marshal(knn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid knn clause: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&knn); err != nil {
+			return nil, fmt.Errorf("invalid knn clause: %w", err)
+		}
+	}
+
+	var wrapped interface{}
+	var err error
+	if clauses, ok := knn.([]interface{}); ok {
+		wrapped, err = addFilters(clauses)
+	} else {
+		wrapped, err = addFilter(knn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		result[k] = v
+	}
+	result["knn"] = wrapped
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSearchVectorsNested(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"hits":{"total":{"value":2},"hits":[
+			{"_id":"doc-1","_score":0.9,"_source":{"title":"Go"},"inner_hits":{"passages":{"hits":{"hits":[
+				{"_score":0.9,"_nested":{"field":"passages","offset":2},"_source":{"text":"goroutines"}},
+				{"_score":0.7,"_nested":{"field":"passages","offset":0},"_source":{"text":"channels"}}
+			]}}}},
+			{"_id":"doc-2","_score":0.6,"_source":{"title":"Rust"},"inner_hits":{"passages":{"hits":{"hits":[]}}}}
+		]}}`))
+	})
+
+	matches, err := client.SearchVectors(context.Background(), "docs", VectorSearch{
+		Field:      "passages.vector",
+		Vector:     []float64{0.1, 0.2},
+		K:          2,
+		NestedPath: "passages",
+		Passages:   2,
+		Filter:     Term("lang", "en").Source(),
+	})
+	if err != nil {
+		t.Fatalf("SearchVectors() error = %v", err)
+	}
+
+	knn := got["knn"].(map[string]interface{})
+	if knn["field"] != "passages.vector" || knn["k"] != float64(2) || knn["num_candidates"] != float64(100) || knn["filter"] == nil {
+		t.Errorf("knn = %v", knn)
+	}
+	if inner, _ := knn["inner_hits"].(map[string]interface{}); inner["size"] != float64(2) {
+		t.Errorf("inner_hits = %v", knn["inner_hits"])
+	}
+	if _, ok := got["query"]; ok {
+		t.Errorf("body = %v, should not contain query", got)
+	}
+
+	if len(matches) != 2 || matches[0].ParentID != "doc-1" || len(matches[0].Passages) != 2 {
+		t.Fatalf("matches = %+v", matches)
+	}
+	if p := matches[0].Passages[0]; p.Offset != 2 || p.Score != 0.9 || string(p.Source) != `{"text":"goroutines"}` {
+		t.Errorf("passage = %+v", p)
+	}
+	if len(matches[1].Passages) != 0 {
+		t.Errorf("matches[1] = %+v", matches[1])
+	}
+}
+
+func TestSearchVectorsChunks(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"hits":{"hits":[
+			{"_id":"a#1","_score":0.95,"_source":{"doc_id":"a","text":"one"}},
+			{"_id":"b#1","_score":0.9,"_source":{"doc_id":"b","text":"two"}},
+			{"_id":"a#2","_score":0.85,"_source":{"doc_id":"a","text":"three"}},
+			{"_id":"a#3","_score":0.8,"_source":{"doc_id":"a","text":"four"}},
+			{"_id":"c#1","_score":0.5,"_source":{"doc_id":"c","text":"five"}}
+		]}}`))
+	})
+
+	matches, err := client.SearchVectors(context.Background(), "chunks", VectorSearch{
+		Field:       "vector",
+		Vector:      []float64{1},
+		K:           2,
+		ParentField: "doc_id",
+		Passages:    2,
+	})
+	if err != nil {
+		t.Fatalf("SearchVectors() error = %v", err)
+	}
+	if knn := got["knn"].(map[string]interface{}); knn["k"] != float64(4) || got["size"] != float64(4) {
+		t.Errorf("body = %v", got)
+	}
+	if len(matches) != 2 || matches[0].ParentID != "a" || matches[1].ParentID != "b" {
+		t.Fatalf("matches = %+v", matches)
+	}
+	if len(matches[0].Passages) != 2 || matches[0].Passages[1].ID != "a#2" || matches[0].Hit.ID != "a#1" {
+		t.Errorf("matches[0] = %+v", matches[0])
+	}
+
+	if _, err := client.SearchVectors(context.Background(), "chunks", VectorSearch{Field: "vector"}); err == nil {
+		t.Error("SearchVectors() without vector should fail")
+	}
+	if _, err := client.SearchVectors(context.Background(), "chunks", VectorSearch{Field: "v", Vector: []float64{1}, NestedPath: "p", ParentField: "d"}); err == nil {
+		t.Error("SearchVectors() with both nested path and parent field should fail")
+	}
+}
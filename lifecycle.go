@@ -0,0 +1,229 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrClientClosed 客户端已关闭，不能再发送请求或重新启动
+var ErrClientClosed = errors.New("elasticsearch client is closed")
+
+// ErrNotInitialized 客户端未通过 NewElasticsearch 初始化（如零值或 nil 指针）
+var ErrNotInitialized = errors.New("elasticsearch client is not initialized")
+
+// clientState 客户端的生命周期状态
+type clientState int
+
+const (
+	stateNew     clientState = iota // 已创建，未调用 Start
+	stateStarted                    // 已启动后台任务
+	stateClosed                     // 已关闭
+)
+
+// resource 客户端持有的后台资源，关闭时按注册的逆序停止
+type resource struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// lifecycle 客户端的生命周期状态和持有的后台资源
+type lifecycle struct {
+	mu        sync.Mutex
+	state     clientState
+	resources []resource
+	closed    chan struct{} // 关闭完成后关闭该通道
+	closeErr  error
+}
+
+// track 注册需要在关闭时停止的后台资源，客户端已关闭时立即停止该资源
+func (l *lifecycle) track(name string, stop func(ctx context.Context) error) error {
+	l.mu.Lock()
+	if l.state != stateClosed {
+		l.resources = append(l.resources, resource{name: name, stop: stop})
+		l.mu.Unlock()
+		return nil
+	}
+	l.mu.Unlock()
+	return stop(context.Background())
+}
+
+// isClosed 返回客户端是否已关闭
+func (l *lifecycle) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state == stateClosed
+}
+
+// shutdown 停止所有后台资源并返回汇总的错误。重复或并发调用时等待首次关闭完成并返回相同的结果
+func (l *lifecycle) shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	if l.state == stateClosed {
+		closed := l.closed
+		l.mu.Unlock()
+		select {
+		case <-closed:
+			return l.closeErr
+		case <-ctx.Done():
+			return fmt.Errorf("failed to stop elasticsearch client: %w", ctx.Err())
+		}
+	}
+	l.state = stateClosed
+	l.closed = make(chan struct{})
+	resources := l.resources
+	l.resources = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(resources) - 1; i >= 0; i-- {
+		if err := resources[i].stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", resources[i].name, err))
+		}
+	}
+	l.closeErr = errors.Join(errs...)
+	close(l.closed)
+	return l.closeErr
+}
+
+// isStopped 返回客户端是否已完成关闭。关闭过程中后台资源仍可发送请求（如 BulkIndexer 发送缓冲的操作）
+func (l *lifecycle) isStopped() bool {
+	l.mu.Lock()
+	closed := l.closed
+	l.mu.Unlock()
+	if closed == nil {
+		return false
+	}
+	select {
+	case <-closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ready 检查客户端是否可以发送请求，所有公开的请求方法在执行前调用
+func (c *ElasticsearchClient) ready() error {
+	if c == nil || c.client == nil {
+		return ErrNotInitialized
+	}
+	if c.lifecycle.isClosed() {
+		return ErrClientClosed
+	}
+	return nil
+}
+
+// Start 启动客户端：检查连接，配置 HealthCacheTTL 时启动后台健康检查循环，
+// 使 IsConnected 始终返回缓存结果而不阻塞调用方。用于接入应用容器的生命周期
+func (c *ElasticsearchClient) Start(ctx context.Context) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	c.lifecycle.mu.Lock()
+	state := c.lifecycle.state
+	c.lifecycle.mu.Unlock()
+
+	switch state {
+	case stateClosed:
+		return ErrClientClosed
+	case stateStarted:
+		return fmt.Errorf("elasticsearch client already started")
+	}
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+
+	c.lifecycle.mu.Lock()
+	switch c.lifecycle.state {
+	case stateClosed:
+		c.lifecycle.mu.Unlock()
+		return ErrClientClosed
+	case stateStarted:
+		c.lifecycle.mu.Unlock()
+		return fmt.Errorf("elasticsearch client already started")
+	}
+	c.lifecycle.state = stateStarted
+	c.lifecycle.mu.Unlock()
+
+	c.health.set(true)
+	if c.health.ttl > 0 {
+		loopCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go c.healthLoop(loopCtx, c.health.ttl, done)
+		return c.lifecycle.track("health loop", func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}
+	return nil
+}
+
+// Stop 停止后台任务并关闭客户端，ctx 到期时不再等待后台任务退出
+func (c *ElasticsearchClient) Stop(ctx context.Context) error {
+	if c == nil {
+		return ErrNotInitialized
+	}
+	return c.lifecycle.shutdown(ctx)
+}
+
+// Close 关闭客户端并停止所有后台资源，可重复和并发调用。关闭后的请求返回 ErrClientClosed
+func (c *ElasticsearchClient) Close() error {
+	if c == nil {
+		return ErrNotInitialized
+	}
+	return c.lifecycle.shutdown(context.Background())
+}
+
+// healthLoop 按 interval 定期刷新健康检查缓存
+func (c *ElasticsearchClient) healthLoop(ctx context.Context, interval time.Duration, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.ForceHealthCheck()
+		}
+	}
+}
+
+// closeGuardTransport 在客户端完成关闭后拒绝新的请求，使直接使用原生客户端的调用也返回 ErrClientClosed
+type closeGuardTransport struct {
+	next      http.RoundTripper
+	lifecycle *lifecycle // 客户端创建完成前为 nil
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *closeGuardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.lifecycle != nil && t.lifecycle.isStopped() {
+		return nil, ErrClientClosed
+	}
+	return t.next.RoundTrip(req)
+}
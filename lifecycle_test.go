@@ -0,0 +1,141 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartStop(t *testing.T) {
+	var pings int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if r.Method == "HEAD" && r.URL.Path == "/" {
+			atomic.AddInt32(&pings, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testInfoResponse))
+	}))
+	defer ts.Close()
+
+	client, err := NewElasticsearch(&Options{
+		Addresses:      []string{ts.URL},
+		DialTimeout:    10 * time.Second,
+		HealthCacheTTL: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewElasticsearch() error = %v", err)
+	}
+
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := client.Start(context.Background()); err == nil {
+		t.Error("second Start() should return error")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&pings); got < 3 {
+		t.Errorf("pings = %d, want background health checks after Start", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	stopped := atomic.LoadInt32(&pings)
+	time.Sleep(30 * time.Millisecond)
+	if got := atomic.LoadInt32(&pings); got != stopped {
+		t.Errorf("pings = %d after Stop, want %d", got, stopped)
+	}
+
+	if err := client.Start(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Start() after Stop error = %v, want ErrClientClosed", err)
+	}
+	if err := client.Stop(ctx); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
+
+func TestStartConnectionError(t *testing.T) {
+	var down atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testInfoResponse))
+	}))
+	defer ts.Close()
+
+	client, err := NewElasticsearch(&Options{
+		Addresses:   []string{ts.URL},
+		DialTimeout: 10 * time.Second,
+		MaxRetries:  1,
+	})
+	if err != nil {
+		t.Fatalf("NewElasticsearch() error = %v", err)
+	}
+
+	down.Store(true)
+	if err := client.Start(context.Background()); err == nil {
+		t.Error("Start() should return error when cluster is unavailable")
+	}
+}
+
+func TestCloseIdempotent(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	})
+
+	var stops int32
+	client.lifecycle.track("first", func(context.Context) error {
+		atomic.AddInt32(&stops, 1)
+		return errors.New("first failed")
+	})
+	client.lifecycle.track("second", func(context.Context) error {
+		atomic.AddInt32(&stops, 1)
+		return errors.New("second failed")
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.Close()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&stops); got != 2 {
+		t.Errorf("resource stops = %d, want 2", got)
+	}
+	for _, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "failed to stop first") || !strings.Contains(err.Error(), "failed to stop second") {
+			t.Errorf("Close() error = %v, want aggregated errors", err)
+		}
+	}
+
+	if _, err := client.Search(context.Background(), "orders", nil); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Search() after Close error = %v, want ErrClientClosed", err)
+	}
+	if err := client.lifecycle.track("late", func(context.Context) error {
+		atomic.AddInt32(&stops, 1)
+		return nil
+	}); err != nil || atomic.LoadInt32(&stops) != 3 {
+		t.Errorf("track() after Close should stop the resource immediately")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQueryTooComplex 查询超过客户端配置的复杂度限制
+var ErrQueryTooComplex = errors.New("query exceeds client-side limits")
+
+// boolClauseKeys bool 查询中包含子句的字段
+var boolClauseKeys = []string{"must", "should", "filter", "must_not"}
+
+// queryLimits 查询复杂度限制，字段为 0 表示不限制
+type queryLimits struct {
+	maxBytes            int
+	maxBoolClauses      int
+	maxAggregationDepth int
+}
+
+// checkBytes 检查查询请求体大小
+func (l queryLimits) checkBytes(n int) error {
+	if l.maxBytes > 0 && n > l.maxBytes {
+		return fmt.Errorf("%w: body size %d bytes exceeds the limit of %d bytes", ErrQueryTooComplex, n, l.maxBytes)
+	}
+	return nil
+}
+
+// checkQuery 检查 bool 子句数量和聚合嵌套深度
+func (l queryLimits) checkQuery(query map[string]interface{}) error {
+	if query == nil {
+		return nil
+	}
+	if l.maxBoolClauses > 0 {
+		if n := countBoolClauses(query); n > l.maxBoolClauses {
+			return fmt.Errorf("%w: %d bool clauses exceed the limit of %d", ErrQueryTooComplex, n, l.maxBoolClauses)
+		}
+	}
+	if l.maxAggregationDepth > 0 {
+		if depth := aggregationDepth(query); depth > l.maxAggregationDepth {
+			return fmt.Errorf("%w: aggregation depth %d exceeds the limit of %d", ErrQueryTooComplex, depth, l.maxAggregationDepth)
+		}
+	}
+	return nil
+}
+
+// countBoolClauses 递归统计查询中所有 bool 子句的数量
+func countBoolClauses(value interface{}) int {
+	count := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if key == "bool" {
+				if boolQuery, ok := child.(map[string]interface{}); ok {
+					for _, clauseKey := range boolClauseKeys {
+						switch clauses := boolQuery[clauseKey].(type) {
+						case nil:
+						case []interface{}:
+							count += len(clauses)
+						case []map[string]interface{}:
+							count += len(clauses)
+						default:
+							count++
+						}
+					}
+				}
+			}
+			count += countBoolClauses(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			count += countBoolClauses(child)
+		}
+	case []map[string]interface{}:
+		for _, child := range v {
+			count += countBoolClauses(child)
+		}
+	}
+	return count
+}
+
+// aggregationDepth 计算聚合的最大嵌套深度
+func aggregationDepth(value map[string]interface{}) int {
+	maxDepth := 0
+	for _, key := range []string{"aggs", "aggregations"} {
+		aggs, ok := value[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, agg := range aggs {
+			aggMap, ok := agg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if depth := 1 + aggregationDepth(aggMap); depth > maxDepth {
+				maxDepth = depth
+			}
+		}
+	}
+	return maxDepth
+}
@@ -0,0 +1,127 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCountBoolClauses(t *testing.T) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []interface{}{
+					map[string]interface{}{"match": map[string]interface{}{"title": "go"}},
+					map[string]interface{}{
+						"bool": map[string]interface{}{
+							"should": []map[string]interface{}{
+								{"term": map[string]interface{}{"tag": "a"}},
+								{"term": map[string]interface{}{"tag": "b"}},
+							},
+						},
+					},
+				},
+				"filter": map[string]interface{}{"term": map[string]interface{}{"status": "active"}},
+			},
+		},
+	}
+
+	if got := countBoolClauses(query); got != 5 {
+		t.Errorf("countBoolClauses() = %d, want 5", got)
+	}
+}
+
+func TestAggregationDepth(t *testing.T) {
+	query := map[string]interface{}{
+		"aggs": map[string]interface{}{
+			"by_status": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "status"},
+				"aggregations": map[string]interface{}{
+					"by_day": map[string]interface{}{
+						"date_histogram": map[string]interface{}{"field": "created_at"},
+						"aggs": map[string]interface{}{
+							"avg_price": map[string]interface{}{"avg": map[string]interface{}{"field": "price"}},
+						},
+					},
+				},
+			},
+			"total": map[string]interface{}{"sum": map[string]interface{}{"field": "price"}},
+		},
+	}
+
+	if got := aggregationDepth(query); got != 3 {
+		t.Errorf("aggregationDepth() = %d, want 3", got)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"a": 1}},
+					map[string]interface{}{"term": map[string]interface{}{"b": 2}},
+				},
+			},
+		},
+	}
+
+	if err := (queryLimits{}).checkQuery(query); err != nil {
+		t.Errorf("checkQuery() without limits error = %v", err)
+	}
+	if err := (queryLimits{maxBoolClauses: 1}).checkQuery(query); !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("checkQuery() error = %v, want ErrQueryTooComplex", err)
+	}
+	if err := (queryLimits{maxBytes: 10}).checkBytes(11); !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("checkBytes() error = %v, want ErrQueryTooComplex", err)
+	}
+}
+
+func TestSearch_QueryBytesLimit(t *testing.T) {
+	called := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}, func(opts *Options) {
+		opts.MaxQueryBytes = 16
+	})
+
+	query := map[string]interface{}{"query": map[string]interface{}{"match": map[string]interface{}{"title": "a long search phrase"}}}
+	_, err := client.Search(context.Background(), "test-index", query)
+	if !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("Search() error = %v, want ErrQueryTooComplex", err)
+	}
+	if called {
+		t.Error("Search() should not send oversized queries to the server")
+	}
+}
+
+func TestScanAll_QueryLimits(t *testing.T) {
+	called := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}, func(opts *Options) {
+		opts.MaxQueryBytes = 16
+		opts.MaxBoolClauses = 1
+	})
+
+	handler := func([]map[string]interface{}) error { return nil }
+	long := map[string]interface{}{"query": map[string]interface{}{"match": map[string]interface{}{"title": "a long search phrase"}}}
+	if err := client.ScanAll(context.Background(), "test-index", long, nil, handler); !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("ScanAll() error = %v, want ErrQueryTooComplex", err)
+	}
+	complex := map[string]interface{}{"query": map[string]interface{}{"bool": map[string]interface{}{
+		"must": []interface{}{Term("a", 1).Source(), Term("b", 2).Source()},
+	}}}
+	if err := client.Export(context.Background(), "test-index", complex, &ScanOptions{Slices: 2}, io.Discard); !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("Export() error = %v, want ErrQueryTooComplex", err)
+	}
+	if called {
+		t.Error("ScanAll() should not open scroll contexts for queries over the limits")
+	}
+}
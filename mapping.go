@@ -0,0 +1,249 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// updatableMappingParams 已有字段上可以修改的映射参数，其余参数修改时需要重建索引
+var updatableMappingParams = map[string]bool{
+	"ignore_above":          true,
+	"search_analyzer":       true,
+	"search_quote_analyzer": true,
+	"meta":                  true,
+	"dynamic":               true,
+}
+
+// PutMapping 为索引添加字段映射（mappings 为 {"properties": {...}} 格式），已有字段只能修改少数参数
+func (c *ElasticsearchClient) PutMapping(ctx context.Context, index string, mappings map[string]interface{}) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	body, err := json.Marshal(mappings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mappings: %w", err)
+	}
+
+	req := esapi.IndicesPutMappingRequest{
+		Index: []string{index},
+		Body:  strings.NewReader(string(body)),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return c.requestError("put mapping", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return c.responseError("put mapping", res)
+	}
+
+	return nil
+}
+
+// GetMapping 获取索引的字段映射，index 为别名或通配符时返回第一个匹配 index 名称的索引的映射
+func (c *ElasticsearchClient) GetMapping(ctx context.Context, index string) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	req := esapi.IndicesGetMappingRequest{
+		Index: []string{index},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("get mapping", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("index %w", ErrNotFound)
+		}
+		return nil, c.responseError("get mapping", res)
+	}
+
+	var result map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if entry, ok := result[index]; ok {
+		return entry.Mappings, nil
+	}
+	for _, entry := range result {
+		return entry.Mappings, nil
+	}
+	return nil, fmt.Errorf("index %w", ErrNotFound)
+}
+
+// MappingConflict 无法在现有索引上应用的映射变更
+type MappingConflict struct {
+	Field  string      // 字段路径，如 user.name、title.keyword
+	Param  string      // 冲突的参数，如 type、analyzer
+	Live   interface{} // 当前值，未设置时为 nil
+	Wanted interface{} // 期望值，未设置时为 nil
+}
+
+// MappingDiff 当前映射与期望映射的差异
+type MappingDiff struct {
+	Added     []string          // 期望映射中新增的字段，可通过 PutMapping 添加
+	Updated   []string          // 仅修改了可更新参数的字段
+	Conflicts []MappingConflict // 不兼容的变更，需要重建索引
+	Unmanaged []string          // 当前映射中存在但期望映射中没有的字段（映射无法删除）
+}
+
+// Compatible 返回期望映射是否可以通过 PutMapping 应用
+func (d *MappingDiff) Compatible() bool {
+	return len(d.Conflicts) == 0
+}
+
+// DiffMappings 比较当前映射和期望映射（均为 {"properties": {...}} 格式），报告新增字段和不兼容的变更
+func DiffMappings(live, desired map[string]interface{}) *MappingDiff {
+	diff := &MappingDiff{}
+	diff.properties("", mappingProperties(live, "properties"), mappingProperties(desired, "properties"))
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Updated)
+	sort.Strings(diff.Unmanaged)
+	sort.SliceStable(diff.Conflicts, func(i, j int) bool {
+		return diff.Conflicts[i].Field < diff.Conflicts[j].Field
+	})
+	return diff
+}
+
+// CheckMapping 比较索引的当前映射与期望映射
+func (c *ElasticsearchClient) CheckMapping(ctx context.Context, index string, desired map[string]interface{}) (*MappingDiff, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	live, err := c.GetMapping(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	return DiffMappings(live, desired), nil
+}
+
+// properties 比较同一层级的字段定义，key 为 properties 或 fields（多字段）
+func (d *MappingDiff) properties(prefix string, live, desired map[string]interface{}) {
+	for name, def := range desired {
+		path := prefix + name
+		wanted, _ := def.(map[string]interface{})
+		current, ok := live[name].(map[string]interface{})
+		if !ok {
+			d.Added = append(d.Added, path)
+			continue
+		}
+		d.field(path, current, wanted)
+	}
+	for name := range live {
+		if _, ok := desired[name]; !ok {
+			d.Unmanaged = append(d.Unmanaged, prefix+name)
+		}
+	}
+}
+
+// field 比较单个字段的定义
+func (d *MappingDiff) field(path string, live, desired map[string]interface{}) {
+	if liveType, wantedType := mappingType(live), mappingType(desired); liveType != wantedType {
+		d.Conflicts = append(d.Conflicts, MappingConflict{Field: path, Param: "type", Live: liveType, Wanted: wantedType})
+		return
+	}
+
+	updated := false
+	params := make(map[string]bool)
+	for param := range live {
+		params[param] = true
+	}
+	for param := range desired {
+		params[param] = true
+	}
+	for param := range params {
+		switch param {
+		case "type":
+			continue
+		case "properties", "fields":
+			// 对象的子字段和多字段按路径逐个比较
+			d.properties(path+".", mappingProperties(live, param), mappingProperties(desired, param))
+			continue
+		}
+		liveValue, wantedValue := live[param], desired[param]
+		if reflect.DeepEqual(normalizeMappingValue(liveValue), normalizeMappingValue(wantedValue)) {
+			continue
+		}
+		// 期望映射未声明的参数保留当前值（通常为服务端补全的默认值）
+		if _, ok := desired[param]; !ok {
+			continue
+		}
+		if updatableMappingParams[param] {
+			updated = true
+			continue
+		}
+		d.Conflicts = append(d.Conflicts, MappingConflict{Field: path, Param: param, Live: liveValue, Wanted: wantedValue})
+	}
+	if updated {
+		d.Updated = append(d.Updated, path)
+	}
+}
+
+// mappingProperties 返回字段定义中的子字段
+func mappingProperties(def map[string]interface{}, key string) map[string]interface{} {
+	properties, _ := def[key].(map[string]interface{})
+	return properties
+}
+
+// mappingType 返回字段类型，声明了 properties 但没有 type 的字段为 object
+func mappingType(def map[string]interface{}) string {
+	if t, ok := def["type"].(string); ok {
+		return t
+	}
+	return "object"
+}
+
+// normalizeMappingValue 统一映射参数的表示，服务端返回的数值和布尔值可能为字符串
+func normalizeMappingValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		var normalized interface{}
+		json.Unmarshal(data, &normalized)
+		return normalized
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,102 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestPutAndGetMapping(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/orders/_mapping":
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/orders/_mapping":
+			w.Write([]byte(`{"orders_v2":{"mappings":{"properties":{"status":{"type":"keyword"}}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`))
+		}
+	})
+	ctx := context.Background()
+
+	mappings := map[string]interface{}{"properties": map[string]interface{}{"status": map[string]interface{}{"type": "keyword"}}}
+	if err := client.PutMapping(ctx, "orders", mappings); err != nil {
+		t.Fatalf("PutMapping() error = %v", err)
+	}
+	if got["properties"] == nil {
+		t.Errorf("PutMapping() body = %v", got)
+	}
+
+	live, err := client.GetMapping(ctx, "orders")
+	if err != nil {
+		t.Fatalf("GetMapping() error = %v", err)
+	}
+	if mappingProperties(live, "properties")["status"] == nil {
+		t.Errorf("GetMapping() = %v", live)
+	}
+	if _, err := client.GetMapping(ctx, "missing"); !IsNotFound(err) {
+		t.Errorf("GetMapping(missing) error = %v, want not found", err)
+	}
+
+	diff, err := client.CheckMapping(ctx, "orders", mappings)
+	if err != nil || !diff.Compatible() || len(diff.Added) != 0 {
+		t.Errorf("CheckMapping() = %+v, %v", diff, err)
+	}
+}
+
+func TestDiffMappings(t *testing.T) {
+	var live, desired map[string]interface{}
+	json.Unmarshal([]byte(`{"properties":{
+		"status":{"type":"keyword","ignore_above":256},
+		"title":{"type":"text","analyzer":"standard","fields":{"raw":{"type":"keyword"}}},
+		"price":{"type":"long"},
+		"user":{"properties":{"name":{"type":"text"}}},
+		"legacy":{"type":"keyword"},
+		"created":{"type":"date","format":"strict_date_optional_time||epoch_millis"}
+	}}`), &live)
+	json.Unmarshal([]byte(`{"properties":{
+		"status":{"type":"keyword","ignore_above":"512"},
+		"title":{"type":"text","analyzer":"ik_smart","fields":{"raw":{"type":"keyword"},"suggest":{"type":"completion"}}},
+		"price":{"type":"double"},
+		"user":{"properties":{"name":{"type":"text"},"email":{"type":"keyword"}}},
+		"created":{"type":"date"},
+		"tags":{"type":"keyword"}
+	}}`), &desired)
+
+	diff := DiffMappings(live, desired)
+	if diff.Compatible() {
+		t.Fatal("Compatible() = true, want false")
+	}
+	wantAdded := []string{"tags", "title.suggest", "user.email"}
+	if len(diff.Added) != len(wantAdded) {
+		t.Fatalf("Added = %v, want %v", diff.Added, wantAdded)
+	}
+	for i := range wantAdded {
+		if diff.Added[i] != wantAdded[i] {
+			t.Errorf("Added = %v, want %v", diff.Added, wantAdded)
+		}
+	}
+	if len(diff.Updated) != 1 || diff.Updated[0] != "status" {
+		t.Errorf("Updated = %v", diff.Updated)
+	}
+	if len(diff.Unmanaged) != 1 || diff.Unmanaged[0] != "legacy" {
+		t.Errorf("Unmanaged = %v", diff.Unmanaged)
+	}
+	if len(diff.Conflicts) != 2 {
+		t.Fatalf("Conflicts = %+v", diff.Conflicts)
+	}
+	if c := diff.Conflicts[0]; c.Field != "price" || c.Param != "type" || c.Live != "long" || c.Wanted != "double" {
+		t.Errorf("Conflicts[0] = %+v", c)
+	}
+	if c := diff.Conflicts[1]; c.Field != "title" || c.Param != "analyzer" {
+		t.Errorf("Conflicts[1] = %+v", c)
+	}
+
+	if diff := DiffMappings(live, live); !diff.Compatible() || len(diff.Added)+len(diff.Updated)+len(diff.Unmanaged) != 0 {
+		t.Errorf("DiffMappings(live, live) = %+v", diff)
+	}
+}
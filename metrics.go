@@ -0,0 +1,250 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"expvar"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyWindowSize 每个操作/索引保留的最近样本数
+const latencyWindowSize = 1024
+
+// maxLatencyKeys 最多跟踪的操作/索引组合数，超出后归入 _other，避免索引名过多时内存无限增长
+const maxLatencyKeys = 500
+
+// latencyOtherIndex 超出跟踪上限的索引统一使用的名称
+const latencyOtherIndex = "_other"
+
+// LatencySummary 单个操作/索引最近一段时间的延迟统计
+type LatencySummary struct {
+	Operation string  `json:"operation"`
+	Index     string  `json:"index"`
+	Count     uint64  `json:"count"`      // 累计请求数
+	Errors    uint64  `json:"errors"`     // 累计失败数
+	ErrorRate float64 `json:"error_rate"` // 最近样本中的失败比例
+	P50Ms     float64 `json:"p50_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+}
+
+// latencyKey 延迟统计的分组键
+type latencyKey struct {
+	operation string
+	index     string
+}
+
+// latencyWindow 固定大小的最近样本环形缓冲区
+type latencyWindow struct {
+	durations [latencyWindowSize]time.Duration
+	failed    [latencyWindowSize]bool
+	next      int
+	size      int
+	count     uint64
+	errors    uint64
+}
+
+// latencyRecorder 按操作和索引记录延迟，nil 时不记录。
+// windows 为 nil 时只将结果转发给错误预算统计
+type latencyRecorder struct {
+	mu         sync.Mutex
+	windows    map[latencyKey]*latencyWindow
+	budget     *errorBudget
+	closed     bool
+	expvarName string // 发布到 expvar 的名称，未发布时为空
+}
+
+// newLatencyRecorder 创建延迟记录器
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{windows: make(map[latencyKey]*latencyWindow)}
+}
+
+// latencyExpvars 按 expvar 名称登记当前使用该名称的延迟记录器。
+// expvar 无法取消发布，每个名称只发布一次，读取时返回当前登记的记录器的统计，客户端关闭时取消登记
+var latencyExpvars = struct {
+	mu        sync.Mutex
+	recorders map[string]*latencyRecorder
+	published map[string]bool
+}{
+	recorders: make(map[string]*latencyRecorder),
+	published: make(map[string]bool),
+}
+
+// checkLatencyExpvar 检查 name 是否可用：未被其他代码发布，且没有未关闭的客户端在使用
+func checkLatencyExpvar(name string) error {
+	latencyExpvars.mu.Lock()
+	defer latencyExpvars.mu.Unlock()
+	return checkLatencyExpvarLocked(name)
+}
+
+// checkLatencyExpvarLocked 在持有 latencyExpvars.mu 时检查 name 是否可用
+func checkLatencyExpvarLocked(name string) error {
+	if latencyExpvars.recorders[name] != nil || (!latencyExpvars.published[name] && expvar.Get(name) != nil) {
+		return fmt.Errorf("expvar %s is already published", name)
+	}
+	return nil
+}
+
+// publishLatencyExpvar 创建延迟记录器并以 name 发布到 expvar（/debug/vars），
+// 名称已由关闭的客户端发布过时复用该 expvar
+func publishLatencyExpvar(name string) (*latencyRecorder, error) {
+	latencyExpvars.mu.Lock()
+	defer latencyExpvars.mu.Unlock()
+	if err := checkLatencyExpvarLocked(name); err != nil {
+		return nil, err
+	}
+
+	recorder := newLatencyRecorder()
+	recorder.expvarName = name
+	latencyExpvars.recorders[name] = recorder
+	if !latencyExpvars.published[name] {
+		expvar.Publish(name, expvar.Func(func() interface{} {
+			latencyExpvars.mu.Lock()
+			current := latencyExpvars.recorders[name]
+			latencyExpvars.mu.Unlock()
+			return current.snapshot()
+		}))
+		latencyExpvars.published[name] = true
+	}
+	return recorder, nil
+}
+
+// record 记录一次操作的耗时和结果
+func (r *latencyRecorder) record(operation string, index string, duration time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.budget.observe(operation, err)
+	if r.windows == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	key := latencyKey{operation: operation, index: index}
+	w, ok := r.windows[key]
+	if !ok {
+		if len(r.windows) >= maxLatencyKeys {
+			key.index = latencyOtherIndex
+			w = r.windows[key]
+		}
+		if w == nil {
+			w = &latencyWindow{}
+			r.windows[key] = w
+		}
+	}
+
+	w.durations[w.next] = duration
+	w.failed[w.next] = err != nil
+	w.next = (w.next + 1) % latencyWindowSize
+	if w.size < latencyWindowSize {
+		w.size++
+	}
+	w.count++
+	if err != nil {
+		w.errors++
+	}
+}
+
+// close 停止记录新的样本，并从 expvar 登记中移除，之后同名 expvar 不再返回该记录器的统计
+func (r *latencyRecorder) close() {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	if r.expvarName == "" {
+		return
+	}
+	latencyExpvars.mu.Lock()
+	defer latencyExpvars.mu.Unlock()
+	if latencyExpvars.recorders[r.expvarName] == r {
+		delete(latencyExpvars.recorders, r.expvarName)
+	}
+}
+
+// snapshot 返回所有操作/索引的延迟统计，按操作和索引排序
+func (r *latencyRecorder) snapshot() []LatencySummary {
+	if r == nil || r.windows == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summaries := make([]LatencySummary, 0, len(r.windows))
+	for key, w := range r.windows {
+		samples := make([]time.Duration, w.size)
+		copy(samples, w.durations[:w.size])
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+		failed := 0
+		for _, f := range w.failed[:w.size] {
+			if f {
+				failed++
+			}
+		}
+
+		summary := LatencySummary{
+			Operation: key.operation,
+			Index:     key.index,
+			Count:     w.count,
+			Errors:    w.errors,
+			P50Ms:     percentileMs(samples, 0.50),
+			P95Ms:     percentileMs(samples, 0.95),
+			P99Ms:     percentileMs(samples, 0.99),
+		}
+		if w.size > 0 {
+			summary.ErrorRate = float64(failed) / float64(w.size)
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Operation != summaries[j].Operation {
+			return summaries[i].Operation < summaries[j].Operation
+		}
+		return summaries[i].Index < summaries[j].Index
+	})
+	return summaries
+}
+
+// percentileMs 返回已排序样本的分位数（毫秒，最近秩法）
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(p*float64(len(sorted))+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return float64(sorted[i]) / float64(time.Millisecond)
+}
+
+// LatencyStats 返回客户端记录的延迟统计，未配置 LatencyExpvar 时返回 nil
+func (c *ElasticsearchClient) LatencyStats() []LatencySummary {
+	if c == nil {
+		return nil
+	}
+	return c.latency.snapshot()
+}
@@ -0,0 +1,94 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyRecorder(t *testing.T) {
+	r := newLatencyRecorder()
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%10 == 0 {
+			err = errors.New("failed")
+		}
+		r.record("search", "orders", time.Duration(i)*time.Millisecond, err)
+	}
+	r.record("index", "orders", 5*time.Millisecond, nil)
+
+	stats := r.snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("snapshot() = %+v", stats)
+	}
+	search := stats[1]
+	if search.Operation != "search" || search.Count != 100 || search.Errors != 10 {
+		t.Errorf("search stats = %+v", search)
+	}
+	if search.P50Ms != 50 || search.P95Ms != 95 || search.P99Ms != 99 {
+		t.Errorf("percentiles = %v/%v/%v, want 50/95/99", search.P50Ms, search.P95Ms, search.P99Ms)
+	}
+	if search.ErrorRate != 0.1 {
+		t.Errorf("error rate = %v, want 0.1", search.ErrorRate)
+	}
+
+	var nilRecorder *latencyRecorder
+	nilRecorder.record("search", "orders", time.Millisecond, nil)
+	if nilRecorder.snapshot() != nil {
+		t.Error("nil recorder snapshot should be nil")
+	}
+}
+
+func TestLatencyRecorderKeyLimit(t *testing.T) {
+	r := newLatencyRecorder()
+	for i := 0; i < maxLatencyKeys+10; i++ {
+		r.record("get", string(rune('a'+i%26))+time.Duration(i).String(), time.Millisecond, nil)
+	}
+	if got := len(r.windows); got != maxLatencyKeys+1 {
+		t.Errorf("windows = %d, want %d", got, maxLatencyKeys+1)
+	}
+}
+
+func TestLatencyExpvar(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"found":true,"_source":{}}`))
+	}, func(opts *Options) {
+		opts.LatencyExpvar = "elasticsearch_test_latency"
+	})
+
+	if _, err := client.Get(context.Background(), "orders", "1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	var stats []LatencySummary
+	if err := json.Unmarshal([]byte(expvar.Get("elasticsearch_test_latency").String()), &stats); err != nil {
+		t.Fatalf("expvar value error = %v", err)
+	}
+	if len(stats) != 1 || stats[0].Operation != "get" || stats[0].Index != "orders" || stats[0].Count != 1 {
+		t.Errorf("expvar stats = %+v", stats)
+	}
+
+	_, err := NewElasticsearch(&Options{Addresses: []string{"http://127.0.0.1:1"}, LatencyExpvar: "elasticsearch_test_latency"})
+	if err == nil || !strings.Contains(err.Error(), "already published") {
+		t.Errorf("NewElasticsearch() with duplicate expvar name error = %v", err)
+	}
+
+	// 关闭后同名 expvar 可被新客户端复用，且不再导出已关闭客户端的统计
+	client.Close()
+	if got := expvar.Get("elasticsearch_test_latency").String(); got != "null" {
+		t.Errorf("expvar after Close() = %s, want null", got)
+	}
+	reopened := newTestClient(t, nil, func(opts *Options) {
+		opts.LatencyExpvar = "elasticsearch_test_latency"
+	})
+	reopened.Ping(context.Background())
+	if err := json.Unmarshal([]byte(expvar.Get("elasticsearch_test_latency").String()), &stats); err != nil || len(stats) != 0 {
+		t.Errorf("expvar after reopen = %+v, %v", stats, err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// DocumentRef 文档的索引和 ID
+type DocumentRef struct {
+	Index string
+	ID    string
+}
+
+// MGetDocument MGet 返回的单个文档，文档不存在时 Found 为 false
+type MGetDocument struct {
+	ID      string          `json:"_id"`
+	Index   string          `json:"_index"`
+	Found   bool            `json:"found"`
+	Version int64           `json:"_version"`
+	Source  json.RawMessage `json:"_source"`
+	Err     error           `json:"-"` // 单个文档的错误（如索引不存在），为 *Error
+}
+
+// DecodeSource 将 _source 解析到 v
+func (d *MGetDocument) DecodeSource(v interface{}) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	if !d.Found {
+		return fmt.Errorf("document %s not found", d.ID)
+	}
+	if len(d.Source) == 0 {
+		return fmt.Errorf("document %s has no _source", d.ID)
+	}
+	if err := json.Unmarshal(d.Source, v); err != nil {
+		return fmt.Errorf("failed to decode source: %w", err)
+	}
+	return nil
+}
+
+// MGet 在一次请求中获取同一索引下的多个文档，结果与 ids 一一对应（自动处理追踪）
+func (c *ElasticsearchClient) MGet(ctx context.Context, index string, ids []string) ([]MGetDocument, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var docs []MGetDocument
+	err := executeWithTrace(
+		ctx,
+		"mget",
+		index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			docs, err = c.mget(ctx, index, map[string]interface{}{"ids": ids}, len(ids))
+			return err
+		},
+	)
+	return docs, err
+}
+
+// MGetRefs 在一次请求中获取多个索引下的文档，结果与 refs 一一对应（自动处理追踪）。
+// 单个文档失败（如索引不存在）不影响其他文档，错误记录在对应结果的 Err 中
+func (c *ElasticsearchClient) MGetRefs(ctx context.Context, refs []DocumentRef) ([]MGetDocument, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	docs := make([]map[string]interface{}, len(refs))
+	for i, ref := range refs {
+		if ref.Index == "" {
+			return nil, fmt.Errorf("document ref %d index cannot be empty", i)
+		}
+		docs[i] = map[string]interface{}{
+			"_index": c.resolveIndexName(ctx, ref.Index),
+			"_id":    ref.ID,
+		}
+	}
+
+	var result []MGetDocument
+	err := executeWithTrace(
+		ctx,
+		"mget",
+		"",
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			result, err = c.mget(ctx, "", map[string]interface{}{"docs": docs}, len(refs))
+			return err
+		},
+	)
+	return result, err
+}
+
+// mget 内部批量获取文档方法
+func (c *ElasticsearchClient) mget(ctx context.Context, index string, body map[string]interface{}, expected int) ([]MGetDocument, error) {
+	if expected == 0 {
+		return []MGetDocument{}, nil
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mget body: %w", err)
+	}
+
+	res, err := esapi.MgetRequest{
+		Index: index,
+		Body:  strings.NewReader(string(bodyBytes)),
+	}.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("mget", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("mget", res)
+	}
+
+	var result struct {
+		Docs []map[string]interface{} `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Docs) != expected {
+		return nil, fmt.Errorf("mget returned %d documents for %d ids", len(result.Docs), expected)
+	}
+
+	docs := make([]MGetDocument, len(result.Docs))
+	for i, doc := range result.Docs {
+		if docErr, ok := doc["error"]; ok {
+			raw, _ := json.Marshal(docErr)
+			docs[i].ID, _ = doc["_id"].(string)
+			docs[i].Index, _ = doc["_index"].(string)
+			docs[i].Err = c.itemError("mget", mgetErrorStatus(raw), raw)
+			continue
+		}
+		if c.readTransformer != nil {
+			if err := c.transformSource(index, doc, false); err != nil {
+				return nil, err
+			}
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal document: %w", err)
+		}
+		if err := json.Unmarshal(data, &docs[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+	}
+	return docs, nil
+}
+
+// mgetErrorStatus 单个文档的错误不带状态码，按错误类型推断，便于 IsNotFound 等判断
+func mgetErrorStatus(raw json.RawMessage) int {
+	var detail errorDetail
+	if json.Unmarshal(raw, &detail) == nil && strings.HasSuffix(detail.Type, "not_found_exception") {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,79 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMGet(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/_mget" {
+			t.Errorf("path = %s, want /orders/_mget", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"docs":[
+			{"_index":"orders","_id":"1","_version":3,"found":true,"_source":{"status":"paid","amount":10}},
+			{"_index":"orders","_id":"2","found":false}]}`))
+	})
+
+	docs, err := client.MGet(context.Background(), "orders", []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("MGet() error = %v", err)
+	}
+	if len(body["ids"].([]interface{})) != 2 {
+		t.Errorf("request body = %v", body)
+	}
+	if len(docs) != 2 || !docs[0].Found || docs[0].Version != 3 || docs[1].Found || docs[1].ID != "2" {
+		t.Fatalf("MGet() = %+v", docs)
+	}
+	var order testOrder
+	if err := docs[0].DecodeSource(&order); err != nil || order.Amount != 10 {
+		t.Errorf("DecodeSource() = %+v, %v", order, err)
+	}
+	if err := docs[1].DecodeSource(&order); err == nil {
+		t.Error("DecodeSource() on missing document should return error")
+	}
+}
+
+func TestMGetRefs(t *testing.T) {
+	var body struct {
+		Docs []map[string]string `json:"docs"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_mget" {
+			t.Errorf("path = %s, want /_mget", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"docs":[
+			{"_index":"eu-orders","_id":"1","found":true,"_source":{}},
+			{"_index":"missing","_id":"2","error":{"type":"index_not_found_exception"}}]}`))
+	}, func(opts *Options) {
+		opts.IndexResolver = func(ctx context.Context, index string) string { return "eu-" + index }
+	})
+
+	docs, err := client.MGetRefs(context.Background(), []DocumentRef{{Index: "orders", ID: "1"}, {Index: "missing", ID: "2"}})
+	if err != nil || len(docs) != 2 {
+		t.Fatalf("MGetRefs() = %v, %v", docs, err)
+	}
+	if docs[0].Err != nil || !docs[0].Found {
+		t.Errorf("docs[0] = %+v, want found", docs[0])
+	}
+	var esErr *Error
+	if !errors.As(docs[1].Err, &esErr) || !IsNotFound(docs[1].Err) || esErr.ErrorType != "index_not_found_exception" || docs[1].ID != "2" {
+		t.Errorf("docs[1].Err = %v, want index_not_found *Error", docs[1].Err)
+	}
+	if err := docs[1].DecodeSource(&map[string]interface{}{}); !IsNotFound(err) {
+		t.Errorf("DecodeSource() error = %v, want the document error", err)
+	}
+	if len(body.Docs) != 2 || body.Docs[0]["_index"] != "eu-orders" || body.Docs[1]["_id"] != "2" {
+		t.Errorf("request body = %+v", body)
+	}
+
+	if _, err := client.MGetRefs(context.Background(), []DocumentRef{{ID: "1"}}); err == nil {
+		t.Error("MGetRefs() with empty index should return error")
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// AnomalyDetector 异常检测任务的检测器，如 {Function: "mean", FieldName: "latency_ms", PartitionFieldName: "service"}
+type AnomalyDetector struct {
+	Function           string `json:"function"` // count、mean、max、high_count、rare 等
+	FieldName          string `json:"field_name,omitempty"`
+	ByFieldName        string `json:"by_field_name,omitempty"`
+	OverFieldName      string `json:"over_field_name,omitempty"`
+	PartitionFieldName string `json:"partition_field_name,omitempty"`
+	Description        string `json:"detector_description,omitempty"`
+}
+
+// AnomalyJobConfig 异常检测任务配置
+type AnomalyJobConfig struct {
+	Description string
+	BucketSpan  time.Duration // 分析的时间桶大小，如 15 分钟
+	Detectors   []AnomalyDetector
+	Influencers []string // 可能影响异常的字段
+	TimeField   string   // 数据中的时间字段，默认 timestamp
+	TimeFormat  string   // 时间格式，默认 epoch_ms
+}
+
+// body 转换为创建任务的请求体
+func (cfg *AnomalyJobConfig) body() map[string]interface{} {
+	analysis := map[string]interface{}{
+		"bucket_span": fmt.Sprintf("%ds", int64(cfg.BucketSpan/time.Second)),
+		"detectors":   cfg.Detectors,
+	}
+	if len(cfg.Influencers) > 0 {
+		analysis["influencers"] = cfg.Influencers
+	}
+	dataDescription := map[string]interface{}{}
+	if cfg.TimeField != "" {
+		dataDescription["time_field"] = cfg.TimeField
+	}
+	if cfg.TimeFormat != "" {
+		dataDescription["time_format"] = cfg.TimeFormat
+	}
+	body := map[string]interface{}{
+		"analysis_config":  analysis,
+		"data_description": dataDescription,
+	}
+	if cfg.Description != "" {
+		body["description"] = cfg.Description
+	}
+	return body
+}
+
+// AnomalyDataCounts 向任务发送数据后的处理统计
+type AnomalyDataCounts struct {
+	ProcessedRecordCount int64 `json:"processed_record_count"`
+	InvalidDateCount     int64 `json:"invalid_date_count"`
+	MissingFieldCount    int64 `json:"missing_field_count"`
+	OutOfOrderCount      int64 `json:"out_of_order_timestamp_count"`
+}
+
+// AnomalyQuery 查询异常结果的条件
+type AnomalyQuery struct {
+	Start          time.Time // 为零值时不限制
+	End            time.Time // 为零值时不限制
+	MinScore       float64   // 最低异常分数（0~100）
+	ExcludeInterim bool      // 排除尚未最终确定的中间结果
+	From           int
+	Size           int // 默认 100
+}
+
+// AnomalyRecord 单个异常记录
+type AnomalyRecord struct {
+	JobID               string    `json:"job_id"`
+	Timestamp           int64     `json:"timestamp"` // 桶的起始时间（毫秒时间戳）
+	RecordScore         float64   `json:"record_score"`
+	Probability         float64   `json:"probability"`
+	Function            string    `json:"function"`
+	FieldName           string    `json:"field_name"`
+	ByFieldValue        string    `json:"by_field_value"`
+	PartitionFieldValue string    `json:"partition_field_value"`
+	Actual              []float64 `json:"actual"`
+	Typical             []float64 `json:"typical"`
+	IsInterim           bool      `json:"is_interim"`
+}
+
+// Time 返回异常所在桶的起始时间
+func (r *AnomalyRecord) Time() time.Time {
+	return time.UnixMilli(r.Timestamp)
+}
+
+// AnomalyBucket 单个时间桶的整体异常结果
+type AnomalyBucket struct {
+	JobID        string  `json:"job_id"`
+	Timestamp    int64   `json:"timestamp"`   // 桶的起始时间（毫秒时间戳）
+	BucketSpan   int64   `json:"bucket_span"` // 桶大小（秒）
+	AnomalyScore float64 `json:"anomaly_score"`
+	EventCount   int64   `json:"event_count"`
+	IsInterim    bool    `json:"is_interim"`
+}
+
+// Time 返回桶的起始时间
+func (b *AnomalyBucket) Time() time.Time {
+	return time.UnixMilli(b.Timestamp)
+}
+
+// PutAnomalyJob 创建异常检测任务（需要 X-Pack ML 许可）
+func (c *ElasticsearchClient) PutAnomalyJob(ctx context.Context, jobID string, cfg AnomalyJobConfig) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if len(cfg.Detectors) == 0 || cfg.BucketSpan < time.Second {
+		return fmt.Errorf("anomaly job %s requires detectors and a bucket span of at least 1s", jobID)
+	}
+	body, err := json.Marshal(cfg.body())
+	if err != nil {
+		return fmt.Errorf("failed to marshal anomaly job: %w", err)
+	}
+	return c.resourceRequest(ctx, "ml put job", jobID, esapi.MLPutJobRequest{
+		JobID: jobID,
+		Body:  strings.NewReader(string(body)),
+	}, nil)
+}
+
+// OpenAnomalyJob 打开任务使其可以接收和分析数据
+func (c *ElasticsearchClient) OpenAnomalyJob(ctx context.Context, jobID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "ml open job", jobID, esapi.MLOpenJobRequest{JobID: jobID}, nil)
+}
+
+// CloseAnomalyJob 关闭任务，处理完已接收的数据并持久化模型状态
+func (c *ElasticsearchClient) CloseAnomalyJob(ctx context.Context, jobID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "ml close job", jobID, esapi.MLCloseJobRequest{JobID: jobID}, nil)
+}
+
+// DeleteAnomalyJob 删除任务及其结果，任务需先关闭
+func (c *ElasticsearchClient) DeleteAnomalyJob(ctx context.Context, jobID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "ml delete job", jobID, esapi.MLDeleteJobRequest{JobID: jobID}, nil)
+}
+
+// PostAnomalyData 向已打开的任务发送数据（按时间升序），flush 为 true 时立即计算已发送数据的中间结果
+func (c *ElasticsearchClient) PostAnomalyData(ctx context.Context, jobID string, docs []interface{}, flush bool) (*AnomalyDataCounts, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var body strings.Builder
+	for _, doc := range docs {
+		data, err := marshalDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		body.Write(data)
+		body.WriteByte('\n')
+	}
+
+	var counts AnomalyDataCounts
+	if err := c.resourceRequest(ctx, "ml post data", jobID, esapi.MLPostDataRequest{
+		JobID: jobID,
+		Body:  strings.NewReader(body.String()),
+	}, &counts); err != nil {
+		return nil, err
+	}
+	if flush {
+		calcInterim := true
+		if err := c.resourceRequest(ctx, "ml flush job", jobID, esapi.MLFlushJobRequest{JobID: jobID, CalcInterim: &calcInterim}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &counts, nil
+}
+
+// AnomalyRecords 查询任务的异常记录，按异常分数降序
+func (c *ElasticsearchClient) AnomalyRecords(ctx context.Context, jobID string, query *AnomalyQuery) ([]AnomalyRecord, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	q := query.normalize()
+	req := esapi.MLGetRecordsRequest{
+		JobID:          jobID,
+		Start:          q.start(),
+		End:            q.end(),
+		ExcludeInterim: &q.ExcludeInterim,
+		From:           &q.From,
+		Size:           &q.Size,
+		Sort:           "record_score",
+		Desc:           boolPtr(true),
+	}
+	if q.MinScore > 0 {
+		req.RecordScore = q.MinScore
+	}
+
+	var result struct {
+		Records []AnomalyRecord `json:"records"`
+	}
+	if err := c.resourceRequest(ctx, "ml get records", jobID, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+// AnomalyBuckets 查询任务各时间桶的异常结果，按时间升序
+func (c *ElasticsearchClient) AnomalyBuckets(ctx context.Context, jobID string, query *AnomalyQuery) ([]AnomalyBucket, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	q := query.normalize()
+	req := esapi.MLGetBucketsRequest{
+		JobID:          jobID,
+		Start:          q.start(),
+		End:            q.end(),
+		ExcludeInterim: &q.ExcludeInterim,
+		From:           &q.From,
+		Size:           &q.Size,
+	}
+	if q.MinScore > 0 {
+		req.AnomalyScore = q.MinScore
+	}
+
+	var result struct {
+		Buckets []AnomalyBucket `json:"buckets"`
+	}
+	if err := c.resourceRequest(ctx, "ml get buckets", jobID, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
+
+// normalize 返回填充默认值后的查询条件
+func (q *AnomalyQuery) normalize() AnomalyQuery {
+	var n AnomalyQuery
+	if q != nil {
+		n = *q
+	}
+	if n.Size <= 0 {
+		n.Size = 100
+	}
+	return n
+}
+
+// start 返回起始时间参数
+func (q AnomalyQuery) start() string {
+	if q.Start.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(q.Start.UnixMilli())
+}
+
+// end 返回结束时间参数
+func (q AnomalyQuery) end() string {
+	if q.End.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(q.End.UnixMilli())
+}
+
+// boolPtr 返回布尔值的指针
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,114 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnomalyJob(t *testing.T) {
+	var calls []string
+	var jobBody map[string]interface{}
+	var data string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/_ml/anomaly_detectors/latency":
+			json.NewDecoder(r.Body).Decode(&jobBody)
+			w.Write([]byte(`{"job_id":"latency"}`))
+		case "/_ml/anomaly_detectors/latency/_data":
+			b, _ := io.ReadAll(r.Body)
+			data = string(b)
+			w.Write([]byte(`{"processed_record_count":2}`))
+		case "/_ml/anomaly_detectors/latency/results/records":
+			if r.URL.Query().Get("record_score") != "75" || r.URL.Query().Get("start") != "1700000000000" {
+				t.Errorf("records query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"count":1,"records":[{"job_id":"latency","timestamp":1700000000000,"record_score":91.5,"function":"mean","actual":[850],"typical":[120]}]}`))
+		case "/_ml/anomaly_detectors/latency/results/buckets":
+			w.Write([]byte(`{"count":1,"buckets":[{"job_id":"latency","timestamp":1700000000000,"bucket_span":900,"anomaly_score":60,"event_count":12}]}`))
+		case "/_ml/anomaly_detectors/missing/_open":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"resource_not_found_exception","reason":"No known job with id 'missing'"},"status":404}`))
+		default:
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.PutAnomalyJob(ctx, "latency", AnomalyJobConfig{
+		BucketSpan:  15 * time.Minute,
+		Detectors:   []AnomalyDetector{{Function: "mean", FieldName: "latency_ms", PartitionFieldName: "service"}},
+		Influencers: []string{"service"},
+		TimeField:   "@timestamp",
+	}); err != nil {
+		t.Fatalf("PutAnomalyJob() error = %v", err)
+	}
+	analysis, _ := jobBody["analysis_config"].(map[string]interface{})
+	if analysis["bucket_span"] != "900s" || len(analysis["detectors"].([]interface{})) != 1 {
+		t.Errorf("job body = %v", jobBody)
+	}
+	if err := client.PutAnomalyJob(ctx, "empty", AnomalyJobConfig{BucketSpan: time.Minute}); err == nil {
+		t.Error("PutAnomalyJob() without detectors should fail")
+	}
+
+	if err := client.OpenAnomalyJob(ctx, "latency"); err != nil {
+		t.Fatalf("OpenAnomalyJob() error = %v", err)
+	}
+	counts, err := client.PostAnomalyData(ctx, "latency", []interface{}{
+		map[string]interface{}{"@timestamp": 1, "latency_ms": 120},
+		map[string]interface{}{"@timestamp": 2, "latency_ms": 850},
+	}, true)
+	if err != nil || counts.ProcessedRecordCount != 2 {
+		t.Fatalf("PostAnomalyData() = %+v, %v", counts, err)
+	}
+	if strings.Count(data, "\n") != 2 {
+		t.Errorf("data = %q, want 2 NDJSON lines", data)
+	}
+
+	records, err := client.AnomalyRecords(ctx, "latency", &AnomalyQuery{Start: time.UnixMilli(1700000000000), MinScore: 75})
+	if err != nil || len(records) != 1 || records[0].RecordScore != 91.5 || records[0].Actual[0] != 850 {
+		t.Fatalf("AnomalyRecords() = %+v, %v", records, err)
+	}
+	if !records[0].Time().Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("record time = %v", records[0].Time())
+	}
+	buckets, err := client.AnomalyBuckets(ctx, "latency", nil)
+	if err != nil || len(buckets) != 1 || buckets[0].AnomalyScore != 60 || buckets[0].BucketSpan != 900 {
+		t.Fatalf("AnomalyBuckets() = %+v, %v", buckets, err)
+	}
+
+	if err := client.CloseAnomalyJob(ctx, "latency"); err != nil {
+		t.Fatalf("CloseAnomalyJob() error = %v", err)
+	}
+	if err := client.DeleteAnomalyJob(ctx, "latency"); err != nil {
+		t.Fatalf("DeleteAnomalyJob() error = %v", err)
+	}
+
+	want := []string{
+		"PUT /_ml/anomaly_detectors/latency",
+		"POST /_ml/anomaly_detectors/latency/_open",
+		"POST /_ml/anomaly_detectors/latency/_data",
+		"POST /_ml/anomaly_detectors/latency/_flush",
+		"POST /_ml/anomaly_detectors/latency/results/records",
+		"POST /_ml/anomaly_detectors/latency/results/buckets",
+		"POST /_ml/anomaly_detectors/latency/_close",
+		"DELETE /_ml/anomaly_detectors/latency",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %q", calls)
+	}
+
+	var esErr *Error
+	if err := client.OpenAnomalyJob(ctx, "missing"); !errors.As(err, &esErr) || esErr.StatusCode != 404 {
+		t.Errorf("OpenAnomalyJob(missing) error = %v", err)
+	}
+	if err := client.OpenAnomalyJob(ctx, ""); err == nil {
+		t.Error("OpenAnomalyJob(\"\") should fail")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// MSearchItem msearch 中的单个查询
+type MSearchItem struct {
+	Index string                 // 索引名、逗号分隔的索引列表或索引模式
+	Query map[string]interface{} // 查询体
+}
+
+// MSearchResult msearch 中单个查询的结果，Err 非 nil 时 Result 为 nil
+type MSearchResult struct {
+	Result map[string]interface{}
+	Err    error // 单个查询的错误，为 *Error
+}
+
+// MSearch 在一次 _msearch 请求中执行多个查询（自动处理追踪），结果与 items 一一对应。
+// 单个查询失败不影响其他查询，错误记录在对应结果的 Err 中；请求本身失败时返回错误。
+// 适用于仪表盘等需要发起大量小查询、往返延迟占主要开销的场景
+func (c *ElasticsearchClient) MSearch(ctx context.Context, items []MSearchItem) ([]MSearchResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	resolved := make([]MSearchItem, len(items))
+	for i, item := range items {
+		resolved[i] = MSearchItem{Index: c.resolveIndexName(ctx, item.Index), Query: item.Query}
+	}
+
+	var results []MSearchResult
+	err := executeWithTrace(
+		ctx,
+		"msearch",
+		"",
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			results, err = c.msearch(ctx, resolved)
+			return err
+		},
+	)
+	return results, err
+}
+
+// msearch 内部批量搜索方法，items 中的索引已解析
+func (c *ElasticsearchClient) msearch(ctx context.Context, items []MSearchItem) ([]MSearchResult, error) {
+	if len(items) == 0 {
+		return []MSearchResult{}, nil
+	}
+
+	allow := c.allowPartialSearchResults(ctx)
+	var buf strings.Builder
+	for i, item := range items {
+		query, err := c.applyDocumentFilter(ctx, item.Query)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.limits.checkQuery(query); err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+		if err := c.checkResultWindow(query, newSearchConfig(nil)); err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+		if query == nil {
+			query = map[string]interface{}{}
+		}
+
+		header := map[string]interface{}{}
+		if item.Index != "" {
+			header["index"] = item.Index
+		}
+		if allow != nil {
+			header["allow_partial_search_results"] = *allow
+		}
+		headerBytes, err := json.Marshal(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal msearch header: %w", err)
+		}
+		bodyBytes, err := json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal query %d: %w", i, err)
+		}
+		if err := c.limits.checkBytes(len(bodyBytes)); err != nil {
+			return nil, fmt.Errorf("query %d: %w", i, err)
+		}
+		buf.Write(headerBytes)
+		buf.WriteByte('\n')
+		buf.Write(bodyBytes)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.MsearchRequest{
+		Body: strings.NewReader(buf.String()),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("msearch", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("msearch", res)
+	}
+
+	var result struct {
+		Responses []json.RawMessage `json:"responses"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Responses) != len(items) {
+		return nil, fmt.Errorf("msearch returned %d responses for %d queries", len(result.Responses), len(items))
+	}
+
+	results := make([]MSearchResult, len(items))
+	for i, raw := range result.Responses {
+		var status struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return nil, fmt.Errorf("failed to decode msearch response %d: %w", i, err)
+		}
+		if len(status.Error) > 0 {
+			results[i].Err = c.itemError("msearch", status.Status, status.Error)
+			continue
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(raw, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode msearch response %d: %w", i, err)
+		}
+		if err := c.transformSearchResult(items[i].Index, response, false); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Result = response
+	}
+	return results, nil
+}
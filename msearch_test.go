@@ -0,0 +1,55 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMSearch(t *testing.T) {
+	var lines []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_msearch" {
+			t.Errorf("path = %s, want /_msearch", r.URL.Path)
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		w.Write([]byte(`{"took":3,"responses":[
+			{"status":200,"hits":{"total":{"value":4},"hits":[]}},
+			{"status":404,"error":{"type":"index_not_found_exception","reason":"no such index [missing]"}}
+		]}`))
+	})
+
+	results, err := client.MSearch(context.Background(), []MSearchItem{
+		{Index: "orders", Query: map[string]interface{}{"query": Match("status", "paid").Source()}},
+		{Index: "missing"},
+	})
+	if err != nil {
+		t.Fatalf("MSearch() error = %v", err)
+	}
+	if len(lines) != 4 || lines[0] != `{"index":"orders"}` || lines[3] != `{}` {
+		t.Errorf("body = %q", lines)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+	if res, err := SearchResultFromMap(results[0].Result); results[0].Err != nil || err != nil || res.Total != 4 {
+		t.Errorf("result 0 = %+v", results[0])
+	}
+	var esErr *Error
+	if results[1].Result != nil || !errors.As(results[1].Err, &esErr) || esErr.StatusCode != 404 || esErr.ErrorType != "index_not_found_exception" {
+		t.Errorf("result 1 error = %v", results[1].Err)
+	}
+	if !strings.Contains(results[1].Err.Error(), "no such index") {
+		t.Errorf("result 1 error = %v, want reason", results[1].Err)
+	}
+
+	if results, err := client.MSearch(context.Background(), nil); err != nil || len(results) != 0 {
+		t.Errorf("MSearch(nil) = %v, %v", results, err)
+	}
+}
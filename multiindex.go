@@ -0,0 +1,76 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// errNoIndices 多索引方法没有传入任何索引
+var errNoIndices = errors.New("indices cannot be empty")
+
+// joinIndices 解析索引列表并拼接为逗号分隔的索引表达式，通配符（如 "logs-*"）原样保留
+func (c *ElasticsearchClient) joinIndices(ctx context.Context, indices []string) (string, error) {
+	if len(indices) == 0 {
+		return "", errNoIndices
+	}
+	return strings.Join(c.resolveIndices(ctx, indices), ","), nil
+}
+
+// SearchIndices 在多个索引或索引模式（如 "logs-*"）上搜索文档（自动处理追踪），
+// 配合 WithIgnoreUnavailable 和 WithAllowNoIndices 控制索引不存在时的行为
+func (c *ElasticsearchClient) SearchIndices(ctx context.Context, indices []string, query map[string]interface{}, opts ...SearchOption) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index, err := c.joinIndices(ctx, indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.searchResolved(ctx, strings.Join(indices, ","), index, query, newSearchConfig(opts))
+}
+
+// CountIndices 统计多个索引或索引模式中匹配查询的文档数量，
+// opts 中只有 WithIgnoreUnavailable、WithAllowNoIndices 和 WithPreference 生效
+func (c *ElasticsearchClient) CountIndices(ctx context.Context, indices []string, query map[string]interface{}, opts ...SearchOption) (int64, error) {
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	index, err := c.joinIndices(ctx, indices)
+	if err != nil {
+		return 0, err
+	}
+	return c.count(ctx, index, query, newSearchConfig(opts))
+}
+
+// DeleteByQueryIndices 在多个索引或索引模式中根据查询删除文档，
+// opts 中只有 WithIgnoreUnavailable 和 WithAllowNoIndices 生效
+func (c *ElasticsearchClient) DeleteByQueryIndices(ctx context.Context, indices []string, query map[string]interface{}, opts ...SearchOption) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index, err := c.joinIndices(ctx, indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.deleteByQuery(ctx, index, query, newSearchConfig(opts))
+}
@@ -0,0 +1,64 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMultiIndexSearch(t *testing.T) {
+	var paths, ignore, allow []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		ignore = append(ignore, r.URL.Query().Get("ignore_unavailable"))
+		allow = append(allow, r.URL.Query().Get("allow_no_indices"))
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_count"):
+			w.Write([]byte(`{"count":7}`))
+		case strings.HasSuffix(r.URL.Path, "/_delete_by_query"):
+			w.Write([]byte(`{"deleted":2}`))
+		default:
+			w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+		}
+	}, func(o *Options) {
+		o.IndexResolver = func(_ context.Context, name string) string {
+			if name == "orders" {
+				return "tenant-a-orders"
+			}
+			return name
+		}
+	})
+	ctx := context.Background()
+	indices := []string{"orders", "logs-*"}
+
+	if _, err := client.SearchIndices(ctx, indices, nil, WithIgnoreUnavailable(true), WithAllowNoIndices(false)); err != nil {
+		t.Fatalf("SearchIndices() error = %v", err)
+	}
+	count, err := client.CountIndices(ctx, indices, nil, WithIgnoreUnavailable(true))
+	if err != nil || count != 7 {
+		t.Fatalf("CountIndices() = %d, %v", count, err)
+	}
+	if _, err := client.DeleteByQueryIndices(ctx, indices, map[string]interface{}{"query": MatchAll().Source()}, WithAllowNoIndices(true)); err != nil {
+		t.Fatalf("DeleteByQueryIndices() error = %v", err)
+	}
+
+	want := []string{
+		"POST /tenant-a-orders,logs-*/_search",
+		"POST /tenant-a-orders,logs-*/_count",
+		"POST /tenant-a-orders,logs-*/_delete_by_query",
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d = %s, want %s", i, paths[i], want[i])
+		}
+	}
+	if ignore[0] != "true" || allow[0] != "false" || ignore[1] != "true" || allow[2] != "true" {
+		t.Errorf("ignore_unavailable = %v, allow_no_indices = %v", ignore, allow)
+	}
+
+	if _, err := client.SearchIndices(ctx, nil, nil); !errors.Is(err, errNoIndices) {
+		t.Errorf("SearchIndices(nil) error = %v, want errNoIndices", err)
+	}
+}
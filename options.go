@@ -18,6 +18,8 @@ package elasticsearch
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	pkgConfig "github.com/go-anyway/framework-config"
@@ -38,6 +40,78 @@ type Config struct {
 	WriteTimeout pkgConfig.Duration `yaml:"write_timeout" env:"ELASTICSEARCH_WRITE_TIMEOUT" default:"30s"`
 	MaxRetries   int                `yaml:"max_retries" env:"ELASTICSEARCH_MAX_RETRIES" default:"3"`
 	EnableTrace  bool               `yaml:"enable_trace" env:"ELASTICSEARCH_ENABLE_TRACE" default:"true"`
+
+	// TLS 客户端证书
+	ClientCert string `yaml:"client_cert" env:"ELASTICSEARCH_CLIENT_CERT"`
+	ClientKey  string `yaml:"client_key" env:"ELASTICSEARCH_CLIENT_KEY"`
+
+	// 连接
+	CompressRequestBody bool               `yaml:"compress_request_body" env:"ELASTICSEARCH_COMPRESS_REQUEST_BODY" default:"false"`
+	MaxIdleConnsPerHost int                `yaml:"max_idle_conns_per_host" env:"ELASTICSEARCH_MAX_IDLE_CONNS_PER_HOST" default:"10"`
+	MaxIdleConns        int                `yaml:"max_idle_conns" env:"ELASTICSEARCH_MAX_IDLE_CONNS"`
+	MaxConnsPerHost     int                `yaml:"max_conns_per_host" env:"ELASTICSEARCH_MAX_CONNS_PER_HOST"`
+	IdleConnTimeout     pkgConfig.Duration `yaml:"idle_conn_timeout" env:"ELASTICSEARCH_IDLE_CONN_TIMEOUT"`
+	DisableHTTP2        bool               `yaml:"disable_http2" env:"ELASTICSEARCH_DISABLE_HTTP2" default:"false"`
+	ConnDiagnostics     bool               `yaml:"conn_diagnostics" env:"ELASTICSEARCH_CONN_DIAGNOSTICS" default:"false"`
+
+	// 负载均衡
+	LatencyAwareSelection bool               `yaml:"latency_aware_selection" env:"ELASTICSEARCH_LATENCY_AWARE_SELECTION" default:"false"`
+	LatencyProbeInterval  pkgConfig.Duration `yaml:"latency_probe_interval" env:"ELASTICSEARCH_LATENCY_PROBE_INTERVAL" default:"30s"`
+
+	// 代理
+	ProxyURL string   `yaml:"proxy_url" env:"ELASTICSEARCH_PROXY_URL"`
+	NoProxy  []string `yaml:"no_proxy" env:"ELASTICSEARCH_NO_PROXY"`
+
+	// 客户端标识
+	UserAgentSuffix string `yaml:"user_agent_suffix" env:"ELASTICSEARCH_USER_AGENT_SUFFIX"`
+	ServiceName     string `yaml:"service_name" env:"ELASTICSEARCH_SERVICE_NAME"`
+	ServiceVersion  string `yaml:"service_version" env:"ELASTICSEARCH_SERVICE_VERSION"`
+
+	// 健康检查
+	HealthCacheTTL pkgConfig.Duration `yaml:"health_cache_ttl" env:"ELASTICSEARCH_HEALTH_CACHE_TTL"`
+
+	// 查询保护
+	MaxResultWindow     int `yaml:"max_result_window" env:"ELASTICSEARCH_MAX_RESULT_WINDOW" default:"10000"`
+	MaxQueryBytes       int `yaml:"max_query_bytes" env:"ELASTICSEARCH_MAX_QUERY_BYTES"`
+	MaxBoolClauses      int `yaml:"max_bool_clauses" env:"ELASTICSEARCH_MAX_BOOL_CLAUSES"`
+	MaxAggregationDepth int `yaml:"max_aggregation_depth" env:"ELASTICSEARCH_MAX_AGGREGATION_DEPTH"`
+
+	// 软删除
+	SoftDeleteField string `yaml:"soft_delete_field" env:"ELASTICSEARCH_SOFT_DELETE_FIELD" default:"deleted_at"`
+
+	// 历史版本
+	HistoryIndexSuffix string `yaml:"history_index_suffix" env:"ELASTICSEARCH_HISTORY_INDEX_SUFFIX"`
+
+	// 指标
+	LatencyExpvar string `yaml:"latency_expvar" env:"ELASTICSEARCH_LATENCY_EXPVAR"`
+
+	// 错误预算
+	ErrorBudgetWindow      pkgConfig.Duration `yaml:"error_budget_window" env:"ELASTICSEARCH_ERROR_BUDGET_WINDOW"`
+	ErrorBudgetThreshold   float64            `yaml:"error_budget_threshold" env:"ELASTICSEARCH_ERROR_BUDGET_THRESHOLD" default:"0.5"`
+	ErrorBudgetMinRequests int                `yaml:"error_budget_min_requests" env:"ELASTICSEARCH_ERROR_BUDGET_MIN_REQUESTS" default:"10"`
+
+	// 调试
+	DebugHTTP             bool `yaml:"debug_http" env:"ELASTICSEARCH_DEBUG_HTTP" default:"false"`
+	DebugHTTPMaxBodyBytes int  `yaml:"debug_http_max_body_bytes" env:"ELASTICSEARCH_DEBUG_HTTP_MAX_BODY_BYTES" default:"4096"`
+
+	// 错误信息
+	MaxErrorBodyBytes int `yaml:"max_error_body_bytes" env:"ELASTICSEARCH_MAX_ERROR_BODY_BYTES" default:"4096"`
+
+	// 搜索缓存
+	SearchCacheSize int `yaml:"search_cache_size" env:"ELASTICSEARCH_SEARCH_CACHE_SIZE" default:"0"`
+
+	// 影子流量
+	ShadowIndex      string  `yaml:"shadow_index" env:"ELASTICSEARCH_SHADOW_INDEX"`
+	ShadowSampleRate float64 `yaml:"shadow_sample_rate" env:"ELASTICSEARCH_SHADOW_SAMPLE_RATE" default:"0"`
+
+	// 刷新
+	Refresh string `yaml:"refresh" env:"ELASTICSEARCH_REFRESH" default:"true"`
+
+	// 部分结果
+	AllowPartialSearchResults bool `yaml:"allow_partial_search_results" env:"ELASTICSEARCH_ALLOW_PARTIAL_SEARCH_RESULTS" default:"true"`
+
+	// 测试集群清理
+	DestructiveOpsToken string `yaml:"destructive_ops_token" env:"ELASTICSEARCH_DESTRUCTIVE_OPS_TOKEN"`
 }
 
 // Validate 验证 Elasticsearch 配置
@@ -70,16 +144,17 @@ func (c *Config) ToOptions() (*Options, error) {
 
 	dialTimeout := c.DialTimeout.Duration()
 	if dialTimeout == 0 {
-		dialTimeout = 30 * time.Second
+		dialTimeout = DefaultDialTimeout
 	}
 	readTimeout := c.ReadTimeout.Duration()
 	if readTimeout == 0 {
-		readTimeout = 30 * time.Second
+		readTimeout = DefaultReadTimeout
 	}
 	writeTimeout := c.WriteTimeout.Duration()
 	if writeTimeout == 0 {
-		writeTimeout = 30 * time.Second
+		writeTimeout = DefaultWriteTimeout
 	}
+	allowPartialSearchResults := c.AllowPartialSearchResults
 
 	return &Options{
 		Addresses:    c.Addresses,
@@ -94,9 +169,80 @@ func (c *Config) ToOptions() (*Options, error) {
 		WriteTimeout: writeTimeout,
 		MaxRetries:   c.MaxRetries,
 		EnableTrace:  c.EnableTrace,
+
+		ClientCert: c.ClientCert,
+		ClientKey:  c.ClientKey,
+
+		CompressRequestBody: c.CompressRequestBody,
+		MaxIdleConnsPerHost: c.MaxIdleConnsPerHost,
+		MaxIdleConns:        c.MaxIdleConns,
+		MaxConnsPerHost:     c.MaxConnsPerHost,
+		IdleConnTimeout:     c.IdleConnTimeout.Duration(),
+		DisableHTTP2:        c.DisableHTTP2,
+		ConnDiagnostics:     c.ConnDiagnostics,
+
+		LatencyAwareSelection: c.LatencyAwareSelection,
+		LatencyProbeInterval:  c.LatencyProbeInterval.Duration(),
+
+		ProxyURL: c.ProxyURL,
+		NoProxy:  c.NoProxy,
+
+		UserAgentSuffix: c.UserAgentSuffix,
+		ServiceName:     c.ServiceName,
+		ServiceVersion:  c.ServiceVersion,
+
+		HealthCacheTTL: c.HealthCacheTTL.Duration(),
+
+		MaxResultWindow:     c.MaxResultWindow,
+		MaxQueryBytes:       c.MaxQueryBytes,
+		MaxBoolClauses:      c.MaxBoolClauses,
+		MaxAggregationDepth: c.MaxAggregationDepth,
+
+		SoftDeleteField: c.SoftDeleteField,
+
+		HistoryIndexSuffix: c.HistoryIndexSuffix,
+
+		LatencyExpvar: c.LatencyExpvar,
+
+		ErrorBudgetWindow:      c.ErrorBudgetWindow.Duration(),
+		ErrorBudgetThreshold:   c.ErrorBudgetThreshold,
+		ErrorBudgetMinRequests: c.ErrorBudgetMinRequests,
+
+		DebugHTTP:             c.DebugHTTP,
+		DebugHTTPMaxBodyBytes: c.DebugHTTPMaxBodyBytes,
+
+		MaxErrorBodyBytes: c.MaxErrorBodyBytes,
+
+		SearchCacheSize: c.SearchCacheSize,
+
+		ShadowIndex:      c.ShadowIndex,
+		ShadowSampleRate: c.ShadowSampleRate,
+
+		Refresh: RefreshPolicy(c.Refresh),
+
+		AllowPartialSearchResults: &allowPartialSearchResults,
+
+		DestructiveOpsToken: c.DestructiveOpsToken,
 	}, nil
 }
 
+// String 返回配置的字符串表示，密码和 API Key 等敏感信息会被遮蔽
+func (c *Config) String() string {
+	if c == nil {
+		return "Config(nil)"
+	}
+	return fmt.Sprintf("Config{Enabled: %t, Addresses: %v, Username: %q, Password: %q, CloudID: %q, APIKey: %q, "+
+		"EnableTLS: %t, CACert: %q, DialTimeout: %s, ReadTimeout: %s, WriteTimeout: %s, MaxRetries: %d, EnableTrace: %t}",
+		c.Enabled, redactAddresses(c.Addresses), c.Username, maskSecret(c.Password), c.CloudID, maskSecret(c.APIKey),
+		c.EnableTLS, c.CACert, c.DialTimeout.Duration(), c.ReadTimeout.Duration(), c.WriteTimeout.Duration(),
+		c.MaxRetries, c.EnableTrace)
+}
+
+// GoString 实现 fmt.GoStringer，避免 %#v 输出敏感信息
+func (c *Config) GoString() string {
+	return c.String()
+}
+
 // DialTimeoutDuration 返回 time.Duration 类型的 DialTimeout
 func (c *Config) DialTimeoutDuration() time.Duration {
 	return c.DialTimeout.Duration()
@@ -120,10 +266,222 @@ type Options struct {
 	CloudID      string        // Elastic Cloud ID（可选）
 	APIKey       string        // API Key（可选）
 	EnableTLS    bool          // 是否启用 TLS
-	CACert       string        // CA 证书路径（可选）
+	CACert       string        // CA 证书路径（PEM，可选，用于私有 CA 签发的集群证书）
 	DialTimeout  time.Duration // 连接超时
-	ReadTimeout  time.Duration // 读取超时
-	WriteTimeout time.Duration // 写入超时
+	ReadTimeout  time.Duration // 读取超时（等待响应头的时间）
+	WriteTimeout time.Duration // 写入超时（每次向连接写入数据的时间）
 	MaxRetries   int           // 最大重试次数
 	EnableTrace  bool          // 是否启用查询追踪，用于记录查询执行时间
+
+	// TLS 客户端证书
+	ClientCert string // 客户端证书路径（PEM，可选，用于双向 TLS）
+	ClientKey  string // 客户端私钥路径（PEM，与 ClientCert 同时设置）
+
+	// 连接
+	CompressRequestBody bool          // 使用 gzip 压缩请求体，适合大批量写入
+	MaxIdleConnsPerHost int           // 每个节点的最大空闲连接数，默认 10
+	MaxIdleConns        int           // 所有节点的最大空闲连接总数，0 表示使用 Go 默认值（100），不小于 MaxIdleConnsPerHost
+	MaxConnsPerHost     int           // 每个节点的最大连接数（含使用中的连接），0 表示不限制
+	IdleConnTimeout     time.Duration // 空闲连接的保持时间，0 表示使用 Go 默认值（90 秒）
+	DisableHTTP2        bool          // 禁用 HTTP/2，HTTPS 连接只使用 HTTP/1.1
+	ConnDiagnostics     bool          // 按节点统计协议、连接复用和 DNS/建连/TLS 握手耗时，通过 ConnectionDiagnostics 查看
+
+	// 负载均衡
+	LatencyAwareSelection bool          // 按节点的延迟和错误率选择节点，优先健康且快速的节点（多个地址时生效）
+	LatencyProbeInterval  time.Duration // 较慢节点的探测间隔，默认 30 秒
+
+	// 代理
+	ProxyURL string   // 代理地址（如 "http://proxy:3128"、"socks5://proxy:1080"），为空时使用 HTTP_PROXY 等环境变量
+	NoProxy  []string // 不经过代理的主机（主机名及其子域名、IP、CIDR 或 "*"），仅在设置 ProxyURL 时生效
+
+	// 客户端标识
+	UserAgentSuffix string // 追加到默认 User-Agent 后的后缀（如 "orders-api/1.4.0"）
+	ServiceName     string // 设置后所有请求携带 X-Service-Name 请求头
+	ServiceVersion  string // 设置后所有请求携带 X-Service-Version 请求头
+
+	// 健康检查
+	HealthCacheTTL time.Duration // IsConnected 结果的缓存时间，0 表示每次都发送 Ping
+
+	// 查询保护
+	MaxResultWindow     int // 分页深度上限（from+size），0 表示默认值 10000，负数表示不检查
+	MaxQueryBytes       int // 查询请求体的最大字节数，0 表示不限制
+	MaxBoolClauses      int // 单个查询中 bool 子句的最大总数，0 表示不限制
+	MaxAggregationDepth int // 聚合的最大嵌套深度，0 表示不限制
+
+	// 索引解析
+	IndexResolver IndexResolver // 将逻辑索引名解析为物理索引名（如按租户或地区），为 nil 时不做转换
+
+	// 文档级安全
+	DocumentFilter DocumentFilter // 从上下文提取过滤条件，自动追加到 Search/Count/DeleteByQuery/UpdateByQuery/ScanAll 的查询中
+
+	// 审计
+	AuditHook  AuditHook  // 破坏性操作（删除索引、按查询删除/更新、别名切换）的审计回调，为 nil 时输出到 elasticsearch.audit 日志
+	AuditActor AuditActor // 从上下文提取调用方身份，记录在审计事件中
+
+	// 软删除
+	SoftDeleteField string // 软删除时间字段，默认 deleted_at
+
+	// 历史版本
+	HistoryIndexSuffix string // 设置后 Update 会先将文档的旧版本写入 index+后缀 的历史索引（如 "-history"）
+
+	// 读取转换
+	ReadTransformer ReadTransformer // 应用于 Get、Search 和 ScanAll 返回文档 _source 的转换函数
+
+	// 反范式化
+	Denormalize []DenormalizeSpec // 写入完整文档（Index、PutIfAbsent、BulkIndexer）时嵌入引用文档字段的规则，引用文档变化后调用 ResyncDenormalized 同步
+
+	// 指标
+	LatencyExpvar string // 设置后按操作和索引统计延迟分位数和错误率，并以该名称发布到 expvar（/debug/vars）
+
+	// 错误预算
+	ErrorBudgetWindow      time.Duration   // 按操作类型统计失败率的滚动窗口，0 表示不统计
+	ErrorBudgetThreshold   float64         // 触发告警的失败率（0~1），默认 0.5
+	ErrorBudgetMinRequests int             // 窗口内请求数少于该值时不告警，默认 10
+	ErrorBudgetHook        ErrorBudgetHook // 失败率超过阈值时的回调，为 nil 时每个窗口输出一次 ERROR 日志
+
+	// 调试
+	DebugHTTP             bool // 在 DEBUG 级别输出每次 HTTP 请求和响应（不含请求头，已剔除凭据）
+	DebugHTTPMaxBodyBytes int  // 调试日志中请求体和响应体的截断长度，默认 4096
+
+	// 错误信息
+	MaxErrorBodyBytes int // 错误信息中保留的响应体最大长度，默认 4096
+
+	// 搜索缓存
+	SearchCacheSize int // stale-while-revalidate 搜索缓存的最大条目数，0 表示不启用，通过 WithStaleWhileRevalidate 按请求使用
+
+	// 影子流量
+	ShadowTarget     *ElasticsearchClient // 镜像搜索请求的目标集群客户端，为 nil 时使用当前集群
+	ShadowIndex      string               // 镜像搜索使用的索引，为空时使用原索引；与 ShadowTarget 至少设置一个
+	ShadowSampleRate float64              // 异步镜像到影子目标的搜索比例（0~1），0 表示不镜像，结果差异通过 ShadowStats 查看
+	ShadowHook       ShadowHook           // 每次影子查询完成后的回调，为 nil 时失败输出 WARN 日志、结果不一致输出 DEBUG 日志
+
+	// 刷新
+	Refresh RefreshPolicy // 写入操作默认的刷新策略（true、wait_for、false），默认 true；可通过 WithRefresh 按请求覆盖
+
+	// 部分结果
+	AllowPartialSearchResults *bool // 搜索请求默认的 allow_partial_search_results，为 nil 时使用服务端默认值（允许）
+
+	// 测试集群清理
+	DestructiveOpsToken string // 启用 ResetFeatures、DeleteAllIndices 的安全令牌，调用时须传入相同的令牌，为空时禁用；只应在测试集群的配置中设置
+}
+
+// String 返回选项的字符串表示，密码和 API Key 等敏感信息会被遮蔽
+func (o *Options) String() string {
+	if o == nil {
+		return "Options(nil)"
+	}
+	return fmt.Sprintf("Options{Addresses: %v, Username: %q, Password: %q, CloudID: %q, APIKey: %q, "+
+		"EnableTLS: %t, CACert: %q, DialTimeout: %s, ReadTimeout: %s, WriteTimeout: %s, MaxRetries: %d, EnableTrace: %t}",
+		redactAddresses(o.Addresses), o.Username, maskSecret(o.Password), o.CloudID, maskSecret(o.APIKey),
+		o.EnableTLS, o.CACert, o.DialTimeout, o.ReadTimeout, o.WriteTimeout, o.MaxRetries, o.EnableTrace)
+}
+
+// GoString 实现 fmt.GoStringer，避免 %#v 输出敏感信息
+func (o *Options) GoString() string {
+	return o.String()
+}
+
+// Validate 验证选项，检查必填项、数值范围和相互矛盾的设置
+func (o *Options) Validate() error {
+	if o == nil {
+		return fmt.Errorf("elasticsearch options cannot be nil")
+	}
+	if len(o.Addresses) == 0 {
+		return fmt.Errorf("elasticsearch addresses cannot be empty")
+	}
+	for i, addr := range o.Addresses {
+		if addr == "" {
+			return fmt.Errorf("elasticsearch addresses[%d] cannot be empty", i)
+		}
+	}
+
+	if o.APIKey != "" && (o.Username != "" || o.Password != "") {
+		return fmt.Errorf("elasticsearch APIKey cannot be used together with Username/Password")
+	}
+	if (o.Username == "") != (o.Password == "") {
+		return fmt.Errorf("elasticsearch Username and Password must be set together")
+	}
+	if (o.ClientCert == "") != (o.ClientKey == "") {
+		return fmt.Errorf("elasticsearch ClientCert and ClientKey must be set together")
+	}
+	if o.EnableTLS || o.CACert != "" || o.ClientCert != "" {
+		for i, addr := range o.Addresses {
+			u, err := url.Parse(addr)
+			if err != nil || !strings.EqualFold(u.Scheme, "https") {
+				return fmt.Errorf("elasticsearch addresses[%d] must use https when TLS is enabled", i)
+			}
+		}
+	}
+
+	if o.ProxyURL != "" {
+		if _, err := parseProxyURL(o.ProxyURL); err != nil {
+			return err
+		}
+	}
+
+	if o.DialTimeout < 0 || o.ReadTimeout < 0 || o.WriteTimeout < 0 || o.HealthCacheTTL < 0 || o.IdleConnTimeout < 0 ||
+		o.LatencyProbeInterval < 0 {
+		return fmt.Errorf("elasticsearch timeouts cannot be negative")
+	}
+	if o.MaxRetries < 0 {
+		return fmt.Errorf("elasticsearch MaxRetries cannot be negative")
+	}
+	if o.MaxIdleConnsPerHost < 0 || o.MaxIdleConns < 0 || o.MaxConnsPerHost < 0 || o.SearchCacheSize < 0 || o.MaxQueryBytes < 0 || o.MaxBoolClauses < 0 || o.MaxAggregationDepth < 0 ||
+		o.DebugHTTPMaxBodyBytes < 0 || o.MaxErrorBodyBytes < 0 || o.ErrorBudgetMinRequests < 0 {
+		return fmt.Errorf("elasticsearch size limits cannot be negative")
+	}
+	if o.ErrorBudgetWindow < 0 {
+		return fmt.Errorf("elasticsearch ErrorBudgetWindow cannot be negative")
+	}
+	if o.ErrorBudgetThreshold < 0 || o.ErrorBudgetThreshold > 1 {
+		return fmt.Errorf("elasticsearch ErrorBudgetThreshold must be between 0 and 1")
+	}
+	if !o.Refresh.valid() {
+		return fmt.Errorf("elasticsearch Refresh must be one of true, wait_for or false")
+	}
+	if o.ShadowSampleRate < 0 || o.ShadowSampleRate > 1 {
+		return fmt.Errorf("elasticsearch ShadowSampleRate must be between 0 and 1")
+	}
+	for i := range o.Denormalize {
+		if err := o.Denormalize[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyDefaults 为未设置的选项填充默认值
+func (o *Options) ApplyDefaults() {
+	if o.DialTimeout == 0 {
+		o.DialTimeout = DefaultDialTimeout
+	}
+	if o.ReadTimeout == 0 {
+		o.ReadTimeout = DefaultReadTimeout
+	}
+	if o.WriteTimeout == 0 {
+		o.WriteTimeout = DefaultWriteTimeout
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = DefaultMaxRetries
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if o.SoftDeleteField == "" {
+		o.SoftDeleteField = DefaultSoftDeleteField
+	}
+	if o.DebugHTTPMaxBodyBytes == 0 {
+		o.DebugHTTPMaxBodyBytes = defaultDebugHTTPMaxBodyBytes
+	}
+	if o.MaxErrorBodyBytes == 0 {
+		o.MaxErrorBodyBytes = DefaultMaxErrorBodyBytes
+	}
+	if o.ErrorBudgetWindow > 0 {
+		if o.ErrorBudgetThreshold == 0 {
+			o.ErrorBudgetThreshold = DefaultErrorBudgetThreshold
+		}
+		if o.ErrorBudgetMinRequests == 0 {
+			o.ErrorBudgetMinRequests = DefaultErrorBudgetMinRequests
+		}
+	}
 }
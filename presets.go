@@ -0,0 +1,71 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import "time"
+
+// 连接相关的默认值
+const (
+	DefaultDialTimeout         = 30 * time.Second // 默认连接超时
+	DefaultReadTimeout         = 30 * time.Second // 默认读取超时（等待响应头）
+	DefaultWriteTimeout        = 30 * time.Second // 默认写入超时
+	DefaultMaxRetries          = 3                // 默认最大重试次数
+	DefaultMaxIdleConnsPerHost = 10               // 默认每个节点的最大空闲连接数
+)
+
+// Preset 一组针对特定负载调优的连接选项，应在设置地址和凭据前后、其他自定义选项之前调用
+type Preset func(opts *Options)
+
+// PresetDefault 通用场景的默认选项
+func PresetDefault(opts *Options) {
+	opts.DialTimeout = DefaultDialTimeout
+	opts.ReadTimeout = DefaultReadTimeout
+	opts.WriteTimeout = DefaultWriteTimeout
+	opts.MaxRetries = DefaultMaxRetries
+	opts.CompressRequestBody = false
+	opts.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+}
+
+// PresetLowLatencySearch 面向在线查询的选项：超时短、少重试、连接池大，
+// 节点异常时快速失败，避免请求在重试中堆积
+func PresetLowLatencySearch(opts *Options) {
+	opts.DialTimeout = 2 * time.Second
+	opts.ReadTimeout = 5 * time.Second
+	opts.WriteTimeout = 5 * time.Second
+	opts.MaxRetries = 1
+	opts.CompressRequestBody = false
+	opts.MaxIdleConnsPerHost = 64
+}
+
+// PresetBulkIngest 面向批量写入的选项：超时长、多重试并压缩请求体，
+// 适合大批量 Bulk 请求在集群繁忙时等待和重试
+func PresetBulkIngest(opts *Options) {
+	opts.DialTimeout = 10 * time.Second
+	opts.ReadTimeout = 2 * time.Minute
+	opts.WriteTimeout = 2 * time.Minute
+	opts.MaxRetries = 5
+	opts.CompressRequestBody = true
+	opts.MaxIdleConnsPerHost = 16
+}
+
+// Apply 依次应用预设并返回 opts，便于链式调用
+func (o *Options) Apply(presets ...Preset) *Options {
+	for _, preset := range presets {
+		preset(o)
+	}
+	return o
+}
@@ -0,0 +1,45 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPresets(t *testing.T) {
+	opts := (&Options{Addresses: []string{"http://localhost:9200"}}).Apply(PresetBulkIngest)
+	if opts.MaxRetries != 5 || !opts.CompressRequestBody || opts.ReadTimeout != 2*time.Minute {
+		t.Errorf("PresetBulkIngest = %+v", opts)
+	}
+	if len(opts.Addresses) != 1 {
+		t.Errorf("Apply() changed Addresses = %v", opts.Addresses)
+	}
+
+	opts.Apply(PresetLowLatencySearch)
+	if opts.MaxRetries != 1 || opts.CompressRequestBody || opts.DialTimeout != 2*time.Second {
+		t.Errorf("PresetLowLatencySearch = %+v", opts)
+	}
+
+	opts.Apply(PresetDefault)
+	if opts.MaxRetries != DefaultMaxRetries || opts.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("PresetDefault = %+v", opts)
+	}
+}
+
+func TestCompressRequestBody(t *testing.T) {
+	var encoding string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}, func(opts *Options) {
+		opts.CompressRequestBody = true
+	})
+
+	if _, err := client.Search(context.Background(), "orders", map[string]interface{}{"size": 1}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if encoding != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", encoding)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ClientProvider 并发安全的延迟初始化客户端访问器，适用于依赖注入容器中配置加载顺序不确定的场景。
+// 客户端在首次 Get 时创建，创建失败不会被缓存，下次 Get 会重试
+type ClientProvider struct {
+	mu     sync.RWMutex
+	init   func() (*ElasticsearchClient, error)
+	client *ElasticsearchClient
+}
+
+// NewClientProvider 创建客户端访问器，init 在首次使用时调用
+func NewClientProvider(init func() (*ElasticsearchClient, error)) *ClientProvider {
+	return &ClientProvider{init: init}
+}
+
+// NewClientProviderFromOptions 创建使用 Options 初始化客户端的访问器
+func NewClientProviderFromOptions(opts *Options) *ClientProvider {
+	return NewClientProvider(func() (*ElasticsearchClient, error) {
+		return NewElasticsearch(opts)
+	})
+}
+
+// Get 返回客户端，未初始化时调用初始化函数创建；并发调用只会创建一次
+func (p *ClientProvider) Get() (*ElasticsearchClient, error) {
+	p.mu.RLock()
+	client := p.client
+	p.mu.RUnlock()
+	if client != nil {
+		return client, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	if p.init == nil {
+		return nil, fmt.Errorf("elasticsearch client provider has no init function")
+	}
+	client, err := p.init()
+	if err != nil {
+		return nil, err
+	}
+	p.client = client
+	return client, nil
+}
+
+// MustGet 返回客户端，初始化失败时 panic，仅用于启动阶段
+func (p *ClientProvider) MustGet() *ElasticsearchClient {
+	client, err := p.Get()
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Replace 替换当前客户端（如配置热加载后），返回被替换的客户端，由调用方在请求排空后关闭
+func (p *ClientProvider) Replace(client *ElasticsearchClient) *ElasticsearchClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	old := p.client
+	p.client = client
+	return old
+}
+
+// Reload 使用新的初始化函数立即创建客户端并替换当前客户端，创建失败时保留原客户端。
+// 返回被替换的客户端，由调用方在请求排空后关闭
+func (p *ClientProvider) Reload(init func() (*ElasticsearchClient, error)) (*ElasticsearchClient, error) {
+	client, err := init()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	old := p.client
+	p.init = init
+	p.client = client
+	return old, nil
+}
+
+// Close 关闭当前客户端，之后的 Get 会重新初始化
+func (p *ClientProvider) Close() error {
+	p.mu.Lock()
+	client := p.client
+	p.client = nil
+	p.mu.Unlock()
+
+	if client == nil {
+		return nil
+	}
+	return client.Close()
+}
@@ -0,0 +1,80 @@
+package elasticsearch
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientProviderLazyInit(t *testing.T) {
+	var calls int32
+	provider := NewClientProvider(func() (*ElasticsearchClient, error) {
+		atomic.AddInt32(&calls, 1)
+		return &ElasticsearchClient{}, nil
+	})
+
+	var wg sync.WaitGroup
+	clients := make([]*ElasticsearchClient, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = provider.MustGet()
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("init calls = %d, want 1", calls)
+	}
+	for i := range clients {
+		if clients[i] != clients[0] {
+			t.Fatal("Get() returned different clients")
+		}
+	}
+}
+
+func TestClientProviderRetriesFailedInit(t *testing.T) {
+	fail := true
+	provider := NewClientProvider(func() (*ElasticsearchClient, error) {
+		if fail {
+			return nil, errors.New("not ready")
+		}
+		return &ElasticsearchClient{}, nil
+	})
+
+	if _, err := provider.Get(); err == nil {
+		t.Fatal("Get() should return init error")
+	}
+	fail = false
+	if _, err := provider.Get(); err != nil {
+		t.Fatalf("Get() after recovery error = %v", err)
+	}
+}
+
+func TestClientProviderReload(t *testing.T) {
+	first := &ElasticsearchClient{}
+	provider := NewClientProvider(func() (*ElasticsearchClient, error) { return first, nil })
+	provider.MustGet()
+
+	second := &ElasticsearchClient{}
+	old, err := provider.Reload(func() (*ElasticsearchClient, error) { return second, nil })
+	if err != nil || old != first {
+		t.Fatalf("Reload() = %p, %v, want old client", old, err)
+	}
+	if provider.MustGet() != second {
+		t.Error("Get() after Reload should return new client")
+	}
+
+	if _, err := provider.Reload(func() (*ElasticsearchClient, error) { return nil, errors.New("bad config") }); err == nil {
+		t.Error("Reload() should return init error")
+	}
+	if provider.MustGet() != second {
+		t.Error("failed Reload should keep current client")
+	}
+
+	if replaced := provider.Replace(first); replaced != second {
+		t.Error("Replace() should return previous client")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// newProxyFunc 根据 ProxyURL 和 NoProxy 构建 Transport 的 Proxy 函数。
+// 未设置 ProxyURL 时返回 nil，沿用 HTTP_PROXY/HTTPS_PROXY/NO_PROXY 环境变量
+func newProxyFunc(opts *Options) (func(*http.Request) (*url.URL, error), error) {
+	if opts.ProxyURL == "" {
+		return nil, nil
+	}
+	proxyURL, err := parseProxyURL(opts.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	noProxy := parseNoProxy(opts.NoProxy)
+	return func(req *http.Request) (*url.URL, error) {
+		if noProxy.match(req.URL.Hostname()) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// parseProxyURL 解析代理地址，支持 http、https 和 socks5
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, redactError(fmt.Errorf("invalid elasticsearch proxy url: %w", err), nil)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported elasticsearch proxy scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("elasticsearch proxy url must include a host")
+	}
+	return u, nil
+}
+
+// noProxyRules 不经过代理的主机规则
+type noProxyRules struct {
+	all      bool
+	hosts    []string // 主机名，同时匹配其子域名
+	networks []*net.IPNet
+}
+
+// parseNoProxy 解析 NoProxy 列表，支持 "*"、主机名、".域名"、IP 和 CIDR
+func parseNoProxy(entries []string) noProxyRules {
+	var rules noProxyRules
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			rules.all = true
+		case strings.Contains(entry, "/"):
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				rules.networks = append(rules.networks, network)
+			}
+		default:
+			if host, _, err := net.SplitHostPort(entry); err == nil {
+				entry = host
+			}
+			rules.hosts = append(rules.hosts, strings.TrimPrefix(entry, "."))
+		}
+	}
+	return rules
+}
+
+// match 判断主机是否不经过代理
+func (r noProxyRules) match(host string) bool {
+	if r.all {
+		return true
+	}
+	host = strings.ToLower(host)
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range r.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	for _, h := range r.hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,446 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+// Query 查询 DSL 中的查询子句
+type Query interface {
+	// Source 返回查询子句的 JSON 结构
+	Source() map[string]interface{}
+}
+
+// FilterQuery 用于过滤上下文的查询子句。
+// 过滤上下文不计算相关性得分，频繁使用的过滤条件会被节点查询缓存复用，
+// 因此精确匹配、范围、存在性等条件应优先放在 Filter/MustNot 中
+type FilterQuery interface {
+	Query
+	filterContext()
+}
+
+// filterable 为可直接用于过滤上下文的查询类型提供 FilterQuery 标记
+type filterable struct{}
+
+func (filterable) filterContext() {}
+
+// SearchBody 将查询包装为 Search/Count/DeleteByQuery 所需的请求体
+func SearchBody(q Query) map[string]interface{} {
+	return map[string]interface{}{"query": q.Source()}
+}
+
+// withBoolClause 将子句放入请求体查询外层 bool 的 clause（filter/must_not）中，
+// 原查询作为 must 子句保留，返回新的请求体且不修改调用方的数据
+func withBoolClause(body map[string]interface{}, clause string, query map[string]interface{}) map[string]interface{} {
+	boolQuery := map[string]interface{}{
+		clause: []interface{}{query},
+	}
+	if original, ok := body["query"]; ok && original != nil {
+		boolQuery["must"] = []interface{}{original}
+	}
+
+	wrapped := make(map[string]interface{}, len(body)+1)
+	for k, v := range body {
+		wrapped[k] = v
+	}
+	wrapped["query"] = map[string]interface{}{"bool": boolQuery}
+	return wrapped
+}
+
+// BoolQuery bool 组合查询
+type BoolQuery struct {
+	must    []Query
+	should  []Query
+	filter  []FilterQuery
+	mustNot []FilterQuery
+
+	minimumShouldMatch interface{}
+}
+
+// NewBoolQuery 创建 bool 查询
+func NewBoolQuery() *BoolQuery {
+	return &BoolQuery{}
+}
+
+// Must 添加必须匹配且参与评分的子句（查询上下文）
+func (q *BoolQuery) Must(queries ...Query) *BoolQuery {
+	q.must = append(q.must, queries...)
+	return q
+}
+
+// Should 添加可选匹配且参与评分的子句（查询上下文）
+func (q *BoolQuery) Should(queries ...Query) *BoolQuery {
+	q.should = append(q.should, queries...)
+	return q
+}
+
+// Filter 添加必须匹配但不参与评分的子句（过滤上下文，可缓存）
+func (q *BoolQuery) Filter(queries ...FilterQuery) *BoolQuery {
+	q.filter = append(q.filter, queries...)
+	return q
+}
+
+// MustNot 添加必须不匹配的子句（过滤上下文，可缓存）
+func (q *BoolQuery) MustNot(queries ...FilterQuery) *BoolQuery {
+	q.mustNot = append(q.mustNot, queries...)
+	return q
+}
+
+// MinimumShouldMatch 设置 should 子句的最少匹配数（如 1 或 "75%"）
+func (q *BoolQuery) MinimumShouldMatch(value interface{}) *BoolQuery {
+	q.minimumShouldMatch = value
+	return q
+}
+
+// Source 返回 bool 查询的 JSON 结构
+func (q *BoolQuery) Source() map[string]interface{} {
+	body := make(map[string]interface{})
+	if len(q.must) > 0 {
+		body["must"] = querySources(q.must)
+	}
+	if len(q.should) > 0 {
+		body["should"] = querySources(q.should)
+	}
+	if len(q.filter) > 0 {
+		body["filter"] = filterSources(q.filter)
+	}
+	if len(q.mustNot) > 0 {
+		body["must_not"] = filterSources(q.mustNot)
+	}
+	if q.minimumShouldMatch != nil {
+		body["minimum_should_match"] = q.minimumShouldMatch
+	}
+	return map[string]interface{}{"bool": body}
+}
+
+// filterContext bool 查询可嵌套在过滤上下文中，此时其所有子句均不参与评分
+func (q *BoolQuery) filterContext() {}
+
+// querySources 转换查询子句列表
+func querySources(queries []Query) []interface{} {
+	sources := make([]interface{}, 0, len(queries))
+	for _, q := range queries {
+		if q != nil {
+			sources = append(sources, q.Source())
+		}
+	}
+	return sources
+}
+
+// filterSources 转换过滤子句列表
+func filterSources(queries []FilterQuery) []interface{} {
+	sources := make([]interface{}, 0, len(queries))
+	for _, q := range queries {
+		if q != nil {
+			sources = append(sources, q.Source())
+		}
+	}
+	return sources
+}
+
+// filterAdapter 将任意查询显式放入过滤上下文
+type filterAdapter struct {
+	filterable
+	query Query
+}
+
+// Source 返回被包装查询的 JSON 结构
+func (f filterAdapter) Source() map[string]interface{} {
+	return f.query.Source()
+}
+
+// AsFilter 将评分查询（如全文 match）显式放入过滤上下文，放弃相关性得分以换取缓存
+func AsFilter(q Query) FilterQuery {
+	if fq, ok := q.(FilterQuery); ok {
+		return fq
+	}
+	return filterAdapter{query: q}
+}
+
+// TermQuery 精确匹配查询
+type TermQuery struct {
+	filterable
+	field string
+	value interface{}
+}
+
+// Term 创建精确匹配查询，适用于 keyword、数值、布尔等未分词字段
+func Term(field string, value interface{}) *TermQuery {
+	return &TermQuery{field: field, value: value}
+}
+
+// Source 返回 term 查询的 JSON 结构
+func (q *TermQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"term": map[string]interface{}{q.field: q.value}}
+}
+
+// CachedTermFilter 创建用于过滤上下文的精确匹配条件。
+// 返回类型只能放入 Filter/MustNot，确保条件不参与评分并可被节点查询缓存复用
+func CachedTermFilter(field string, value interface{}) FilterQuery {
+	return Term(field, value)
+}
+
+// TermsQuery 多值精确匹配查询
+type TermsQuery struct {
+	filterable
+	field  string
+	values []interface{}
+}
+
+// Terms 创建多值精确匹配查询，匹配任意一个值即可
+func Terms(field string, values ...interface{}) *TermsQuery {
+	return &TermsQuery{field: field, values: values}
+}
+
+// Source 返回 terms 查询的 JSON 结构
+func (q *TermsQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"terms": map[string]interface{}{q.field: q.values}}
+}
+
+// ExistsQuery 字段存在性查询
+type ExistsQuery struct {
+	filterable
+	field string
+}
+
+// Exists 创建字段存在性查询
+func Exists(field string) *ExistsQuery {
+	return &ExistsQuery{field: field}
+}
+
+// Source 返回 exists 查询的 JSON 结构
+func (q *ExistsQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"exists": map[string]interface{}{"field": q.field}}
+}
+
+// MatchAllQuery 匹配全部文档的查询
+type MatchAllQuery struct{}
+
+// MatchAll 创建匹配全部文档的查询
+func MatchAll() MatchAllQuery {
+	return MatchAllQuery{}
+}
+
+// Source 返回 match_all 查询的 JSON 结构
+func (MatchAllQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"match_all": map[string]interface{}{}}
+}
+
+// MatchQuery 全文匹配查询
+type MatchQuery struct {
+	field     string
+	text      interface{}
+	operator  string
+	fuzziness string
+	boost     float64
+}
+
+// Match 创建全文匹配查询，查询文本按字段的分析器分词后匹配
+func Match(field string, text interface{}) *MatchQuery {
+	return &MatchQuery{field: field, text: text}
+}
+
+// Operator 设置分词之间的关系（"and" 或 "or"，默认 or）
+func (q *MatchQuery) Operator(operator string) *MatchQuery {
+	q.operator = operator
+	return q
+}
+
+// Fuzziness 设置模糊匹配的编辑距离（如 "AUTO"、"1"）
+func (q *MatchQuery) Fuzziness(fuzziness string) *MatchQuery {
+	q.fuzziness = fuzziness
+	return q
+}
+
+// Boost 设置查询的权重
+func (q *MatchQuery) Boost(boost float64) *MatchQuery {
+	q.boost = boost
+	return q
+}
+
+// Source 返回 match 查询的 JSON 结构
+func (q *MatchQuery) Source() map[string]interface{} {
+	params := map[string]interface{}{"query": q.text}
+	if q.operator != "" {
+		params["operator"] = q.operator
+	}
+	if q.fuzziness != "" {
+		params["fuzziness"] = q.fuzziness
+	}
+	if q.boost != 0 {
+		params["boost"] = q.boost
+	}
+	return map[string]interface{}{"match": map[string]interface{}{q.field: params}}
+}
+
+// MatchPhraseQuery 短语匹配查询
+type MatchPhraseQuery struct {
+	field string
+	text  string
+	slop  int
+}
+
+// MatchPhrase 创建短语匹配查询，要求分词按顺序相邻出现
+func MatchPhrase(field string, text string) *MatchPhraseQuery {
+	return &MatchPhraseQuery{field: field, text: text}
+}
+
+// Slop 设置分词之间允许间隔的位置数
+func (q *MatchPhraseQuery) Slop(slop int) *MatchPhraseQuery {
+	q.slop = slop
+	return q
+}
+
+// Source 返回 match_phrase 查询的 JSON 结构
+func (q *MatchPhraseQuery) Source() map[string]interface{} {
+	params := map[string]interface{}{"query": q.text}
+	if q.slop > 0 {
+		params["slop"] = q.slop
+	}
+	return map[string]interface{}{"match_phrase": map[string]interface{}{q.field: params}}
+}
+
+// MultiMatchQuery 多字段全文匹配查询
+type MultiMatchQuery struct {
+	text      string
+	fields    []string
+	matchType string
+}
+
+// MultiMatch 创建多字段全文匹配查询，字段可带权重（如 "title^3"）
+func MultiMatch(text string, fields ...string) *MultiMatchQuery {
+	return &MultiMatchQuery{text: text, fields: fields}
+}
+
+// Type 设置匹配方式（best_fields、most_fields、cross_fields、phrase 等）
+func (q *MultiMatchQuery) Type(matchType string) *MultiMatchQuery {
+	q.matchType = matchType
+	return q
+}
+
+// Source 返回 multi_match 查询的 JSON 结构
+func (q *MultiMatchQuery) Source() map[string]interface{} {
+	params := map[string]interface{}{"query": q.text, "fields": q.fields}
+	if q.matchType != "" {
+		params["type"] = q.matchType
+	}
+	return map[string]interface{}{"multi_match": params}
+}
+
+// RangeQuery 范围查询
+type RangeQuery struct {
+	filterable
+	field  string
+	params map[string]interface{}
+}
+
+// Range 创建范围查询，通过 Gt/Gte/Lt/Lte 设置边界
+func Range(field string) *RangeQuery {
+	return &RangeQuery{field: field, params: map[string]interface{}{}}
+}
+
+// Gt 设置下界（不含）
+func (q *RangeQuery) Gt(value interface{}) *RangeQuery {
+	q.params["gt"] = value
+	return q
+}
+
+// Gte 设置下界（含）
+func (q *RangeQuery) Gte(value interface{}) *RangeQuery {
+	q.params["gte"] = value
+	return q
+}
+
+// Lt 设置上界（不含）
+func (q *RangeQuery) Lt(value interface{}) *RangeQuery {
+	q.params["lt"] = value
+	return q
+}
+
+// Lte 设置上界（含）
+func (q *RangeQuery) Lte(value interface{}) *RangeQuery {
+	q.params["lte"] = value
+	return q
+}
+
+// Format 设置日期边界的格式（如 "yyyy-MM-dd"）
+func (q *RangeQuery) Format(format string) *RangeQuery {
+	q.params["format"] = format
+	return q
+}
+
+// TimeZone 设置日期边界的时区（如 "+08:00"）
+func (q *RangeQuery) TimeZone(timeZone string) *RangeQuery {
+	q.params["time_zone"] = timeZone
+	return q
+}
+
+// Source 返回 range 查询的 JSON 结构
+func (q *RangeQuery) Source() map[string]interface{} {
+	params := make(map[string]interface{}, len(q.params))
+	for k, v := range q.params {
+		params[k] = v
+	}
+	return map[string]interface{}{"range": map[string]interface{}{q.field: params}}
+}
+
+// PrefixQuery 前缀匹配查询
+type PrefixQuery struct {
+	filterable
+	field  string
+	prefix string
+}
+
+// Prefix 创建前缀匹配查询，适用于 keyword 字段
+func Prefix(field string, prefix string) *PrefixQuery {
+	return &PrefixQuery{field: field, prefix: prefix}
+}
+
+// Source 返回 prefix 查询的 JSON 结构
+func (q *PrefixQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"prefix": map[string]interface{}{q.field: q.prefix}}
+}
+
+// WildcardQuery 通配符查询
+type WildcardQuery struct {
+	filterable
+	field   string
+	pattern string
+}
+
+// Wildcard 创建通配符查询（* 匹配任意字符，? 匹配单个字符），以通配符开头的模式代价很高
+func Wildcard(field string, pattern string) *WildcardQuery {
+	return &WildcardQuery{field: field, pattern: pattern}
+}
+
+// Source 返回 wildcard 查询的 JSON 结构
+func (q *WildcardQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"wildcard": map[string]interface{}{q.field: q.pattern}}
+}
+
+// IDsQuery 按文档 ID 匹配的查询
+type IDsQuery struct {
+	filterable
+	ids []string
+}
+
+// IDs 创建按文档 ID 匹配的查询
+func IDs(ids ...string) *IDsQuery {
+	return &IDsQuery{ids: ids}
+}
+
+// Source 返回 ids 查询的 JSON 结构
+func (q *IDsQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"ids": map[string]interface{}{"values": q.ids}}
+}
@@ -0,0 +1,78 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// matchAllQuery 测试用的评分查询
+type matchAllQuery struct{}
+
+func (matchAllQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"match_all": map[string]interface{}{}}
+}
+
+func TestBoolQuery_Source(t *testing.T) {
+	q := NewBoolQuery().
+		Must(matchAllQuery{}).
+		Filter(CachedTermFilter("status", "active"), Terms("tag", "a", "b")).
+		MustNot(Exists("deleted_at")).
+		Should(Term("featured", true)).
+		MinimumShouldMatch(1)
+
+	got, err := json.Marshal(SearchBody(q))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"query":{"bool":{"filter":[{"term":{"status":"active"}},{"terms":{"tag":["a","b"]}}],"minimum_should_match":1,"must":[{"match_all":{}}],"must_not":[{"exists":{"field":"deleted_at"}}],"should":[{"term":{"featured":true}}]}}}`
+	if string(got) != want {
+		t.Errorf("SearchBody() = %s, want %s", got, want)
+	}
+}
+
+func TestAsFilter(t *testing.T) {
+	scored := matchAllQuery{}
+	filter := AsFilter(scored)
+	if filter.Source()["match_all"] == nil {
+		t.Errorf("AsFilter() source = %v", filter.Source())
+	}
+
+	term := Term("status", "active")
+	if AsFilter(term) != FilterQuery(term) {
+		t.Error("AsFilter() should return filter queries unchanged")
+	}
+
+	nested := NewBoolQuery().Filter(NewBoolQuery().Should(Term("a", 1), Term("b", 2)))
+	if countBoolClauses(nested.Source()) != 3 {
+		t.Errorf("nested bool clauses = %d, want 3", countBoolClauses(nested.Source()))
+	}
+}
+
+func TestQueryBuilders(t *testing.T) {
+	q := NewBoolQuery().
+		Must(Match("title", "go").Operator("and").Fuzziness("AUTO"), MultiMatch("elastic", "title^3", "body").Type("best_fields")).
+		Should(MatchPhrase("body", "fast search").Slop(2)).
+		Filter(Range("age").Gte(18).Lt(65), Prefix("sku", "AB-"), IDs("1", "2")).
+		MustNot(Wildcard("email", "*@spam.test"))
+
+	got, err := json.Marshal(SearchBody(q))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"query":{"bool":{` +
+		`"filter":[{"range":{"age":{"gte":18,"lt":65}}},{"prefix":{"sku":"AB-"}},{"ids":{"values":["1","2"]}}],` +
+		`"must":[{"match":{"title":{"fuzziness":"AUTO","operator":"and","query":"go"}}},` +
+		`{"multi_match":{"fields":["title^3","body"],"query":"elastic","type":"best_fields"}}],` +
+		`"must_not":[{"wildcard":{"email":"*@spam.test"}}],` +
+		`"should":[{"match_phrase":{"body":{"query":"fast search","slop":2}}}]}}}`
+	if string(got) != want {
+		t.Errorf("SearchBody() = %s, want %s", got, want)
+	}
+
+	if _, ok := MatchAll().Source()["match_all"]; !ok {
+		t.Error("MatchAll() should build a match_all query")
+	}
+	if _, ok := Query(Match("title", "go")).(FilterQuery); ok {
+		t.Error("Match() should not be usable as a filter without AsFilter")
+	}
+}
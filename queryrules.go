@@ -0,0 +1,206 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// 查询规则类型
+const (
+	QueryRulePinned  = "pinned"  // 将文档固定在结果顶部
+	QueryRuleExclude = "exclude" // 将文档从结果中排除
+)
+
+// PinnedDocument 跨索引固定或排除文档时的文档标识
+type PinnedDocument struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+// PinnedQuery pinned 查询，将指定文档按顺序固定在自然结果之前
+type PinnedQuery struct {
+	organic Query
+	ids     []string
+	docs    []PinnedDocument
+}
+
+// Pinned 创建 pinned 查询，ids 中的文档按顺序排在 organic 查询结果之前
+func Pinned(organic Query, ids ...string) *PinnedQuery {
+	return &PinnedQuery{organic: organic, ids: ids}
+}
+
+// PinnedDocs 创建按索引和 ID 固定文档的 pinned 查询，用于跨索引搜索
+func PinnedDocs(organic Query, docs ...PinnedDocument) *PinnedQuery {
+	return &PinnedQuery{organic: organic, docs: docs}
+}
+
+// Source 返回 pinned 查询的 JSON 结构
+func (q *PinnedQuery) Source() map[string]interface{} {
+	params := map[string]interface{}{"organic": q.organic.Source()}
+	if len(q.docs) > 0 {
+		params["docs"] = q.docs
+	} else {
+		params["ids"] = q.ids
+	}
+	return map[string]interface{}{"pinned": params}
+}
+
+// QueryRulesQuery rule 查询，按规则集对 organic 查询结果应用固定和排除规则
+type QueryRulesQuery struct {
+	organic       Query
+	matchCriteria map[string]interface{}
+	rulesetIDs    []string
+}
+
+// QueryRules 创建 rule 查询，matchCriteria 为规则条件中 metadata 对应的值（如 {"user_query": "pugs"}）
+func QueryRules(organic Query, matchCriteria map[string]interface{}, rulesetIDs ...string) *QueryRulesQuery {
+	return &QueryRulesQuery{organic: organic, matchCriteria: matchCriteria, rulesetIDs: rulesetIDs}
+}
+
+// Source 返回 rule 查询的 JSON 结构
+func (q *QueryRulesQuery) Source() map[string]interface{} {
+	return map[string]interface{}{"rule": map[string]interface{}{
+		"organic":        q.organic.Source(),
+		"match_criteria": q.matchCriteria,
+		"ruleset_ids":    q.rulesetIDs,
+	}}
+}
+
+// QueryRuleCriteria 规则的匹配条件
+type QueryRuleCriteria struct {
+	Type     string        `json:"type"`               // exact、fuzzy、prefix、suffix、contains、lt、lte、gt、gte、always
+	Metadata string        `json:"metadata,omitempty"` // 对应 rule 查询 match_criteria 中的键
+	Values   []interface{} `json:"values,omitempty"`
+}
+
+// ExactMatch 返回 metadata 的值与任一 values 完全相同时匹配的条件
+func ExactMatch(metadata string, values ...interface{}) QueryRuleCriteria {
+	return QueryRuleCriteria{Type: "exact", Metadata: metadata, Values: values}
+}
+
+// QueryRuleActions 规则匹配时固定或排除的文档，IDs 和 Docs 只能设置其一
+type QueryRuleActions struct {
+	IDs  []string         `json:"ids,omitempty"`
+	Docs []PinnedDocument `json:"docs,omitempty"`
+}
+
+// QueryRule 查询规则
+type QueryRule struct {
+	RuleID   string              `json:"rule_id,omitempty"`
+	Type     string              `json:"type"` // QueryRulePinned 或 QueryRuleExclude
+	Criteria []QueryRuleCriteria `json:"criteria"`
+	Actions  QueryRuleActions    `json:"actions"`
+	Priority *int                `json:"priority,omitempty"`
+}
+
+// PinRule 返回满足条件时将 ids 固定在结果顶部的规则
+func PinRule(ruleID string, criteria QueryRuleCriteria, ids ...string) QueryRule {
+	return QueryRule{RuleID: ruleID, Type: QueryRulePinned, Criteria: []QueryRuleCriteria{criteria}, Actions: QueryRuleActions{IDs: ids}}
+}
+
+// ExcludeRule 返回满足条件时将 ids 从结果中排除的规则（需要 8.16 及以上版本）
+func ExcludeRule(ruleID string, criteria QueryRuleCriteria, ids ...string) QueryRule {
+	return QueryRule{RuleID: ruleID, Type: QueryRuleExclude, Criteria: []QueryRuleCriteria{criteria}, Actions: QueryRuleActions{IDs: ids}}
+}
+
+// PutQueryRuleset 创建或替换规则集
+func (c *ElasticsearchClient) PutQueryRuleset(ctx context.Context, rulesetID string, rules []QueryRule) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.RuleID == "" {
+			return fmt.Errorf("query rule ID cannot be empty")
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"rules": rules})
+	if err != nil {
+		return fmt.Errorf("failed to marshal query ruleset: %w", err)
+	}
+	return c.resourceRequest(ctx, "put query ruleset", rulesetID, esapi.QueryRulesPutRulesetRequest{
+		RulesetID: rulesetID,
+		Body:      strings.NewReader(string(body)),
+	}, nil)
+}
+
+// GetQueryRuleset 获取规则集中的规则，规则集不存在时返回的错误满足 IsNotFound
+func (c *ElasticsearchClient) GetQueryRuleset(ctx context.Context, rulesetID string) ([]QueryRule, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Rules []QueryRule `json:"rules"`
+	}
+	if err := c.resourceRequest(ctx, "get query ruleset", rulesetID, esapi.QueryRulesGetRulesetRequest{RulesetID: rulesetID}, &result); err != nil {
+		return nil, err
+	}
+	return result.Rules, nil
+}
+
+// DeleteQueryRuleset 删除规则集
+func (c *ElasticsearchClient) DeleteQueryRuleset(ctx context.Context, rulesetID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.resourceRequest(ctx, "delete query ruleset", rulesetID, esapi.QueryRulesDeleteRulesetRequest{RulesetID: rulesetID}, nil)
+}
+
+// PutQueryRule 在规则集中创建或替换单条规则，规则集不存在时自动创建
+func (c *ElasticsearchClient) PutQueryRule(ctx context.Context, rulesetID string, rule QueryRule) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if rule.RuleID == "" {
+		return fmt.Errorf("query rule ID cannot be empty")
+	}
+
+	ruleID := rule.RuleID
+	rule.RuleID = ""
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal query rule: %w", err)
+	}
+	return c.resourceRequest(ctx, "put query rule", rulesetID, esapi.QueryRulesPutRuleRequest{
+		RulesetID: rulesetID,
+		RuleID:    ruleID,
+		Body:      strings.NewReader(string(body)),
+	}, nil)
+}
+
+// DeleteQueryRule 删除规则集中的单条规则
+func (c *ElasticsearchClient) DeleteQueryRule(ctx context.Context, rulesetID string, ruleID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if ruleID == "" {
+		return fmt.Errorf("query rule ID cannot be empty")
+	}
+
+	return c.resourceRequest(ctx, "delete query rule", rulesetID, esapi.QueryRulesDeleteRuleRequest{
+		RulesetID: rulesetID,
+		RuleID:    ruleID,
+	}, nil)
+}
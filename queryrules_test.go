@@ -0,0 +1,82 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestPinnedQuery(t *testing.T) {
+	got, _ := json.Marshal(Pinned(Match("title", "shoes"), "1", "2").Source())
+	want := `{"pinned":{"ids":["1","2"],"organic":{"match":{"title":{"query":"shoes"}}}}}`
+	if string(got) != want {
+		t.Errorf("Pinned() = %s, want %s", got, want)
+	}
+
+	got, _ = json.Marshal(PinnedDocs(MatchAll(), PinnedDocument{Index: "products", ID: "9"}).Source())
+	want = `{"pinned":{"docs":[{"_index":"products","_id":"9"}],"organic":{"match_all":{}}}}`
+	if string(got) != want {
+		t.Errorf("PinnedDocs() = %s, want %s", got, want)
+	}
+
+	got, _ = json.Marshal(QueryRules(Match("title", "pugs"), map[string]interface{}{"user_query": "pugs"}, "promotions").Source())
+	want = `{"rule":{"match_criteria":{"user_query":"pugs"},"organic":{"match":{"title":{"query":"pugs"}}},"ruleset_ids":["promotions"]}}`
+	if string(got) != want {
+		t.Errorf("QueryRules() = %s, want %s", got, want)
+	}
+}
+
+func TestQueryRulesets(t *testing.T) {
+	bodies := map[string]string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.Method+" "+r.URL.Path] = string(body)
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"ruleset_id":"promotions","rules":[{"rule_id":"pin-pugs","type":"pinned","criteria":[{"type":"exact","metadata":"user_query","values":["pugs"]}],"actions":{"ids":["1"]}}]}`))
+			return
+		}
+		w.Write([]byte(`{"result":"created"}`))
+	})
+	ctx := context.Background()
+
+	rules := []QueryRule{
+		PinRule("pin-pugs", ExactMatch("user_query", "pugs"), "1"),
+		ExcludeRule("bury-cats", ExactMatch("user_query", "dogs"), "7"),
+	}
+	if err := client.PutQueryRuleset(ctx, "promotions", rules); err != nil {
+		t.Fatalf("PutQueryRuleset() error = %v", err)
+	}
+	want := `{"rules":[{"rule_id":"pin-pugs","type":"pinned","criteria":[{"type":"exact","metadata":"user_query","values":["pugs"]}],"actions":{"ids":["1"]}},` +
+		`{"rule_id":"bury-cats","type":"exclude","criteria":[{"type":"exact","metadata":"user_query","values":["dogs"]}],"actions":{"ids":["7"]}}]}`
+	if got := bodies["PUT /_query_rules/promotions"]; got != want {
+		t.Errorf("ruleset body = %s", got)
+	}
+	if err := client.PutQueryRuleset(ctx, "promotions", []QueryRule{{Type: QueryRulePinned}}); err == nil {
+		t.Error("PutQueryRuleset() with an empty rule ID should fail")
+	}
+
+	got, err := client.GetQueryRuleset(ctx, "promotions")
+	if err != nil || len(got) != 1 || got[0].RuleID != "pin-pugs" || got[0].Actions.IDs[0] != "1" {
+		t.Fatalf("GetQueryRuleset() = %+v, %v", got, err)
+	}
+
+	if err := client.PutQueryRule(ctx, "promotions", rules[1]); err != nil {
+		t.Fatalf("PutQueryRule() error = %v", err)
+	}
+	if got := bodies["PUT /_query_rules/promotions/_rule/bury-cats"]; got != `{"type":"exclude","criteria":[{"type":"exact","metadata":"user_query","values":["dogs"]}],"actions":{"ids":["7"]}}` {
+		t.Errorf("rule body = %s", got)
+	}
+	if err := client.DeleteQueryRule(ctx, "promotions", "bury-cats"); err != nil {
+		t.Fatalf("DeleteQueryRule() error = %v", err)
+	}
+	if err := client.DeleteQueryRuleset(ctx, "promotions"); err != nil {
+		t.Fatalf("DeleteQueryRuleset() error = %v", err)
+	}
+	for _, key := range []string{"DELETE /_query_rules/promotions/_rule/bury-cats", "DELETE /_query_rules/promotions"} {
+		if _, ok := bodies[key]; !ok {
+			t.Errorf("missing request %s", key)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// DefaultRRFRankConstant 倒数排名融合（RRF）的默认排名常数
+const DefaultRRFRankConstant = 60
+
+// Embedder 将查询文本转换为向量，需与写入分块时使用的模型一致
+type Embedder func(ctx context.Context, text string) ([]float64, error)
+
+// InferenceEmbedder 返回使用集群 text_embedding 推理端点生成向量的 Embedder
+func (c *ElasticsearchClient) InferenceEmbedder(inferenceID string) Embedder {
+	return func(ctx context.Context, text string) ([]float64, error) {
+		embeddings, err := c.TextEmbeddings(ctx, inferenceID, []string{text})
+		if err != nil {
+			return nil, err
+		}
+		return embeddings[0], nil
+	}
+}
+
+// Chunk RetrieveChunks 返回的文本分块
+type Chunk struct {
+	ID         string                 // 分块文档 ID
+	Index      string                 // 分块所在索引
+	Text       string                 // 分块文本
+	Score      float64                // RRF 融合得分
+	TextRank   int                    // 在全文检索结果中的排名（从 1 开始），未命中时为 0
+	VectorRank int                    // 在向量检索结果中的排名（从 1 开始），未命中时为 0
+	Metadata   map[string]interface{} // 除文本和向量字段外的 _source 字段
+}
+
+// RetrieveOption RetrieveChunks 的选项
+type RetrieveOption func(*retrieveConfig)
+
+// retrieveConfig 分块检索配置
+type retrieveConfig struct {
+	textField    string
+	vectorField  string
+	rankConstant int
+	rankWindow   int
+}
+
+// newRetrieveConfig 应用分块检索选项
+func newRetrieveConfig(k int, opts []RetrieveOption) *retrieveConfig {
+	cfg := &retrieveConfig{
+		textField:    "text",
+		vectorField:  "vector",
+		rankConstant: DefaultRRFRankConstant,
+		rankWindow:   max(k, 50),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithChunkFields 设置分块的文本字段和向量字段，默认为 text 和 vector
+func WithChunkFields(textField string, vectorField string) RetrieveOption {
+	return func(cfg *retrieveConfig) {
+		cfg.textField = textField
+		cfg.vectorField = vectorField
+	}
+}
+
+// WithRRF 设置 RRF 的排名常数和每路检索参与融合的结果数（不小于 k）
+func WithRRF(rankConstant int, rankWindow int) RetrieveOption {
+	return func(cfg *retrieveConfig) {
+		if rankConstant > 0 {
+			cfg.rankConstant = rankConstant
+		}
+		if rankWindow > 0 {
+			cfg.rankWindow = rankWindow
+		}
+	}
+}
+
+// RetrieveChunks 为检索增强生成（RAG）召回与 queryText 最相关的 k 个文本分块。
+// 在一次 msearch 中同时执行 BM25 全文检索和 kNN 向量检索，并在客户端使用倒数排名融合（RRF）合并结果，
+// 不依赖服务端 retriever 的许可证要求；filters 同时作用于两路检索
+func (c *ElasticsearchClient) RetrieveChunks(ctx context.Context, index string, queryText string, embedder Embedder, k int, filters []FilterQuery, opts ...RetrieveOption) ([]Chunk, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if queryText == "" || embedder == nil {
+		return nil, fmt.Errorf("chunk retrieval requires query text and an embedder")
+	}
+	if k <= 0 {
+		k = 10
+	}
+	cfg := newRetrieveConfig(k, opts)
+	window := max(cfg.rankWindow, k)
+
+	vector, err := embedder(ctx, queryText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	filterSources := make([]interface{}, len(filters))
+	for i, filter := range filters {
+		filterSources[i] = filter.Source()
+	}
+	source := map[string]interface{}{"excludes": []string{cfg.vectorField}}
+
+	textQuery := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":   []interface{}{Match(cfg.textField, queryText).Source()},
+			"filter": filterSources,
+		},
+	}
+	search := VectorSearch{Field: cfg.vectorField, Vector: vector}
+	knn := search.knn(window)
+	if len(filterSources) > 0 {
+		knn["filter"] = filterSources
+	}
+
+	results, err := c.MSearch(ctx, []MSearchItem{
+		{Index: index, Query: map[string]interface{}{"query": textQuery, "size": window, "_source": source}},
+		{Index: index, Query: map[string]interface{}{"knn": knn, "size": window, "_source": source}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(map[string]*Chunk)
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		hits, err := decodeHits(result.Result["hits"])
+		if err != nil {
+			return nil, err
+		}
+		for rank, hit := range hits {
+			key := hit.Index + "/" + hit.ID
+			chunk, ok := chunks[key]
+			if !ok {
+				chunk, err = newChunk(hit, cfg.textField)
+				if err != nil {
+					return nil, err
+				}
+				chunks[key] = chunk
+			}
+			if i == 0 {
+				chunk.TextRank = rank + 1
+			} else {
+				chunk.VectorRank = rank + 1
+			}
+			chunk.Score += 1 / float64(cfg.rankConstant+rank+1)
+		}
+	}
+
+	ranked := make([]Chunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		ranked = append(ranked, *chunk)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+	if len(ranked) > k {
+		ranked = ranked[:k]
+	}
+	return ranked, nil
+}
+
+// newChunk 从命中文档中提取分块文本和元数据
+func newChunk(hit Hit, textField string) (*Chunk, error) {
+	var source map[string]interface{}
+	if err := hit.DecodeSource(&source); err != nil {
+		return nil, err
+	}
+	chunk := &Chunk{ID: hit.ID, Index: hit.Index}
+	if text, ok := source[textField].(string); ok {
+		chunk.Text = text
+	}
+	delete(source, textField)
+	chunk.Metadata = source
+	return chunk, nil
+}
@@ -0,0 +1,73 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRetrieveChunks(t *testing.T) {
+	var queries []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		for i := 0; scanner.Scan(); i++ {
+			if i%2 == 1 {
+				var q map[string]interface{}
+				json.Unmarshal(scanner.Bytes(), &q)
+				queries = append(queries, q)
+			}
+		}
+		w.Write([]byte(`{"responses":[
+			{"status":200,"hits":{"hits":[
+				{"_id":"c1","_index":"kb","_score":7.1,"_source":{"content":"alpha","doc":"manual"}},
+				{"_id":"c2","_index":"kb","_score":5.0,"_source":{"content":"beta","doc":"faq"}}
+			]}},
+			{"status":200,"hits":{"hits":[
+				{"_id":"c3","_index":"kb","_score":0.93,"_source":{"content":"gamma","doc":"blog"}},
+				{"_id":"c2","_index":"kb","_score":0.91,"_source":{"content":"beta","doc":"faq"}}
+			]}}
+		]}`))
+	})
+
+	embedder := func(ctx context.Context, text string) ([]float64, error) {
+		return []float64{0.5, 0.5}, nil
+	}
+	chunks, err := client.RetrieveChunks(context.Background(), "kb", "how to deploy", embedder, 2,
+		[]FilterQuery{Term("lang", "en")}, WithChunkFields("content", "embedding"), WithRRF(10, 20))
+	if err != nil {
+		t.Fatalf("RetrieveChunks() error = %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("queries = %v", queries)
+	}
+	must := queries[0]["query"].(map[string]interface{})["bool"].(map[string]interface{})["must"].([]interface{})
+	if _, ok := must[0].(map[string]interface{})["match"].(map[string]interface{})["content"]; !ok || queries[0]["size"] != float64(20) {
+		t.Errorf("text query = %v", queries[0])
+	}
+	knn := queries[1]["knn"].(map[string]interface{})
+	if knn["field"] != "embedding" || knn["k"] != float64(20) || len(knn["filter"].([]interface{})) != 1 {
+		t.Errorf("knn query = %v", queries[1])
+	}
+
+	// c2 在两路检索中均命中，融合后排名第一
+	if len(chunks) != 2 || chunks[0].ID != "c2" || chunks[1].ID != "c1" {
+		t.Fatalf("chunks = %+v", chunks)
+	}
+	if c := chunks[0]; c.TextRank != 2 || c.VectorRank != 2 || c.Score != 2.0/12 {
+		t.Errorf("chunks[0] = %+v", c)
+	}
+	if c := chunks[1]; c.Text != "alpha" || c.Metadata["doc"] != "manual" || c.Metadata["content"] != nil || c.VectorRank != 0 {
+		t.Errorf("chunks[1] = %+v", c)
+	}
+
+	failing := func(ctx context.Context, text string) ([]float64, error) {
+		return nil, errors.New("model unavailable")
+	}
+	if _, err := client.RetrieveChunks(context.Background(), "kb", "q", failing, 2, nil); err == nil {
+		t.Error("RetrieveChunks() should fail when the embedder fails")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefreshPolicy 写入后的刷新策略，决定写入的文档何时对搜索可见
+type RefreshPolicy string
+
+const (
+	RefreshTrue    RefreshPolicy = "true"     // 写入后立即刷新相关分片，文档立即可见，但会显著降低写入吞吐
+	RefreshWaitFor RefreshPolicy = "wait_for" // 等待下一次周期刷新后返回，文档可见且不额外触发刷新
+	RefreshFalse   RefreshPolicy = "false"    // 不等待刷新，文档在下一次周期刷新后可见（吞吐最高）
+)
+
+// DefaultRefreshPolicy 未配置时的默认刷新策略，与早期版本的行为保持一致
+const DefaultRefreshPolicy = RefreshTrue
+
+// refreshPolicyKey 单次请求刷新策略的上下文键
+type refreshPolicyKey struct{}
+
+// WithRefresh 覆盖使用该上下文的 Index、Delete、Update 和 Bulk 等写入操作的刷新策略，
+// 单次写入的 WithRefreshPolicy 等选项优先；取值不受支持时写入返回错误
+func WithRefresh(ctx context.Context, policy RefreshPolicy) context.Context {
+	return context.WithValue(ctx, refreshPolicyKey{}, policy)
+}
+
+// WithRefreshPolicy 设置单次索引的刷新策略，优先于 WithRefresh 和客户端默认值
+func WithRefreshPolicy(policy RefreshPolicy) IndexOption {
+	return func(cfg *indexConfig) {
+		cfg.refresh = policy
+	}
+}
+
+// WithUpdateRefreshPolicy 设置单次更新的刷新策略，优先于 WithRefresh 和客户端默认值
+func WithUpdateRefreshPolicy(policy RefreshPolicy) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.refresh = policy
+	}
+}
+
+// WithDeleteRefreshPolicy 设置单次删除的刷新策略，优先于 WithRefresh 和客户端默认值
+func WithDeleteRefreshPolicy(policy RefreshPolicy) DeleteOption {
+	return func(cfg *deleteConfig) {
+		cfg.refresh = policy
+	}
+}
+
+// refreshPolicy 返回写入请求的 refresh 参数，优先级依次为单次写入的选项、上下文设置和客户端默认值；
+// 取值不受支持时返回错误，避免无效参数发送到服务端
+func (c *ElasticsearchClient) refreshPolicy(ctx context.Context, policy RefreshPolicy) (string, error) {
+	if policy == "" {
+		policy, _ = ctx.Value(refreshPolicyKey{}).(RefreshPolicy)
+	}
+	if !policy.valid() {
+		return "", fmt.Errorf("invalid refresh policy %q", policy)
+	}
+	if policy == "" {
+		policy = c.refresh
+	}
+	if policy == "" {
+		policy = DefaultRefreshPolicy
+	}
+	return string(policy), nil
+}
+
+// valid 判断刷新策略是否为支持的取值，空值表示使用默认值
+func (p RefreshPolicy) valid() bool {
+	switch p {
+	case "", RefreshTrue, RefreshWaitFor, RefreshFalse:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRefreshPolicy(t *testing.T) {
+	var got []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("refresh"))
+		w.Write([]byte(`{"_id":"1","result":"updated","items":[]}`))
+	}
+	ctx := context.Background()
+	doc := map[string]interface{}{"status": "paid"}
+
+	client := newTestClient(t, handler)
+	client.Index(ctx, "orders", "1", doc)
+	client.Index(WithRefresh(ctx, RefreshWaitFor), "orders", "1", doc)
+
+	async := newTestClient(t, handler, func(o *Options) {
+		o.Refresh = RefreshFalse
+	})
+	async.Index(ctx, "orders", "1", doc)
+	async.Update(ctx, "orders", "1", doc)
+	async.Delete(ctx, "orders", "1")
+	async.Bulk(ctx, "{\"delete\":{\"_index\":\"orders\",\"_id\":\"1\"}}\n")
+	async.Index(WithRefresh(ctx, RefreshTrue), "orders", "1", doc)
+
+	want := []string{"true", "wait_for", "false", "false", "false", "false", "true"}
+	if len(got) != len(want) {
+		t.Fatalf("refresh params = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d refresh = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if err := (&Options{Addresses: []string{"http://localhost:9200"}, Refresh: "sometimes"}).Validate(); err == nil {
+		t.Error("Validate() should reject unknown refresh policies")
+	}
+}
+
+func TestRefreshPolicyOptions(t *testing.T) {
+	var got []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("refresh"))
+		w.Write([]byte(`{"_id":"1","result":"updated"}`))
+	})
+	ctx := WithRefresh(context.Background(), RefreshTrue)
+	doc := map[string]interface{}{"status": "paid"}
+
+	client.Index(ctx, "orders", "1", doc, WithRefreshPolicy(RefreshWaitFor))
+	client.Update(ctx, "orders", "1", doc, WithUpdateRefreshPolicy(RefreshFalse))
+	client.Delete(ctx, "orders", "1", WithDeleteRefreshPolicy(RefreshWaitFor))
+
+	want := []string{"wait_for", "false", "wait_for"}
+	if len(got) != len(want) {
+		t.Fatalf("refresh params = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d refresh = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if err := client.Index(context.Background(), "orders", "1", doc, WithRefreshPolicy("yes")); err == nil {
+		t.Error("Index() should reject an invalid refresh option")
+	}
+	if err := client.Delete(WithRefresh(context.Background(), "yes"), "orders", "1"); err == nil {
+		t.Error("Delete() should reject an invalid refresh policy in the context")
+	}
+	if len(got) != len(want) {
+		t.Errorf("invalid refresh policies should not be sent, requests = %v", got)
+	}
+}
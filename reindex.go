@@ -0,0 +1,197 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ReindexRemote 从远程集群重建索引时的连接配置，远程地址需加入目标集群的 reindex.remote.whitelist
+type ReindexRemote struct {
+	Host           string            // 远程集群地址，如 https://old-cluster:9200
+	Username       string            // 用户名
+	Password       string            // 密码
+	Headers        map[string]string // 附加请求头（如 Authorization）
+	SocketTimeout  time.Duration     // 读取超时，默认 30s
+	ConnectTimeout time.Duration     // 连接超时，默认 30s
+}
+
+// body 转换为请求体中的 remote 字段
+func (r *ReindexRemote) body() map[string]interface{} {
+	remote := map[string]interface{}{"host": r.Host}
+	if r.Username != "" {
+		remote["username"] = r.Username
+		remote["password"] = r.Password
+	}
+	if len(r.Headers) > 0 {
+		remote["headers"] = r.Headers
+	}
+	if r.SocketTimeout > 0 {
+		remote["socket_timeout"] = fmt.Sprintf("%ds", int64(r.SocketTimeout/time.Second))
+	}
+	if r.ConnectTimeout > 0 {
+		remote["connect_timeout"] = fmt.Sprintf("%ds", int64(r.ConnectTimeout/time.Second))
+	}
+	return remote
+}
+
+// ReindexOptions 重建索引选项，零值字段使用服务端默认值
+type ReindexOptions struct {
+	Query             map[string]interface{} // 只复制匹配的源文档（query 子句内容）
+	SourceFields      []string               // 只复制的源字段
+	Script            map[string]interface{} // 复制时转换文档的 painless 脚本
+	Pipeline          string                 // 写入目标索引时使用的 ingest pipeline
+	Remote            *ReindexRemote         // 从远程集群复制
+	Slices            int                    // 并行切片数，默认 auto（远程复制不支持切片）
+	BatchSize         int                    // 每批读取的文档数，默认 1000
+	MaxDocs           int                    // 最多复制的文档数
+	OpType            string                 // 设为 create 时只写入目标索引中不存在的文档
+	ProceedOnConflict bool                   // 版本冲突时继续而不是中止
+	RequestsPerSecond int                    // 限速（每秒请求数）
+}
+
+// Reindex 以后台任务方式将 source 索引的文档复制到 dest 索引（wait_for_completion=false），返回任务 ID，
+// 可通过 WaitForTask 等待完成。用于索引版本迁移，配合 SwapAlias 实现零停机切换
+func (c *ElasticsearchClient) Reindex(ctx context.Context, source string, dest string, opts *ReindexOptions) (string, error) {
+	if err := c.ready(); err != nil {
+		return "", err
+	}
+	if opts == nil {
+		opts = &ReindexOptions{}
+	}
+
+	source = c.resolveIndexName(ctx, source)
+	dest = c.resolveIndexName(ctx, dest)
+	if err := ValidateIndexName(dest); err != nil {
+		return "", err
+	}
+
+	body, err := c.reindexBody(ctx, source, dest, opts)
+	if err != nil {
+		return "", err
+	}
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reindex body: %w", err)
+	}
+
+	wait := false
+	req := esapi.ReindexRequest{
+		Body:              strings.NewReader(string(bodyBytes)),
+		WaitForCompletion: &wait,
+	}
+	switch {
+	case opts.Remote != nil:
+		if opts.Slices > 1 {
+			return "", fmt.Errorf("reindex from remote does not support slices")
+		}
+	case opts.Slices > 0:
+		req.Slices = opts.Slices
+	default:
+		req.Slices = "auto"
+	}
+	if opts.MaxDocs > 0 {
+		req.MaxDocs = &opts.MaxDocs
+	}
+	if opts.RequestsPerSecond > 0 {
+		req.RequestsPerSecond = &opts.RequestsPerSecond
+	}
+
+	var taskID string
+	err = executeWithTrace(
+		ctx,
+		"reindex",
+		dest,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			res, err := req.Do(ctx, c.client)
+			if err != nil {
+				return c.requestError("reindex", err)
+			}
+			defer res.Body.Close()
+
+			if res.IsError() {
+				return c.responseError("reindex", res)
+			}
+
+			var result struct {
+				Task string `json:"task"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			taskID = result.Task
+			return nil
+		},
+	)
+	return taskID, err
+}
+
+// reindexBody 构建重建索引的请求体
+func (c *ElasticsearchClient) reindexBody(ctx context.Context, source string, dest string, opts *ReindexOptions) (map[string]interface{}, error) {
+	src := map[string]interface{}{"index": source}
+
+	query := map[string]interface{}{}
+	if opts.Query != nil {
+		query["query"] = opts.Query
+	}
+	query, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if q, ok := query["query"]; ok {
+		src["query"] = q
+	}
+	if err := c.limits.checkQuery(query); err != nil {
+		return nil, err
+	}
+
+	if len(opts.SourceFields) > 0 {
+		src["_source"] = opts.SourceFields
+	}
+	if opts.BatchSize > 0 {
+		src["size"] = opts.BatchSize
+	}
+	if opts.Remote != nil {
+		src["remote"] = opts.Remote.body()
+	}
+
+	dst := map[string]interface{}{"index": dest}
+	if opts.Pipeline != "" {
+		dst["pipeline"] = opts.Pipeline
+	}
+	if opts.OpType != "" {
+		dst["op_type"] = opts.OpType
+	}
+
+	body := map[string]interface{}{"source": src, "dest": dst}
+	if opts.Script != nil {
+		body["script"] = opts.Script
+	}
+	if opts.ProceedOnConflict {
+		body["conflicts"] = "proceed"
+	}
+	return body, nil
+}
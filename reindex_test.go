@@ -0,0 +1,104 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReindex(t *testing.T) {
+	var got map[string]interface{}
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_reindex" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"task":"node-1:42"}`))
+	})
+	ctx := context.Background()
+
+	taskID, err := client.Reindex(ctx, "orders_v1", "orders_v2", &ReindexOptions{
+		Query:             Term("status", "paid").Source(),
+		Script:            map[string]interface{}{"source": "ctx._source.migrated = true", "lang": "painless"},
+		BatchSize:         500,
+		OpType:            "create",
+		ProceedOnConflict: true,
+	})
+	if err != nil || taskID != "node-1:42" {
+		t.Fatalf("Reindex() = %s, %v", taskID, err)
+	}
+	if !strings.Contains(query, "wait_for_completion=false") || !strings.Contains(query, "slices=auto") {
+		t.Errorf("query = %s", query)
+	}
+	source := got["source"].(map[string]interface{})
+	dest := got["dest"].(map[string]interface{})
+	if source["index"] != "orders_v1" || source["size"] != float64(500) || source["query"] == nil {
+		t.Errorf("source = %v", source)
+	}
+	if dest["index"] != "orders_v2" || dest["op_type"] != "create" || got["conflicts"] != "proceed" || got["script"] == nil {
+		t.Errorf("body = %v", got)
+	}
+
+	_, err = client.Reindex(ctx, "orders", "orders_v2", &ReindexOptions{
+		Remote: &ReindexRemote{Host: "https://old:9200", Username: "elastic", Password: "secret", SocketTimeout: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("Reindex(remote) error = %v", err)
+	}
+	remote := got["source"].(map[string]interface{})["remote"].(map[string]interface{})
+	if remote["host"] != "https://old:9200" || remote["username"] != "elastic" || remote["socket_timeout"] != "60s" {
+		t.Errorf("remote = %v", remote)
+	}
+	if strings.Contains(query, "slices") {
+		t.Errorf("remote reindex query = %s, should not use slices", query)
+	}
+	if _, err := client.Reindex(ctx, "orders", "orders_v2", &ReindexOptions{Remote: &ReindexRemote{Host: "h"}, Slices: 4}); err == nil {
+		t.Error("Reindex() from remote with slices should fail")
+	}
+}
+
+func TestWaitForTask(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_tasks/node-1:42":
+			calls++
+			if calls == 1 {
+				w.Write([]byte(`{"completed":false,"task":{"action":"indices:data/write/reindex","running_time_in_nanos":1000000000,"status":{"total":10,"created":4}}}`))
+				return
+			}
+			w.Write([]byte(`{"completed":true,"task":{"action":"indices:data/write/reindex","status":{"total":10,"created":10}},"response":{"total":10,"created":9,"version_conflicts":1,"failures":[]}}`))
+		case "/_tasks/node-1:43":
+			w.Write([]byte(`{"completed":true,"task":{"status":{"total":10}},"error":{"type":"search_phase_execution_exception","reason":"all shards failed"}}`))
+		default:
+			w.Write([]byte(`{"completed":true,"task":{"status":{"total":2}},"response":{"total":2,"created":1,"failures":[{"id":"x","cause":{"type":"mapper_parsing_exception"}}]}}`))
+		}
+	})
+	ctx := context.Background()
+
+	status, err := client.GetTask(ctx, "node-1:42")
+	if err != nil || status.Completed || status.Progress() != 0.4 || status.Running != time.Second {
+		t.Fatalf("GetTask() = %+v, %v", status, err)
+	}
+	calls = 0
+
+	status, err = client.WaitForTask(ctx, "node-1:42", 10*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForTask() error = %v", err)
+	}
+	if !status.Completed || status.Created != 9 || status.VersionConflicts != 1 || status.Progress() != 1 || calls != 2 {
+		t.Errorf("WaitForTask() = %+v after %d calls", status, calls)
+	}
+
+	if _, err := client.WaitForTask(ctx, "node-1:43", time.Second); err == nil || !strings.Contains(err.Error(), "all shards failed") {
+		t.Errorf("WaitForTask() error = %v, want task error", err)
+	}
+	if status, err := client.WaitForTask(ctx, "node-1:44", time.Second); err == nil || status.Failures != 1 {
+		t.Errorf("WaitForTask() = %+v, %v, want failures", status, err)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// Report 定时执行的保存查询（通常为聚合查询）
+type Report struct {
+	Name        string                 // 报表名称，在调度器内唯一
+	Index       string                 // 查询的索引
+	Query       map[string]interface{} // 查询体，通常设置 size 为 0 并包含 aggs
+	Schedule    Schedule               // 执行计划，如 Every(time.Hour) 或 ParseCron("0 8 * * *")
+	Timeout     time.Duration          // 单次执行的超时，0 表示不限制
+	ReportIndex string                 // 设置后将每次的结果写入该索引，聚合结果以 JSON 字符串存储在 aggregations 字段
+	Deliver     ReportHandler          // 每次执行后的回调，执行失败时也会调用
+}
+
+// ReportResult 报表的一次执行结果
+type ReportResult struct {
+	Name   string
+	RunAt  time.Time
+	Result *SearchResult // 执行失败时为 nil
+	Err    error
+}
+
+// ReportHandler 接收报表执行结果的回调
+type ReportHandler func(ctx context.Context, result ReportResult)
+
+// ReportScheduler 按计划定期执行已注册的报表查询，将结果交给回调或写入报表索引，
+// 无需外部调度系统即可生成周期性的业务指标。客户端关闭时自动停止
+type ReportScheduler struct {
+	client *ElasticsearchClient
+
+	mu      sync.Mutex
+	reports map[string]*Report
+	started bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewReportScheduler 创建报表调度器，注册报表后调用 Start 开始调度
+func (c *ElasticsearchClient) NewReportScheduler() *ReportScheduler {
+	return &ReportScheduler{
+		client:  c,
+		reports: make(map[string]*Report),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Register 注册报表，调度器启动后注册的报表立即开始调度
+func (s *ReportScheduler) Register(report Report) error {
+	if report.Name == "" || report.Index == "" || report.Schedule == nil {
+		return fmt.Errorf("report requires Name, Index and Schedule")
+	}
+	if err := validateSchedule(report.Schedule); err != nil {
+		return fmt.Errorf("report %s: %w", report.Name, err)
+	}
+	if report.Deliver == nil && report.ReportIndex == "" {
+		return fmt.Errorf("report %s requires Deliver or ReportIndex", report.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.reports[report.Name]; ok {
+		return fmt.Errorf("report %s is already registered", report.Name)
+	}
+	r := &report
+	s.reports[report.Name] = r
+	if s.started {
+		s.schedule(r)
+	}
+	return nil
+}
+
+// Start 开始调度所有已注册的报表
+func (s *ReportScheduler) Start() error {
+	if err := s.client.ready(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("report scheduler already started")
+	}
+	if err := s.client.lifecycle.track("report scheduler", s.Stop); err != nil {
+		return err
+	}
+	s.started = true
+	for _, r := range s.reports {
+		s.schedule(r)
+	}
+	return nil
+}
+
+// schedule 启动单个报表的调度循环，调用方持有锁
+func (s *ReportScheduler) schedule(r *Report) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		runSchedule(r.Schedule, s.stop, func(at time.Time) {
+			s.run(context.Background(), r, at)
+		})
+	}()
+}
+
+// Stop 停止调度并等待进行中的报表执行结束，ctx 到期时不再等待。可重复调用
+func (s *ReportScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunNow 立即执行一次报表并返回结果，同时按报表配置投递结果
+func (s *ReportScheduler) RunNow(ctx context.Context, name string) (ReportResult, error) {
+	s.mu.Lock()
+	r, ok := s.reports[name]
+	s.mu.Unlock()
+	if !ok {
+		return ReportResult{}, fmt.Errorf("report %s is not registered", name)
+	}
+	return s.run(ctx, r, time.Now()), nil
+}
+
+// store 将执行结果写入报表索引。聚合结构因报表而异，序列化为字符串存储，
+// 避免动态映射在不同报表之间产生字段类型冲突
+func (s *ReportScheduler) store(ctx context.Context, r *Report, at time.Time, result *SearchResult) error {
+	aggs, err := json.Marshal(result.Aggregations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report aggregations: %w", err)
+	}
+	doc := map[string]interface{}{
+		"report":       r.Name,
+		"index":        r.Index,
+		"run_at":       at.UTC().Format(time.RFC3339Nano),
+		"total":        result.Total,
+		"aggregations": string(aggs),
+	}
+	return s.client.Index(ctx, r.ReportIndex, "", doc)
+}
+
+// run 执行报表并投递结果
+func (s *ReportScheduler) run(ctx context.Context, r *Report, at time.Time) ReportResult {
+	ctx = WithOperationTag(ctx, "report", r.Name)
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	result := ReportResult{Name: r.Name, RunAt: at}
+	result.Result, result.Err = s.client.SearchTyped(ctx, r.Index, r.Query)
+
+	if r.ReportIndex != "" && result.Err == nil {
+		if err := s.store(ctx, r, at, result.Result); err != nil {
+			log.FromContext(ctx).Warn("Failed to store Elasticsearch report result",
+				zap.String("report", r.Name), zap.String("report_index", r.ReportIndex), zap.Error(err))
+		}
+	}
+	if r.Deliver != nil {
+		r.Deliver(ctx, result)
+	} else if result.Err != nil {
+		log.FromContext(ctx).Warn("Elasticsearch report failed", zap.String("report", r.Name), zap.Error(result.Err))
+	}
+	return result
+}
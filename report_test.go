@@ -0,0 +1,91 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportScheduler(t *testing.T) {
+	stored := make(chan map[string]interface{}, 10)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/orders/_search"):
+			w.Write([]byte(`{"hits":{"total":{"value":12},"hits":[]},"aggregations":{"revenue":{"value":340.5}}}`))
+		case r.URL.Path == "/reports/_doc":
+			var doc map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &doc)
+			stored <- doc
+			w.Write([]byte(`{"_id":"r1","result":"created"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	delivered := make(chan ReportResult, 10)
+	scheduler := client.NewReportScheduler()
+	err := scheduler.Register(Report{
+		Name:        "daily_revenue",
+		Index:       "orders",
+		Query:       map[string]interface{}{"size": 0, "aggs": AggregationsBody(map[string]Aggregation{"revenue": SumAgg("amount")})},
+		Schedule:    Every(20 * time.Millisecond),
+		ReportIndex: "reports",
+		Deliver:     func(_ context.Context, result ReportResult) { delivered <- result },
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := scheduler.Register(Report{Name: "daily_revenue", Index: "orders", Schedule: Every(time.Hour), ReportIndex: "reports"}); err == nil {
+		t.Error("Register() should reject duplicated names")
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := scheduler.Register(Report{Name: "spin", Index: "orders", Schedule: Every(interval), ReportIndex: "reports"}); err == nil {
+			t.Errorf("Register() should reject interval %s", interval)
+		}
+	}
+
+	result, err := scheduler.RunNow(context.Background(), "daily_revenue")
+	if err != nil || result.Err != nil || result.Result.Total != 12 {
+		t.Fatalf("RunNow() = %+v, %v", result, err)
+	}
+	var revenue struct {
+		Value float64 `json:"value"`
+	}
+	if err := result.Result.Aggregations.Decode("revenue", &revenue); err != nil || revenue.Value != 340.5 {
+		t.Errorf("revenue = %v, %v", revenue.Value, err)
+	}
+	doc := <-stored
+	if doc["report"] != "daily_revenue" || doc["total"] != float64(12) {
+		t.Errorf("stored report = %v", doc)
+	}
+	if aggs, _ := doc["aggregations"].(string); aggs != `{"revenue":{"value":340.5}}` {
+		t.Errorf("stored aggregations = %#v, want JSON string", doc["aggregations"])
+	}
+	<-delivered
+
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	select {
+	case result := <-delivered:
+		if result.Err != nil {
+			t.Errorf("scheduled run error = %v", result.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("scheduled report did not run")
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case <-scheduler.stop:
+	default:
+		t.Error("Close() should stop the report scheduler")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// IndexResolver 根据上下文（如租户、地区）将逻辑索引名解析为物理索引名。
+// 对于不需要转换的名称应原样返回；客户端的每个方法对传入的索引名只解析一次
+type IndexResolver func(ctx context.Context, logicalName string) string
+
+// resolveIndex 解析单个索引名，返回可直接用于请求路径的名称（日期数学表达式已编码）
+func (c *ElasticsearchClient) resolveIndex(ctx context.Context, index string) string {
+	return escapeIndexPath(c.resolveIndexName(ctx, index))
+}
+
+// resolveIndexName 解析单个索引名，不做路径编码，用于放入请求体
+func (c *ElasticsearchClient) resolveIndexName(ctx context.Context, index string) string {
+	if c.indexResolver == nil || index == "" {
+		return index
+	}
+	return c.indexResolver(ctx, index)
+}
+
+// resolveIndices 解析索引名列表
+func (c *ElasticsearchClient) resolveIndices(ctx context.Context, indices []string) []string {
+	resolved := make([]string, len(indices))
+	for i, index := range indices {
+		resolved[i] = c.resolveIndex(ctx, index)
+	}
+	return resolved
+}
+
+// DateMathIndex 构建日期数学索引名表达式，如 DateMathIndex("logs-", "now/d", "yyyy.MM.dd")
+// 返回 "<logs-{now/d{yyyy.MM.dd}}>"；format 为空时使用服务端默认格式
+func DateMathIndex(prefix string, dateMath string, format string) string {
+	if format != "" {
+		dateMath += "{" + format + "}"
+	}
+	return "<" + prefix + "{" + dateMath + "}>"
+}
+
+// isDateMathIndex 判断索引名是否为日期数学表达式
+func isDateMathIndex(index string) bool {
+	return strings.HasPrefix(index, "<") && strings.HasSuffix(index, ">")
+}
+
+// escapeIndexPath 对索引表达式（可为逗号分隔的列表）中的日期数学部分进行路径编码。
+// 表达式中的 "/"、"{"、"<" 等字符必须编码，否则会被解析为路径分隔符或导致服务端返回 400
+func escapeIndexPath(index string) string {
+	if !strings.Contains(index, "<") {
+		return index
+	}
+	parts := strings.Split(index, ",")
+	for i, part := range parts {
+		if isDateMathIndex(part) {
+			parts[i] = url.PathEscape(part)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,92 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type regionKey struct{}
+
+func TestIndexResolver(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_id":"1","found":true,"_source":{},"hits":{"hits":[]},"count":3}`))
+	}, func(opts *Options) {
+		opts.IndexResolver = func(ctx context.Context, logicalName string) string {
+			if region, ok := ctx.Value(regionKey{}).(string); ok {
+				return logicalName + "-" + region
+			}
+			return logicalName
+		}
+	})
+
+	ctx := context.WithValue(context.Background(), regionKey{}, "eu")
+	if err := client.Index(ctx, "orders", "1", map[string]interface{}{}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if _, err := client.Get(ctx, "orders", "1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := client.Search(ctx, "orders", nil); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if _, err := client.Count(context.Background(), "orders", nil); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+
+	want := []string{
+		"PUT /orders-eu/_doc/1",
+		"GET /orders-eu/_doc/1",
+		"POST /orders-eu/_search",
+		"POST /orders/_count",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestDateMathIndex(t *testing.T) {
+	if got, want := DateMathIndex("logs-", "now/d", ""), "<logs-{now/d}>"; got != want {
+		t.Errorf("DateMathIndex() = %s, want %s", got, want)
+	}
+	if got, want := DateMathIndex("logs-", "now/M", "yyyy.MM"), "<logs-{now/M{yyyy.MM}}>"; got != want {
+		t.Errorf("DateMathIndex() = %s, want %s", got, want)
+	}
+}
+
+func TestDateMathIndexEncoding(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]},"count":0}`))
+	})
+
+	if _, err := client.Search(context.Background(), "<logs-{now/d}>", nil); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if _, err := client.Count(context.Background(), "<logs-{now/d}>,<logs-{now/d-1d}>,archive", nil); err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+
+	want := []string{
+		"/%3Clogs-%7Bnow%2Fd%7D%3E/_search",
+		"/%3Clogs-%7Bnow%2Fd%7D%3E,%3Clogs-%7Bnow%2Fd-1d%7D%3E,archive/_count",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+}
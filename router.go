@@ -0,0 +1,198 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultRouterVirtualNodes 每个目标在哈希环上的默认虚拟节点数
+const DefaultRouterVirtualNodes = 128
+
+// RouterOption 文档路由器选项
+type RouterOption func(*Router)
+
+// WithVirtualNodes 设置每个目标的虚拟节点数，越多分布越均匀，内存占用也越大
+func WithVirtualNodes(n int) RouterOption {
+	return func(r *Router) {
+		if n > 0 {
+			r.virtualNodes = n
+		}
+	}
+}
+
+// KeyMove 目标变化时需要迁移的文档键
+type KeyMove struct {
+	Key  string
+	From string
+	To   string
+}
+
+// Router 使用一致性哈希将文档键映射到一组索引（或路由值）之一，用于手动分片超大数据集。
+// 增删目标时只有约 1/N 的键会改变归属，可通过 Rebalance 预先计算需要迁移的键。并发安全
+type Router struct {
+	virtualNodes int
+
+	mu      sync.RWMutex
+	targets []string
+	ring    []routerNode // 按哈希值排序
+}
+
+// routerNode 哈希环上的虚拟节点
+type routerNode struct {
+	hash   uint64
+	target string
+}
+
+// NewRouter 创建文档路由器，targets 为索引名或路由值，不能为空或重复
+func NewRouter(targets []string, opts ...RouterOption) (*Router, error) {
+	r := &Router{virtualNodes: DefaultRouterVirtualNodes}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if err := r.SetTargets(targets); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Route 返回键所属的目标
+func (r *Router) Route(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return lookupRing(r.ring, key)
+}
+
+// Targets 返回当前的目标列表
+func (r *Router) Targets() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.targets...)
+}
+
+// SetTargets 替换目标列表
+func (r *Router) SetTargets(targets []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.setTargetsLocked(targets)
+}
+
+// AddTarget 添加目标，已存在时返回错误
+func (r *Router) AddTarget(target string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	targets := append([]string(nil), r.targets...)
+	return r.setTargetsLocked(append(targets, target))
+}
+
+// RemoveTarget 移除目标，不存在或移除后为空时返回错误
+func (r *Router) RemoveTarget(target string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, t := range r.targets {
+		if t == target {
+			targets := append([]string(nil), r.targets[:i]...)
+			return r.setTargetsLocked(append(targets, r.targets[i+1:]...))
+		}
+	}
+	return fmt.Errorf("router target %s does not exist", target)
+}
+
+// setTargetsLocked 重建哈希环并替换目标列表，调用方需持有写锁
+func (r *Router) setTargetsLocked(targets []string) error {
+	ring, err := buildRing(targets, r.virtualNodes)
+	if err != nil {
+		return err
+	}
+	r.targets = append([]string(nil), targets...)
+	r.ring = ring
+	return nil
+}
+
+// Rebalance 计算目标列表变为 targets 后需要迁移的键，不修改路由器。
+// 迁移完成后调用 SetTargets 切换
+func (r *Router) Rebalance(keys []string, targets []string) ([]KeyMove, error) {
+	next, err := buildRing(targets, r.virtualNodes)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var moves []KeyMove
+	for _, key := range keys {
+		from, to := lookupRing(r.ring, key), lookupRing(next, key)
+		if from != to {
+			moves = append(moves, KeyMove{Key: key, From: from, To: to})
+		}
+	}
+	return moves, nil
+}
+
+// buildRing 构建哈希环
+func buildRing(targets []string, virtualNodes int) ([]routerNode, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("router targets cannot be empty")
+	}
+	seen := make(map[string]struct{}, len(targets))
+	ring := make([]routerNode, 0, len(targets)*virtualNodes)
+	for _, target := range targets {
+		if target == "" {
+			return nil, fmt.Errorf("router target cannot be empty")
+		}
+		if _, ok := seen[target]; ok {
+			return nil, fmt.Errorf("router target %s is duplicated", target)
+		}
+		seen[target] = struct{}{}
+		for i := 0; i < virtualNodes; i++ {
+			ring = append(ring, routerNode{hash: routerHash(target + "#" + strconv.Itoa(i)), target: target})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash != ring[j].hash {
+			return ring[i].hash < ring[j].hash
+		}
+		return ring[i].target < ring[j].target
+	})
+	return ring, nil
+}
+
+// lookupRing 返回哈希环上顺时针方向第一个虚拟节点的目标
+func lookupRing(ring []routerNode, key string) string {
+	h := routerHash(key)
+	i := sort.Search(len(ring), func(i int) bool { return ring[i].hash >= h })
+	if i == len(ring) {
+		i = 0
+	}
+	return ring[i].target
+}
+
+// routerHash 计算键的哈希值
+func routerHash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	// FNV 对相近输入的高位分布较差，混合后再使用
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,80 @@
+package elasticsearch
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	router, err := NewRouter([]string{"events-0", "events-1", "events-2"})
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	keys := make([]string, 3000)
+	counts := map[string]int{}
+	for i := range keys {
+		keys[i] = "user-" + strconv.Itoa(i)
+		counts[router.Route(keys[i])]++
+	}
+	for target, n := range counts {
+		if n < 700 || n > 1300 {
+			t.Errorf("target %s got %d keys, want roughly even distribution", target, n)
+		}
+	}
+	if router.Route("user-42") != router.Route("user-42") {
+		t.Error("Route() should be deterministic")
+	}
+
+	moves, err := router.Rebalance(keys, []string{"events-0", "events-1", "events-2", "events-3"})
+	if err != nil {
+		t.Fatalf("Rebalance() error = %v", err)
+	}
+	if len(moves) < 450 || len(moves) > 1050 {
+		t.Errorf("Rebalance() moved %d keys, want about a quarter", len(moves))
+	}
+	for _, move := range moves {
+		if move.To != "events-3" {
+			t.Fatalf("key %s moved from %s to %s, want only moves to the new target", move.Key, move.From, move.To)
+		}
+	}
+
+	if err := router.AddTarget("events-3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := router.Route(moves[0].Key); got != "events-3" {
+		t.Errorf("Route(%s) = %s after AddTarget, want events-3", moves[0].Key, got)
+	}
+	if err := router.RemoveTarget("events-3"); err != nil || router.Route(moves[0].Key) != moves[0].From {
+		t.Errorf("RemoveTarget() error = %v, key should route back to %s", err, moves[0].From)
+	}
+
+	if _, err := NewRouter([]string{"a", "a"}); err == nil {
+		t.Error("NewRouter() should reject duplicated targets")
+	}
+	if _, err := NewRouter(nil); err == nil {
+		t.Error("NewRouter() should reject empty targets")
+	}
+}
+
+func TestRouterConcurrentAddTarget(t *testing.T) {
+	router, err := NewRouter([]string{"base"})
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := router.AddTarget("shard-" + strconv.Itoa(i)); err != nil {
+				t.Errorf("AddTarget() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if got := len(router.Targets()); got != 21 {
+		t.Errorf("len(Targets()) = %d, want 21", got)
+	}
+}
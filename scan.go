@@ -0,0 +1,334 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// MergeMode 并行切片扫描结果的合并方式
+type MergeMode int
+
+const (
+	// MergeUnordered 按到达顺序交付各切片的批次，吞吐量最高
+	MergeUnordered MergeMode = iota
+	// MergeOrdered 按切片编号依次交付（切片 0 全部交付后再交付切片 1），
+	// 结果顺序与串行扫描各切片一致，后续切片在缓冲区满时会等待
+	MergeOrdered
+)
+
+// ScanOptions 全量扫描选项
+type ScanOptions struct {
+	BatchSize int           // 每批返回的文档数，默认 1000
+	KeepAlive time.Duration // scroll 上下文保持时间，默认 1 分钟
+	Slices    int           // 并行切片数，小于等于 1 时串行扫描
+	Merge     MergeMode     // 多切片结果的合并方式
+}
+
+// scanBatch 单个切片返回的一批命中结果
+type scanBatch struct {
+	hits []map[string]interface{}
+	err  error
+}
+
+// withDefaults 返回填充默认值后的扫描选项
+func (o *ScanOptions) withDefaults() ScanOptions {
+	opts := ScanOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = time.Minute
+	}
+	if opts.Slices < 1 {
+		opts.Slices = 1
+	}
+	return opts
+}
+
+// ScanAll 使用 scroll 遍历查询匹配的全部文档（自动处理追踪）。
+// 设置 Slices 后按切片并行扫描；handler 始终在调用方 goroutine 中串行调用，
+// 返回错误时停止扫描并清理所有 scroll 上下文
+func (c *ElasticsearchClient) ScanAll(ctx context.Context, index string, query map[string]interface{}, opts *ScanOptions, handler func(hits []map[string]interface{}) error) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	return executeWithTrace(
+		ctx,
+		"scan_all",
+		index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			return c.scanAll(ctx, index, query, opts.withDefaults(), handler)
+		},
+	)
+}
+
+// Export 将查询匹配的全部文档的 _source 以 NDJSON 格式写入 w
+func (c *ElasticsearchClient) Export(ctx context.Context, index string, query map[string]interface{}, opts *ScanOptions, w io.Writer) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	return c.ScanAll(ctx, index, query, opts, func(hits []map[string]interface{}) error {
+		for _, hit := range hits {
+			if err := encoder.Encode(hit["_source"]); err != nil {
+				return fmt.Errorf("failed to write document: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// scanAll 内部全量扫描方法
+func (c *ElasticsearchClient) scanAll(ctx context.Context, index string, query map[string]interface{}, opts ScanOptions, handler func([]map[string]interface{}) error) error {
+	query, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		return err
+	}
+	// 在打开切片前检查查询限制，超限时不创建任何 scroll 上下文
+	if err := c.limits.checkQuery(query); err != nil {
+		return err
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("failed to marshal query: %w", err)
+	}
+	if err := c.limits.checkBytes(len(queryBytes)); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 每个切片一个通道；无序合并时所有切片共用一个通道
+	channels := make([]chan scanBatch, opts.Slices)
+	var shared chan scanBatch
+	if opts.Merge == MergeUnordered {
+		shared = make(chan scanBatch, opts.Slices)
+	}
+	for i := range channels {
+		if shared != nil {
+			channels[i] = shared
+		} else {
+			channels[i] = make(chan scanBatch, 1)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Slices; i++ {
+		wg.Add(1)
+		go func(slice int) {
+			defer wg.Done()
+			if shared == nil {
+				defer close(channels[slice])
+			}
+			c.scanSlice(ctx, index, query, opts, slice, channels[slice])
+		}(i)
+	}
+	if shared != nil {
+		go func() {
+			wg.Wait()
+			close(shared)
+		}()
+	}
+	// 确保返回前所有切片都已清理 scroll 上下文
+	defer wg.Wait()
+
+	consume := func(ch <-chan scanBatch) error {
+		for batch := range ch {
+			if batch.err != nil {
+				return batch.err
+			}
+			if err := handler(batch.hits); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if shared != nil {
+		if err := consume(shared); err != nil {
+			cancel()
+			return err
+		}
+		return ctx.Err()
+	}
+	for _, ch := range channels {
+		if err := consume(ch); err != nil {
+			cancel()
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// scanSlice 扫描单个切片并将结果批次发送到 out
+func (c *ElasticsearchClient) scanSlice(ctx context.Context, index string, query map[string]interface{}, opts ScanOptions, slice int, out chan<- scanBatch) {
+	send := func(batch scanBatch) bool {
+		select {
+		case out <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	body := make(map[string]interface{}, len(query)+2)
+	for k, v := range query {
+		body[k] = v
+	}
+	if _, ok := body["sort"]; !ok {
+		body["sort"] = []string{"_doc"}
+	}
+	if opts.Slices > 1 {
+		body["slice"] = map[string]interface{}{"id": slice, "max": opts.Slices}
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		send(scanBatch{err: fmt.Errorf("failed to marshal query: %w", err)})
+		return
+	}
+
+	size := opts.BatchSize
+	res, err := esapi.SearchRequest{
+		Index:  []string{index},
+		Body:   strings.NewReader(string(bodyBytes)),
+		Scroll: opts.KeepAlive,
+		Size:   &size,
+
+		AllowPartialSearchResults: c.allowPartialSearchResults(ctx),
+	}.Do(ctx, c.client)
+	if err != nil {
+		send(scanBatch{err: c.requestError("scroll", err)})
+		return
+	}
+
+	var scrollID string
+	defer func() {
+		if scrollID != "" {
+			c.clearScroll(scrollID)
+		}
+	}()
+
+	for {
+		id, hits, err := c.decodeScrollResponse(res)
+		if id != "" {
+			scrollID = id
+		}
+		if err != nil {
+			send(scanBatch{err: err})
+			return
+		}
+		if len(hits) == 0 {
+			return
+		}
+		if c.readTransformer != nil {
+			for _, hit := range hits {
+				if err := c.transformSource(index, hit, false); err != nil {
+					send(scanBatch{err: err})
+					return
+				}
+			}
+		}
+		if !send(scanBatch{hits: hits}) {
+			return
+		}
+
+		res, err = c.scrollNext(ctx, scrollID, opts.KeepAlive)
+		if err != nil {
+			send(scanBatch{err: c.requestError("scroll", err)})
+			return
+		}
+	}
+}
+
+// scrollNext 获取下一批 scroll 结果，scroll ID 放在请求体中以避免超长 URL
+func (c *ElasticsearchClient) scrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (*esapi.Response, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"scroll":    formatKeepAlive(keepAlive),
+		"scroll_id": scrollID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return esapi.ScrollRequest{Body: strings.NewReader(string(body))}.Do(ctx, c.client)
+}
+
+// formatKeepAlive 将时间格式化为 Elasticsearch 的时间单位表示
+func formatKeepAlive(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+	return strconv.FormatInt(int64(d/time.Millisecond), 10) + "ms"
+}
+
+// decodeScrollResponse 解析 scroll 响应，返回 scroll ID 和命中结果
+func (c *ElasticsearchClient) decodeScrollResponse(res *esapi.Response) (string, []map[string]interface{}, error) {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", nil, c.responseError("scroll", res)
+	}
+
+	var result struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Hits []map[string]interface{} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.ScrollID, result.Hits.Hits, nil
+}
+
+// clearScroll 清理 scroll 上下文，使用独立的 context 以便在调用方取消后仍能释放服务端资源
+func (c *ElasticsearchClient) clearScroll(scrollID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]interface{}{"scroll_id": []string{scrollID}})
+	if err != nil {
+		return
+	}
+	res, err := esapi.ClearScrollRequest{Body: strings.NewReader(string(body))}.Do(ctx, c.client)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+}
@@ -0,0 +1,149 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newScrollTestClient 创建模拟 scroll 接口的测试客户端，每个切片返回 pages 批，每批一个文档
+func newScrollTestClient(t *testing.T, pages int) (*ElasticsearchClient, *sync.Map) {
+	t.Helper()
+
+	cleared := &sync.Map{}
+	var mu sync.Mutex
+	progress := map[string]int{}
+
+	page := func(w http.ResponseWriter, scrollID string) {
+		mu.Lock()
+		n := progress[scrollID]
+		progress[scrollID] = n + 1
+		mu.Unlock()
+
+		hits := "[]"
+		if n < pages {
+			hits = fmt.Sprintf(`[{"_id":"%s-%d","_source":{"slice":"%s","page":%d}}]`, scrollID, n, scrollID, n)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"_scroll_id":"%s","hits":{"hits":%s}}`, scrollID, hits)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/test-index/_search":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			scrollID := "s0"
+			if slice, ok := body["slice"].(map[string]interface{}); ok {
+				scrollID = fmt.Sprintf("s%v", slice["id"])
+			}
+			page(w, scrollID)
+		case r.URL.Path == "/_search/scroll" && r.Method != "DELETE":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			page(w, body["scroll_id"].(string))
+		case r.Method == "DELETE" && r.URL.Path == "/_search/scroll":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, id := range body["scroll_id"].([]interface{}) {
+				cleared.Store(id, true)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"succeeded":true}`))
+		}
+	})
+	return client, cleared
+}
+
+func TestScanAll_Sequential(t *testing.T) {
+	client, cleared := newScrollTestClient(t, 3)
+
+	var ids []string
+	err := client.ScanAll(context.Background(), "test-index", nil, nil, func(hits []map[string]interface{}) error {
+		for _, hit := range hits {
+			ids = append(ids, hit["_id"].(string))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "s0-0,s0-1,s0-2" {
+		t.Errorf("ScanAll() ids = %v", ids)
+	}
+	if _, ok := cleared.Load("s0"); !ok {
+		t.Error("ScanAll() should clear the scroll context")
+	}
+}
+
+func TestScanAll_SlicedOrdered(t *testing.T) {
+	client, cleared := newScrollTestClient(t, 2)
+
+	var ids []string
+	opts := &ScanOptions{Slices: 3, Merge: MergeOrdered}
+	err := client.ScanAll(context.Background(), "test-index", nil, opts, func(hits []map[string]interface{}) error {
+		for _, hit := range hits {
+			ids = append(ids, hit["_id"].(string))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "s0-0,s0-1,s1-0,s1-1,s2-0,s2-1" {
+		t.Errorf("ScanAll() ids = %v, want slice order", ids)
+	}
+	for _, id := range []string{"s0", "s1", "s2"} {
+		if _, ok := cleared.Load(id); !ok {
+			t.Errorf("ScanAll() should clear scroll context %s", id)
+		}
+	}
+}
+
+func TestScanAll_SlicedUnordered(t *testing.T) {
+	client, _ := newScrollTestClient(t, 4)
+
+	count := 0
+	opts := &ScanOptions{Slices: 2, Merge: MergeUnordered}
+	err := client.ScanAll(context.Background(), "test-index", nil, opts, func(hits []map[string]interface{}) error {
+		count += len(hits)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if count != 8 {
+		t.Errorf("ScanAll() count = %d, want 8", count)
+	}
+}
+
+func TestScanAll_HandlerError(t *testing.T) {
+	client, _ := newScrollTestClient(t, 5)
+
+	stop := errors.New("stop")
+	err := client.ScanAll(context.Background(), "test-index", nil, &ScanOptions{Slices: 2}, func(hits []map[string]interface{}) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("ScanAll() error = %v, want handler error", err)
+	}
+}
+
+func TestExport(t *testing.T) {
+	client, _ := newScrollTestClient(t, 2)
+
+	var buf bytes.Buffer
+	if err := client.Export(context.Background(), "test-index", nil, nil, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != `{"page":0,"slice":"s0"}` {
+		t.Errorf("Export() output = %q", buf.String())
+	}
+}
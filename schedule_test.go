@@ -0,0 +1,41 @@
+package elasticsearch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2024, 3, 15, 10, 7, 30, 0, time.UTC) // 周五
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 8 * * *", time.Date(2024, 3, 16, 8, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2024, 3, 18, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,10 10 * * *", time.Date(2024, 3, 15, 10, 10, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+		}
+		if got := schedule.Next(base); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next() = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+
+	if got := Every(time.Minute).Next(base); !got.Equal(base.Add(time.Minute)) {
+		t.Errorf("Every().Next() = %s", got)
+	}
+}
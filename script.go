@@ -0,0 +1,143 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// scriptRetryOnConflict 脚本更新在并发冲突时的服务端重试次数
+const scriptRetryOnConflict = 3
+
+// incrementScript 字段不存在时以增量初始化，否则累加
+const incrementScript = `if (ctx._source[params.field] == null) { ctx._source[params.field] = params.delta } else { ctx._source[params.field] += params.delta }`
+
+// appendScript 追加元素到数组字段，unique 时跳过已存在的元素，未发生变化时不写入新版本
+const appendScript = `def field = ctx._source[params.field];
+if (field == null) { field = new ArrayList(); ctx._source[params.field] = field; }
+else if (!(field instanceof List)) { field = new ArrayList([field]); ctx._source[params.field] = field; }
+boolean changed = false;
+for (def v : params.values) { if (!params.unique || !field.contains(v)) { field.add(v); changed = true; } }
+if (!changed) { ctx.op = 'noop'; }`
+
+// IncrementField 原子地为数值字段增加 delta（可为负数），文档不存在时以 {field: delta} 创建。
+// 不会记录历史版本
+func (c *ElasticsearchClient) IncrementField(ctx context.Context, index string, documentID string, field string, delta int64) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	script := map[string]interface{}{
+		"source": incrementScript,
+		"lang":   "painless",
+		"params": map[string]interface{}{"field": field, "delta": delta},
+	}
+	upsert := map[string]interface{}{field: delta}
+	return c.scriptUpdate(ctx, index, documentID, script, upsert)
+}
+
+// AppendToArray 原子地向数组字段追加元素，unique 为 true 时跳过已存在的元素（适用于标签列表），
+// 文档不存在时以 {field: values} 创建。不会记录历史版本
+func (c *ElasticsearchClient) AppendToArray(ctx context.Context, index string, documentID string, field string, values []interface{}, unique bool) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	initial := values
+	if unique {
+		initial = uniqueValues(values)
+	}
+
+	script := map[string]interface{}{
+		"source": appendScript,
+		"lang":   "painless",
+		"params": map[string]interface{}{"field": field, "values": values, "unique": unique},
+	}
+	upsert := map[string]interface{}{field: initial}
+	return c.scriptUpdate(ctx, index, documentID, script, upsert)
+}
+
+// scriptUpdate 执行带 upsert 的脚本更新
+func (c *ElasticsearchClient) scriptUpdate(ctx context.Context, index string, documentID string, script map[string]interface{}, upsert map[string]interface{}) error {
+	index = c.resolveIndex(ctx, index)
+
+	idPath, err := documentIDPath(documentID)
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"script": script,
+		"upsert": upsert,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal update body: %w", err)
+	}
+
+	refresh, err := c.refreshPolicy(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	retry := scriptRetryOnConflict
+	req := esapi.UpdateRequest{
+		Index:           index,
+		DocumentID:      idPath,
+		Body:            strings.NewReader(string(bodyBytes)),
+		Refresh:         refresh,
+		RetryOnConflict: &retry,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return c.requestError("update document", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return c.responseError("update", res)
+	}
+
+	return nil
+}
+
+// uniqueValues 去除重复元素并保持原有顺序
+func uniqueValues(values []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		duplicate := false
+		for _, existing := range result {
+			if reflect.DeepEqual(existing, v) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, v)
+		}
+	}
+	return result
+}
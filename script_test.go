@@ -0,0 +1,65 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIncrementField(t *testing.T) {
+	var body map[string]interface{}
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result":"updated"}`))
+	})
+
+	if err := client.IncrementField(context.Background(), "stats", "page-1", "views", 5); err != nil {
+		t.Fatalf("IncrementField() error = %v", err)
+	}
+
+	params := body["script"].(map[string]interface{})["params"].(map[string]interface{})
+	if params["field"] != "views" || params["delta"] != float64(5) {
+		t.Errorf("params = %v", params)
+	}
+	if upsert := body["upsert"].(map[string]interface{}); upsert["views"] != float64(5) {
+		t.Errorf("upsert = %v", upsert)
+	}
+	if query != "refresh=true&retry_on_conflict=3" {
+		t.Errorf("query = %s", query)
+	}
+}
+
+func TestAppendToArray(t *testing.T) {
+	var body map[string]interface{}
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result":"updated"}`))
+	})
+
+	values := []interface{}{"go", "es", "go"}
+	if err := client.AppendToArray(context.Background(), "posts", "1", "tags", values, true); err != nil {
+		t.Fatalf("AppendToArray() error = %v", err)
+	}
+
+	params := body["script"].(map[string]interface{})["params"].(map[string]interface{})
+	if params["unique"] != true || len(params["values"].([]interface{})) != 3 {
+		t.Errorf("params = %v", params)
+	}
+	if upsert := body["upsert"].(map[string]interface{})["tags"].([]interface{}); len(upsert) != 2 {
+		t.Errorf("upsert tags = %v, want de-duplicated", upsert)
+	}
+
+	if err := client.AppendToArray(context.Background(), "posts", "1", "tags", nil, true); err != nil {
+		t.Fatalf("AppendToArray() with no values error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"io"
+)
+
+// ScrollIterator 按批次遍历 scroll 结果，自动管理 scroll ID，结束或关闭时清理服务端上下文。
+// 适用于需要逐批处理（如分批写入文件或下游系统）的大结果集导出
+type ScrollIterator struct {
+	it *Iterator
+}
+
+// Scroll 创建查询匹配的全部文档的 scroll 批次迭代器，首批在第一次调用 Next 时请求。
+// opts 中的 Strategy 会被忽略，始终使用 scroll
+func (c *ElasticsearchClient) Scroll(ctx context.Context, index string, query map[string]interface{}, opts *IterateOptions) *ScrollIterator {
+	iterOpts := opts.withDefaults()
+	iterOpts.Strategy = IterateScroll
+	return &ScrollIterator{it: c.Iterate(ctx, index, query, &iterOpts)}
+}
+
+// Next 返回下一批命中，没有更多文档时返回 io.EOF 并清理 scroll 上下文
+func (s *ScrollIterator) Next(ctx context.Context) ([]Hit, error) {
+	it := s.it
+	if it.err != nil {
+		return nil, it.err
+	}
+	if it.closed || (it.started && it.last) {
+		it.release()
+		return nil, io.EOF
+	}
+
+	it.ctx = ctx
+	if err := it.fetch(); err != nil {
+		it.err = err
+		it.release()
+		return nil, err
+	}
+	if len(it.hits) == 0 {
+		it.release()
+		return nil, io.EOF
+	}
+	hits := it.hits
+	it.pos = len(hits)
+	return hits, nil
+}
+
+// ScrollID 返回当前的 scroll ID，尚未开始或已清理时为空
+func (s *ScrollIterator) ScrollID() string {
+	return s.it.scrollID
+}
+
+// Close 清理服务端的 scroll 上下文，可重复调用
+func (s *ScrollIterator) Close() error {
+	return s.it.Close()
+}
@@ -0,0 +1,55 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestScrollIterator(t *testing.T) {
+	var scrolls, cleared int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/orders/_search":
+			w.Write([]byte(`{"_scroll_id":"s1","hits":{"hits":[{"_id":"1","_source":{}},{"_id":"2","_source":{}}]}}`))
+		case r.URL.Path == "/_search/scroll" && r.Method == "POST":
+			scrolls++
+			w.Write([]byte(`{"_scroll_id":"s2","hits":{"hits":[{"_id":"3","_source":{}}]}}`))
+		case r.URL.Path == "/_search/scroll" && r.Method == "DELETE":
+			cleared++
+			w.Write([]byte(`{"succeeded":true}`))
+		}
+	})
+
+	ctx := context.Background()
+	s := client.Scroll(ctx, "orders", nil, &IterateOptions{BatchSize: 2, Strategy: IteratePointInTime})
+	defer s.Close()
+
+	var ids []string
+	for {
+		hits, err := s.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if s.ScrollID() == "" {
+			t.Error("ScrollID() is empty during scrolling")
+		}
+		for _, hit := range hits {
+			ids = append(ids, hit.ID)
+		}
+	}
+	if len(ids) != 3 || ids[2] != "3" || scrolls != 1 {
+		t.Errorf("ids = %v, scrolls = %d", ids, scrolls)
+	}
+	if cleared != 1 || s.ScrollID() != "" {
+		t.Errorf("cleared = %d, ScrollID() = %q", cleared, s.ScrollID())
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
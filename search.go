@@ -0,0 +1,268 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// DefaultMaxResultWindow Elasticsearch 默认的 index.max_result_window
+const DefaultMaxResultWindow = 10000
+
+// ErrResultWindowExceeded 分页深度（from+size）超过上限
+var ErrResultWindowExceeded = errors.New("result window is too large")
+
+// partialSearchResultsKey 单次请求是否允许部分结果的上下文键
+type partialSearchResultsKey struct{}
+
+// WithAllowPartialSearchResults 覆盖使用该上下文的搜索请求的 allow_partial_search_results 参数。
+// 设置为 false 时部分分片失败或超时会使请求返回错误，而不是静默返回不完整的结果
+func WithAllowPartialSearchResults(ctx context.Context, allow bool) context.Context {
+	return context.WithValue(ctx, partialSearchResultsKey{}, allow)
+}
+
+// allowPartialSearchResults 返回搜索请求的 allow_partial_search_results 参数，
+// 上下文设置优先于客户端默认值，均未设置时返回 nil 使用服务端默认值
+func (c *ElasticsearchClient) allowPartialSearchResults(ctx context.Context) *bool {
+	if allow, ok := ctx.Value(partialSearchResultsKey{}).(bool); ok {
+		return &allow
+	}
+	return c.allowPartialResults
+}
+
+// resultWindow 返回客户端生效的分页深度上限，0 表示不检查
+func (c *ElasticsearchClient) resultWindow() int {
+	switch {
+	case c.maxResultWindow < 0:
+		return 0
+	case c.maxResultWindow == 0:
+		return DefaultMaxResultWindow
+	default:
+		return c.maxResultWindow
+	}
+}
+
+// checkResultWindow 检查查询中的 from+size 是否超过分页深度上限，
+// 提前返回明确的错误，避免深分页请求在服务端失败
+func (c *ElasticsearchClient) checkResultWindow(query map[string]interface{}, cfg *searchConfig) error {
+	limit := c.resultWindow()
+	if limit == 0 {
+		return nil
+	}
+
+	from, err := intParam(query, "from")
+	if err != nil {
+		return err
+	}
+	size, err := intParam(query, "size")
+	if err != nil {
+		return err
+	}
+	// URL 参数优先于查询体
+	if cfg.from != nil {
+		from = *cfg.from
+	}
+	if cfg.size != nil {
+		size = *cfg.size
+	}
+
+	if from+size > limit {
+		return fmt.Errorf("%w: from (%d) + size (%d) must be less than or equal to %d, "+
+			"use search_after with a point in time (PIT) for deep pagination",
+			ErrResultWindowExceeded, from, size, limit)
+	}
+	return nil
+}
+
+// intParam 读取查询中的整数参数，兼容 int/float64/json.Number/string 等常见类型
+func intParam(query map[string]interface{}, key string) (int, error) {
+	value, ok := query[key]
+	if !ok || value == nil {
+		return 0, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case float32:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s value %q: %w", key, v, err)
+		}
+		return int(n), nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s value %q: %w", key, v, err)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("invalid %s value type %T", key, value)
+	}
+}
+
+// SearchOption 搜索请求参数
+type SearchOption func(*searchConfig)
+
+// searchConfig 搜索请求的 URL 参数，优先于查询体中的同名设置
+type searchConfig struct {
+	from           *int
+	size           *int
+	sort           []string
+	sourceIncludes []string
+	sourceExcludes []string
+	trackTotalHits interface{}
+	timeout        time.Duration
+	preference     string
+
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+
+	useNumber bool // 以 json.Number 解析响应中的数字，SearchTyped 使用以保留超过 2^53 的整数
+}
+
+// newSearchConfig 应用搜索选项
+func newSearchConfig(opts []SearchOption) *searchConfig {
+	cfg := &searchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithFrom 设置返回结果的起始偏移
+func WithFrom(from int) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.from = &from
+	}
+}
+
+// WithSize 设置返回的文档数
+func WithSize(size int) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.size = &size
+	}
+}
+
+// WithSort 设置排序，格式为 "field:asc" 或 "field:desc"，可多次指定
+func WithSort(sort ...string) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.sort = append(cfg.sort, sort...)
+	}
+}
+
+// WithSourceIncludes 只返回 _source 中的指定字段，支持通配符
+func WithSourceIncludes(fields ...string) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.sourceIncludes = append(cfg.sourceIncludes, fields...)
+	}
+}
+
+// WithSourceExcludes 从 _source 中排除指定字段，支持通配符
+func WithSourceExcludes(fields ...string) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.sourceExcludes = append(cfg.sourceExcludes, fields...)
+	}
+}
+
+// WithTrackTotalHits 设置是否精确统计命中总数，为 false 时不统计总数以提升性能
+func WithTrackTotalHits(track bool) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.trackTotalHits = track
+	}
+}
+
+// WithTrackTotalHitsUpTo 精确统计命中总数直到 limit，超过时总数为下限
+func WithTrackTotalHitsUpTo(limit int) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.trackTotalHits = limit
+	}
+}
+
+// WithRequestTimeout 设置服务端的搜索超时，超时后返回已收集的部分结果
+func WithRequestTimeout(timeout time.Duration) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithPreference 设置分片副本的选择偏好（如 "_local" 或会话 ID），使同一用户的请求命中相同副本，结果排序更稳定
+func WithPreference(preference string) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.preference = preference
+	}
+}
+
+// WithIgnoreUnavailable 忽略不存在或已关闭的索引，而不是返回错误
+func WithIgnoreUnavailable(ignore bool) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.ignoreUnavailable = &ignore
+	}
+}
+
+// WithAllowNoIndices 设置通配符或别名没有匹配任何索引时是否允许（返回空结果），为 false 时返回错误
+func WithAllowNoIndices(allow bool) SearchOption {
+	return func(cfg *searchConfig) {
+		cfg.allowNoIndices = &allow
+	}
+}
+
+// apply 将选项写入搜索请求
+func (cfg *searchConfig) apply(req *esapi.SearchRequest) {
+	req.From = cfg.from
+	req.Size = cfg.size
+	req.Sort = cfg.sort
+	req.SourceIncludes = cfg.sourceIncludes
+	req.SourceExcludes = cfg.sourceExcludes
+	req.TrackTotalHits = cfg.trackTotalHits
+	req.Timeout = cfg.timeout
+	req.Preference = cfg.preference
+	req.IgnoreUnavailable = cfg.ignoreUnavailable
+	req.AllowNoIndices = cfg.allowNoIndices
+}
+
+// cacheKey 返回影响搜索结果的参数，用于区分搜索缓存条目
+func (cfg *searchConfig) cacheKey() map[string]interface{} {
+	return map[string]interface{}{
+		"from":               cfg.from,
+		"size":               cfg.size,
+		"sort":               cfg.sort,
+		"source_includes":    cfg.sourceIncludes,
+		"source_excludes":    cfg.sourceExcludes,
+		"track_total_hits":   cfg.trackTotalHits,
+		"timeout":            cfg.timeout.String(),
+		"preference":         cfg.preference,
+		"ignore_unavailable": cfg.ignoreUnavailable,
+		"allow_no_indices":   cfg.allowNoIndices,
+		"use_number":         cfg.useNumber,
+	}
+}
@@ -0,0 +1,128 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCheckResultWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  int
+		query   map[string]interface{}
+		wantErr bool
+	}{
+		{"no pagination", 0, map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}, false},
+		{"within default window", 0, map[string]interface{}{"from": 9990, "size": 10}, false},
+		{"exceeds default window", 0, map[string]interface{}{"from": 9995, "size": 10}, true},
+		{"float values from json", 0, map[string]interface{}{"from": float64(20000), "size": float64(10)}, true},
+		{"json number", 0, map[string]interface{}{"from": json.Number("100"), "size": json.Number("10")}, false},
+		{"custom window", 100, map[string]interface{}{"from": 95, "size": 10}, true},
+		{"disabled", -1, map[string]interface{}{"from": 50000, "size": 10}, false},
+		{"invalid type", 0, map[string]interface{}{"from": []int{1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &ElasticsearchClient{maxResultWindow: tt.window}
+			err := client.checkResultWindow(tt.query, newSearchConfig(nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkResultWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearch_DeepPaginationRejected(t *testing.T) {
+	called := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	})
+
+	_, err := client.Search(context.Background(), "test-index", map[string]interface{}{"from": 10000, "size": 20})
+	if !errors.Is(err, ErrResultWindowExceeded) {
+		t.Errorf("Search() error = %v, want ErrResultWindowExceeded", err)
+	}
+	if called {
+		t.Error("Search() should not send deep pagination requests to the server")
+	}
+}
+
+func TestAllowPartialSearchResults(t *testing.T) {
+	var got []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("allow_partial_search_results"))
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}
+	ctx := context.Background()
+
+	client := newTestClient(t, handler)
+	client.Search(ctx, "orders", nil)
+	client.Search(WithAllowPartialSearchResults(ctx, false), "orders", nil)
+
+	disallow := false
+	strict := newTestClient(t, handler, func(opts *Options) {
+		opts.AllowPartialSearchResults = &disallow
+	})
+	strict.Search(ctx, "orders", nil)
+	strict.Search(WithAllowPartialSearchResults(ctx, true), "orders", nil)
+
+	want := []string{"", "false", "false", "true"}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d allow_partial_search_results = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSearchOptions(t *testing.T) {
+	var params url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	})
+
+	_, err := client.Search(context.Background(), "orders", nil,
+		WithFrom(20),
+		WithSize(10),
+		WithSort("created_at:desc", "_id:asc"),
+		WithSourceIncludes("id", "status"),
+		WithSourceExcludes("payload.*"),
+		WithTrackTotalHitsUpTo(1000),
+		WithRequestTimeout(2*time.Second),
+		WithPreference("_local"),
+	)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	want := map[string]string{
+		"from":             "20",
+		"size":             "10",
+		"sort":             "created_at:desc,_id:asc",
+		"_source_includes": "id,status",
+		"_source_excludes": "payload.*",
+		"track_total_hits": "1000",
+		"timeout":          "2000ms",
+		"preference":       "_local",
+	}
+	for key, value := range want {
+		if got := params.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+
+	_, err = client.Search(context.Background(), "orders", nil, WithFrom(9995), WithSize(10))
+	if !errors.Is(err, ErrResultWindowExceeded) {
+		t.Errorf("Search() error = %v, want ErrResultWindowExceeded for option pagination", err)
+	}
+}
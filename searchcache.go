@@ -0,0 +1,220 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// searchCachePolicyKey 单次搜索缓存策略的上下文键
+type searchCachePolicyKey struct{}
+
+// searchCachePolicy stale-while-revalidate 缓存策略
+type searchCachePolicy struct {
+	softTTL time.Duration
+	hardTTL time.Duration
+}
+
+// WithStaleWhileRevalidate 为使用该上下文的 Search 启用 stale-while-revalidate 缓存（需配置 SearchCacheSize）：
+// 缓存在 softTTL 内直接返回；超过 softTTL 但未超过 hardTTL 时立即返回旧结果并在后台刷新；
+// 超过 hardTTL 或未命中时同步查询。hardTTL 小于 softTTL 时按 softTTL 处理。
+// 适用于可以容忍短暂过期数据的仪表盘类查询
+func WithStaleWhileRevalidate(ctx context.Context, softTTL, hardTTL time.Duration) context.Context {
+	if hardTTL < softTTL {
+		hardTTL = softTTL
+	}
+	return context.WithValue(ctx, searchCachePolicyKey{}, searchCachePolicy{softTTL: softTTL, hardTTL: hardTTL})
+}
+
+// searchCacheEntry 缓存的搜索响应
+type searchCacheEntry struct {
+	key        string
+	data       []byte
+	storedAt   time.Time
+	refreshing bool
+}
+
+// searchCache 按索引和查询缓存搜索响应的 LRU 缓存
+type searchCache struct {
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List     // 最近使用的在前
+	pending sync.WaitGroup // 后台刷新
+}
+
+// newSearchCache 创建搜索缓存，size 小于等于 0 时返回 nil
+func newSearchCache(size int) *searchCache {
+	if size <= 0 {
+		return nil
+	}
+	return &searchCache{
+		size:    size,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// lookup 返回缓存的响应及其存储时间
+func (sc *searchCache) lookup(key string) ([]byte, time.Time, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	elem, ok := sc.entries[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	sc.order.MoveToFront(elem)
+	entry := elem.Value.(*searchCacheEntry)
+	return entry.data, entry.storedAt, true
+}
+
+// store 写入缓存，超出容量时淘汰最久未使用的条目
+func (sc *searchCache) store(key string, data []byte) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if elem, ok := sc.entries[key]; ok {
+		entry := elem.Value.(*searchCacheEntry)
+		entry.data = data
+		entry.storedAt = sc.now()
+		entry.refreshing = false
+		sc.order.MoveToFront(elem)
+		return
+	}
+	sc.entries[key] = sc.order.PushFront(&searchCacheEntry{key: key, data: data, storedAt: sc.now()})
+	for sc.order.Len() > sc.size {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.entries, oldest.Value.(*searchCacheEntry).key)
+	}
+}
+
+// beginRefresh 标记条目正在后台刷新，已有刷新在进行时返回 false
+func (sc *searchCache) beginRefresh(key string) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	elem, ok := sc.entries[key]
+	if !ok {
+		return false
+	}
+	entry := elem.Value.(*searchCacheEntry)
+	if entry.refreshing {
+		return false
+	}
+	entry.refreshing = true
+	sc.pending.Add(1)
+	return true
+}
+
+// endRefresh 清除后台刷新标记
+func (sc *searchCache) endRefresh(key string) {
+	sc.mu.Lock()
+	if elem, ok := sc.entries[key]; ok {
+		elem.Value.(*searchCacheEntry).refreshing = false
+	}
+	sc.mu.Unlock()
+	sc.pending.Done()
+}
+
+// wait 等待后台刷新结束，ctx 到期时不再等待
+func (sc *searchCache) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		sc.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cachedSearch 按 stale-while-revalidate 策略执行搜索，load 执行实际的搜索请求
+func (c *ElasticsearchClient) cachedSearch(ctx context.Context, index string, query map[string]interface{}, cfg *searchConfig, policy searchCachePolicy,
+	load func(ctx context.Context) (map[string]interface{}, error)) (map[string]interface{}, error) {
+	// 缓存键包含文档级过滤条件，不同租户的查询不会共享结果
+	filtered, err := c.applyDocumentFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := json.Marshal(map[string]interface{}{"index": index, "query": filtered, "params": cfg.cacheKey()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+	key := string(keyBytes)
+
+	if data, storedAt, ok := c.searchCache.lookup(key); ok {
+		age := c.searchCache.now().Sub(storedAt)
+		if age < policy.hardTTL {
+			if age >= policy.softTTL && c.searchCache.beginRefresh(key) {
+				go c.refreshSearch(context.WithoutCancel(ctx), key, load)
+			}
+			return decodeCachedSearch(data, cfg.useNumber)
+		}
+	}
+
+	result, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(result); err == nil {
+		c.searchCache.store(key, data)
+	}
+	return result, nil
+}
+
+// refreshSearch 在后台刷新缓存的搜索结果，失败时保留旧结果
+func (c *ElasticsearchClient) refreshSearch(ctx context.Context, key string, load func(ctx context.Context) (map[string]interface{}, error)) {
+	defer c.searchCache.endRefresh(key)
+
+	result, err := load(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to refresh cached Elasticsearch search", zap.Error(err))
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	c.searchCache.store(key, data)
+}
+
+// decodeCachedSearch 解码缓存的响应，每次返回独立的副本，调用方修改结果不会影响缓存
+func decodeCachedSearch(data []byte, useNumber bool) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if useNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode cached search result: %w", err)
+	}
+	return result, nil
+}
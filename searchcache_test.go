@@ -0,0 +1,84 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int64
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		fmt.Fprintf(w, `{"hits":{"total":{"value":%d},"hits":[]}}`, n)
+	}, func(o *Options) {
+		o.SearchCacheSize = 8
+	})
+	now := time.Now()
+	client.searchCache.now = func() time.Time { return now }
+
+	ctx := WithStaleWhileRevalidate(context.Background(), time.Minute, 10*time.Minute)
+	query := map[string]interface{}{"query": MatchAll().Source()}
+	total := func() int64 {
+		t.Helper()
+		result, err := client.Search(ctx, "orders", query)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		res, err := SearchResultFromMap(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Total
+	}
+
+	if got := total(); got != 1 {
+		t.Fatalf("first search total = %d, want 1", got)
+	}
+	if got := total(); got != 1 || calls.Load() != 1 {
+		t.Fatalf("fresh hit total = %d, calls = %d, want cached result without request", got, calls.Load())
+	}
+
+	// 超过 softTTL：立即返回旧结果并在后台刷新
+	now = now.Add(2 * time.Minute)
+	if got := total(); got != 1 {
+		t.Fatalf("stale hit total = %d, want stale result 1", got)
+	}
+	if err := client.searchCache.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := total(); got != 2 || calls.Load() != 2 {
+		t.Fatalf("after refresh total = %d, calls = %d, want 2 and 2", got, calls.Load())
+	}
+
+	// 超过 hardTTL：同步查询
+	now = now.Add(time.Hour)
+	if got := total(); got != 3 {
+		t.Fatalf("expired total = %d, want 3", got)
+	}
+
+	// 未启用策略时不使用缓存
+	if _, err := client.Search(context.Background(), "orders", query); err != nil || calls.Load() != 4 {
+		t.Errorf("uncached Search() error = %v, calls = %d, want 4", err, calls.Load())
+	}
+}
+
+func TestSearchCacheEviction(t *testing.T) {
+	cache := newSearchCache(2)
+	cache.store("a", []byte(`{}`))
+	cache.store("b", []byte(`{}`))
+	cache.lookup("a")
+	cache.store("c", []byte(`{}`))
+	if _, _, ok := cache.lookup("b"); ok {
+		t.Error("least recently used entry should be evicted")
+	}
+	if _, _, ok := cache.lookup("a"); !ok {
+		t.Error("recently used entry should be kept")
+	}
+	if newSearchCache(0) != nil {
+		t.Error("newSearchCache(0) should disable the cache")
+	}
+}
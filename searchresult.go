@@ -0,0 +1,138 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SearchResult 类型化的搜索响应
+type SearchResult struct {
+	Total        int64        // 匹配的文档总数（track_total_hits 限制时为下限）
+	MaxScore     float64      // 最高评分，按字段排序时为 0
+	Hits         []Hit        // 当前页的命中文档
+	Aggregations Aggregations // 聚合结果，键为聚合名称
+}
+
+// SearchTyped 搜索文档并返回类型化的结果，查询处理与 Search 一致。
+// 响应中的数字按原始文本保留，_source 中超过 2^53 的整数不会丢失精度
+func (c *ElasticsearchClient) SearchTyped(ctx context.Context, index string, query map[string]interface{}, opts ...SearchOption) (*SearchResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	cfg := newSearchConfig(opts)
+	cfg.useNumber = true
+	result, err := c.searchResolved(ctx, index, c.resolveIndex(ctx, index), query, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return SearchResultFromMap(result)
+}
+
+// SearchResultFromMap 将 Search 返回的原始响应转换为 SearchResult。
+// Search 以 float64 解析数字，超过 2^53 的整数已丢失精度，需要精确值时使用 SearchTyped
+func SearchResultFromMap(result map[string]interface{}) (*SearchResult, error) {
+	hits, _ := result["hits"].(map[string]interface{})
+	total, err := decodeTotal(hits["total"])
+	if err != nil {
+		return nil, err
+	}
+	items, err := decodeHits(hits)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := &SearchResult{
+		Total:        total,
+		Hits:         items,
+		Aggregations: Aggregations{},
+	}
+	switch score := hits["max_score"].(type) {
+	case float64:
+		typed.MaxScore = score
+	case json.Number:
+		typed.MaxScore, _ = score.Float64()
+	}
+
+	aggregations, _ := result["aggregations"].(map[string]interface{})
+	for name, agg := range aggregations {
+		raw, err := json.Marshal(agg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal aggregation %s: %w", name, err)
+		}
+		typed.Aggregations[name] = raw
+	}
+	return typed, nil
+}
+
+// decodeTotal 解析 hits.total，兼容 {"value": n} 和旧版本的数字格式
+func decodeTotal(total interface{}) (int64, error) {
+	if obj, ok := total.(map[string]interface{}); ok {
+		total = obj["value"]
+	}
+	switch v := total.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode hits total: %w", err)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("failed to decode hits total: unexpected type %T", total)
+	}
+}
+
+// HitsToSlice 将搜索结果中所有命中的 _source 解析为 T 列表，顺序与命中一致
+func HitsToSlice[T any](result *SearchResult) ([]T, error) {
+	if result == nil {
+		return nil, nil
+	}
+	items := make([]T, len(result.Hits))
+	for i := range result.Hits {
+		if err := result.Hits[i].DecodeSource(&items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// HitsToMapByID 将搜索结果中所有命中的 _source 解析为以文档 ID 为键的 map
+func HitsToMapByID[T any](result *SearchResult) (map[string]T, error) {
+	if result == nil {
+		return nil, nil
+	}
+	items := make(map[string]T, len(result.Hits))
+	for i := range result.Hits {
+		var item T
+		if err := result.Hits[i].DecodeSource(&item); err != nil {
+			return nil, err
+		}
+		items[result.Hits[i].ID] = item
+	}
+	return items, nil
+}
@@ -0,0 +1,102 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+type testOrder struct {
+	Status string `json:"status"`
+	Amount int    `json:"amount"`
+}
+
+func TestHitsToSliceAndMap(t *testing.T) {
+	raw := map[string]interface{}{
+		"hits": map[string]interface{}{
+			"total": map[string]interface{}{"value": 42, "relation": "eq"},
+			"hits": []interface{}{
+				map[string]interface{}{"_id": "a", "_index": "orders", "_source": map[string]interface{}{"status": "paid", "amount": 10}},
+				map[string]interface{}{"_id": "b", "_index": "orders", "_source": map[string]interface{}{"status": "new", "amount": 5}},
+			},
+		},
+	}
+
+	result, err := SearchResultFromMap(raw)
+	if err != nil {
+		t.Fatalf("SearchResultFromMap() error = %v", err)
+	}
+	if result.Total != 42 || len(result.Hits) != 2 {
+		t.Fatalf("SearchResult = %+v", result)
+	}
+
+	orders, err := HitsToSlice[testOrder](result)
+	if err != nil {
+		t.Fatalf("HitsToSlice() error = %v", err)
+	}
+	if len(orders) != 2 || orders[0].Status != "paid" || orders[1].Amount != 5 {
+		t.Errorf("HitsToSlice() = %+v", orders)
+	}
+
+	byID, err := HitsToMapByID[testOrder](result)
+	if err != nil {
+		t.Fatalf("HitsToMapByID() error = %v", err)
+	}
+	if byID["b"].Status != "new" || len(byID) != 2 {
+		t.Errorf("HitsToMapByID() = %+v", byID)
+	}
+}
+
+func TestDecodeTotalLegacy(t *testing.T) {
+	total, err := decodeTotal(float64(17))
+	if err != nil || total != 17 {
+		t.Errorf("decodeTotal(17) = %d, %v", total, err)
+	}
+	if _, err := decodeTotal("x"); err == nil {
+		t.Error("decodeTotal(string) error = nil, want error")
+	}
+}
+
+func TestSearchTyped(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"hits":{"total":{"value":3,"relation":"eq"},"max_score":1.5,"hits":[
+			{"_id":"1","_index":"orders","_score":1.5,"_source":{"status":"paid","amount":7}}]},
+			"aggregations":{"total_amount":{"value":21}}}`))
+	})
+
+	result, err := client.SearchTyped(context.Background(), "orders", nil)
+	if err != nil {
+		t.Fatalf("SearchTyped() error = %v", err)
+	}
+	if result.Total != 3 || result.MaxScore != 1.5 || len(result.Hits) != 1 || result.Hits[0].Score != 1.5 {
+		t.Fatalf("SearchTyped() = %+v", result)
+	}
+	var order testOrder
+	if err := result.Hits[0].DecodeSource(&order); err != nil || order.Amount != 7 {
+		t.Errorf("DecodeSource() = %+v, %v", order, err)
+	}
+	if _, ok := result.Aggregations["total_amount"]; !ok {
+		t.Errorf("Aggregations = %v, want total_amount", result.Aggregations)
+	}
+}
+
+func TestSearchTypedPreservesLargeIntegers(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"hits":{"total":{"value":1,"relation":"eq"},"hits":[
+			{"_id":"1","_index":"orders","_score":1,"_source":{"order_id":9007199254740993}}]}}`))
+	}, func(o *Options) {
+		o.ReadTransformer = func(index string, source json.RawMessage) (json.RawMessage, error) { return source, nil }
+	})
+
+	result, err := client.SearchTyped(context.Background(), "orders", nil)
+	if err != nil {
+		t.Fatalf("SearchTyped() error = %v", err)
+	}
+	orders, err := HitsToSlice[struct {
+		OrderID int64 `json:"order_id"`
+	}](result)
+	if err != nil || len(orders) != 1 || orders[0].OrderID != 9007199254740993 {
+		t.Errorf("HitsToSlice() = %+v, %v", orders, err)
+	}
+}
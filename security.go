@@ -0,0 +1,58 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrDocumentFilterDenied 文档级安全过滤条件提取失败，请求被拒绝
+var ErrDocumentFilterDenied = errors.New("document filter denied the request")
+
+// DocumentFilter 从上下文中提取当前租户或用户的过滤条件（如 {"term": {"tenant_id": "acme"}}）。
+// 返回 nil 表示不追加过滤条件；返回错误时请求被拒绝，用于在缺少身份信息时默认拒绝访问
+type DocumentFilter func(ctx context.Context) (map[string]interface{}, error)
+
+// applyDocumentFilter 将文档级安全过滤条件放入 bool 查询的 filter 中，
+// 原查询作为 must 子句保留；请求体包含 knn 时同时放入每个 knn 子句的 filter，
+// 返回新的请求体且不修改调用方的查询
+func (c *ElasticsearchClient) applyDocumentFilter(ctx context.Context, body map[string]interface{}) (map[string]interface{}, error) {
+	if c.documentFilter == nil {
+		return body, nil
+	}
+
+	filter, err := c.documentFilter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDocumentFilterDenied, err)
+	}
+	if filter == nil {
+		return body, nil
+	}
+
+	if knn, ok := body["knn"]; ok {
+		if body, err = withKNNFilter(body, knn, filter); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDocumentFilterDenied, err)
+		}
+		// 仅有 knn 时不能追加 query，否则所有匹配过滤条件的文档都会以固定评分混入结果
+		if _, ok := body["query"]; !ok {
+			return body, nil
+		}
+	}
+	return withBoolClause(body, "filter", filter), nil
+}
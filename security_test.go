@@ -0,0 +1,131 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func tenantFilter(ctx context.Context) (map[string]interface{}, error) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok {
+		return nil, errors.New("missing tenant")
+	}
+	return Term("tenant_id", tenant).Source(), nil
+}
+
+func TestApplyDocumentFilter(t *testing.T) {
+	client := &ElasticsearchClient{documentFilter: tenantFilter}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"title": "go"}},
+		"size":  10,
+	}
+	filtered, err := client.applyDocumentFilter(ctx, query)
+	if err != nil {
+		t.Fatalf("applyDocumentFilter() error = %v", err)
+	}
+
+	got, _ := json.Marshal(filtered)
+	want := `{"query":{"bool":{"filter":[{"term":{"tenant_id":"acme"}}],"must":[{"match":{"title":"go"}}]}},"size":10}`
+	if string(got) != want {
+		t.Errorf("applyDocumentFilter() = %s, want %s", got, want)
+	}
+	if _, ok := query["query"].(map[string]interface{})["match"]; !ok {
+		t.Error("applyDocumentFilter() should not modify the original query")
+	}
+
+	if _, err := client.applyDocumentFilter(context.Background(), query); !errors.Is(err, ErrDocumentFilterDenied) {
+		t.Errorf("applyDocumentFilter() without tenant error = %v, want ErrDocumentFilterDenied", err)
+	}
+}
+
+func TestApplyDocumentFilter_KNN(t *testing.T) {
+	client := &ElasticsearchClient{documentFilter: tenantFilter}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	knn := map[string]interface{}{"field": "vector", "query_vector": []float64{1, 0}, "k": 5, "filter": Term("lang", "en").Source()}
+	filtered, err := client.applyDocumentFilter(ctx, map[string]interface{}{"knn": knn})
+	if err != nil {
+		t.Fatalf("applyDocumentFilter() error = %v", err)
+	}
+	got, _ := json.Marshal(filtered)
+	want := `{"knn":{"field":"vector","filter":[{"term":{"lang":"en"}},{"term":{"tenant_id":"acme"}}],"k":5,"query_vector":[1,0]}}`
+	if string(got) != want {
+		t.Errorf("applyDocumentFilter() = %s, want %s", got, want)
+	}
+	if _, ok := knn["filter"].(map[string]interface{}); !ok {
+		t.Error("applyDocumentFilter() should not modify the original knn clause")
+	}
+
+	hybrid := map[string]interface{}{"knn": []interface{}{knn}, "query": Match("title", "go").Source()}
+	filtered, err = client.applyDocumentFilter(ctx, hybrid)
+	if err != nil {
+		t.Fatalf("applyDocumentFilter() error = %v", err)
+	}
+	if _, ok := filtered["query"].(map[string]interface{})["bool"]; !ok {
+		t.Errorf("hybrid query = %v, want bool filter", filtered["query"])
+	}
+	if clauses, _ := filtered["knn"].([]interface{}); len(clauses) != 1 || len(clauses[0].(map[string]interface{})["filter"].([]interface{})) != 2 {
+		t.Errorf("hybrid knn = %v", filtered["knn"])
+	}
+}
+
+type structKNN struct {
+	Field       string    `json:"field"`
+	QueryVector []float64 `json:"query_vector"`
+	K           int       `json:"k"`
+}
+
+func TestApplyDocumentFilter_StructKNN(t *testing.T) {
+	client := &ElasticsearchClient{documentFilter: tenantFilter}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	want := `{"field":"vector","filter":{"term":{"tenant_id":"acme"}},"k":5,"query_vector":[1,0]}`
+	clause := structKNN{Field: "vector", QueryVector: []float64{1, 0}, K: 5}
+	for _, knn := range []interface{}{clause, &clause, []structKNN{clause}, []interface{}{clause}} {
+		filtered, err := client.applyDocumentFilter(ctx, map[string]interface{}{"knn": knn})
+		if err != nil {
+			t.Fatalf("applyDocumentFilter(%T) error = %v", knn, err)
+		}
+		got, _ := json.Marshal(filtered["knn"])
+		if s := string(got); s != want && s != "["+want+"]" {
+			t.Errorf("applyDocumentFilter(%T) knn = %s, want filter %s", knn, got, want)
+		}
+	}
+
+	if _, err := client.applyDocumentFilter(ctx, map[string]interface{}{"knn": []interface{}{"bad"}}); !errors.Is(err, ErrDocumentFilterDenied) {
+		t.Errorf("applyDocumentFilter() with invalid knn error = %v, want ErrDocumentFilterDenied", err)
+	}
+}
+
+func TestCount_DocumentFilter(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/_count" {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"count":2}`))
+		}
+	}, func(opts *Options) {
+		opts.DocumentFilter = tenantFilter
+	})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	count, err := client.Count(ctx, "orders", nil)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count() = %d, want 2", count)
+	}
+	body, _ := json.Marshal(got)
+	if string(body) != `{"query":{"bool":{"filter":[{"term":{"tenant_id":"acme"}}]}}}` {
+		t.Errorf("Count() body = %s", body)
+	}
+}
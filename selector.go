@@ -0,0 +1,155 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+)
+
+// DefaultLatencyProbeInterval 延迟感知选择下，较慢节点至少每隔该时间被探测一次
+const DefaultLatencyProbeInterval = 30 * time.Second
+
+// errNoConnections 没有可选择的连接
+var errNoConnections = errors.New("no elasticsearch connection available")
+
+// latencyDecay 延迟和错误率 EWMA 的平滑系数，越大越偏向最近的样本
+const latencyDecay = 0.2
+
+// latencyErrorPenalty 错误率对节点评分的放大倍数，错误率 10% 的节点评分约为延迟的 2 倍
+const latencyErrorPenalty = 10
+
+// nodeLatency 单个节点的延迟和错误率统计
+type nodeLatency struct {
+	latency      float64 // 响应延迟的 EWMA（毫秒）
+	errorRate    float64 // 失败率的 EWMA
+	samples      uint64
+	lastSelected time.Time
+}
+
+// score 节点评分，越小越优先
+func (n *nodeLatency) score() float64 {
+	return n.latency * (1 + latencyErrorPenalty*n.errorRate)
+}
+
+// latencySelector 按节点的延迟和错误率选择连接，优先选择健康且快速的节点，
+// 并定期探测较慢的节点以便在其恢复后重新使用
+type latencySelector struct {
+	probeInterval time.Duration
+	now           func() time.Time
+
+	mu    sync.Mutex
+	nodes map[string]*nodeLatency
+}
+
+// newLatencySelector 创建延迟感知的连接选择器
+func newLatencySelector(probeInterval time.Duration) *latencySelector {
+	if probeInterval <= 0 {
+		probeInterval = DefaultLatencyProbeInterval
+	}
+	return &latencySelector{
+		probeInterval: probeInterval,
+		now:           time.Now,
+		nodes:         make(map[string]*nodeLatency),
+	}
+}
+
+// Select 实现 elastictransport.Selector：没有样本或超过探测间隔未被选中的节点优先，其余选择评分最小的节点
+func (s *latencySelector) Select(conns []*elastictransport.Connection) (*elastictransport.Connection, error) {
+	if len(conns) == 0 {
+		return nil, errNoConnections
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var (
+		best      *elastictransport.Connection
+		bestNode  *nodeLatency
+		probe     *elastictransport.Connection
+		probeNode *nodeLatency
+	)
+	for _, conn := range conns {
+		node := s.node(conn.URL.Host)
+		if node.samples == 0 || now.Sub(node.lastSelected) >= s.probeInterval {
+			if probe == nil || node.lastSelected.Before(probeNode.lastSelected) {
+				probe, probeNode = conn, node
+			}
+			continue
+		}
+		if best == nil || node.score() < bestNode.score() {
+			best, bestNode = conn, node
+		}
+	}
+	if probe != nil {
+		best, bestNode = probe, probeNode
+	}
+	bestNode.lastSelected = now
+	return best, nil
+}
+
+// observe 记录一次请求的延迟和结果
+func (s *latencySelector) observe(host string, d time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node := s.node(host)
+	ms := float64(d) / float64(time.Millisecond)
+	errValue := 0.0
+	if failed {
+		errValue = 1
+	}
+	if node.samples == 0 {
+		node.latency = ms
+		node.errorRate = errValue
+	} else {
+		node.latency += latencyDecay * (ms - node.latency)
+		node.errorRate += latencyDecay * (errValue - node.errorRate)
+	}
+	node.samples++
+}
+
+// node 返回节点的统计，不存在时创建
+func (s *latencySelector) node(host string) *nodeLatency {
+	node, ok := s.nodes[host]
+	if !ok {
+		node = &nodeLatency{}
+		s.nodes[host] = node
+	}
+	return node
+}
+
+// latencyObserverTransport 记录每个节点的响应延迟和失败（网络错误或 5xx），供 latencySelector 选择节点
+type latencyObserverTransport struct {
+	next     http.RoundTripper
+	selector *latencySelector
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *latencyObserverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	// 调用方取消的请求不计入节点失败
+	failed := (err != nil && req.Context().Err() == nil) || (err == nil && res.StatusCode >= 500)
+	t.selector.observe(req.URL.Host, time.Since(start), failed)
+	return res, err
+}
@@ -0,0 +1,64 @@
+package elasticsearch
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+)
+
+func testConnections(hosts ...string) []*elastictransport.Connection {
+	conns := make([]*elastictransport.Connection, len(hosts))
+	for i, host := range hosts {
+		conns[i] = &elastictransport.Connection{URL: &url.URL{Scheme: "http", Host: host}}
+	}
+	return conns
+}
+
+func TestLatencySelector(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := newLatencySelector(time.Minute)
+	s.now = func() time.Time { return now }
+	conns := testConnections("fast:9200", "slow:9200", "flaky:9200")
+
+	// 没有样本的节点先被依次选中
+	seen := map[string]bool{}
+	for range conns {
+		conn, err := s.Select(conns)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		seen[conn.URL.Host] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("initial selections = %v, want every node once", seen)
+	}
+
+	s.observe("fast:9200", 10*time.Millisecond, false)
+	s.observe("slow:9200", 200*time.Millisecond, false)
+	s.observe("flaky:9200", 5*time.Millisecond, true)
+
+	now = now.Add(time.Second)
+	for i := 0; i < 5; i++ {
+		conn, _ := s.Select(conns)
+		if conn.URL.Host != "fast:9200" {
+			t.Fatalf("Select() = %s, want fast:9200", conn.URL.Host)
+		}
+	}
+
+	// 超过探测间隔后较慢的节点会被再次选中
+	now = now.Add(time.Minute)
+	probed := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		conn, _ := s.Select(conns)
+		probed[conn.URL.Host] = true
+	}
+	if !probed["slow:9200"] || !probed["flaky:9200"] {
+		t.Errorf("probed = %v, want slow and flaky nodes", probed)
+	}
+
+	if _, err := s.Select(nil); err == nil {
+		t.Error("Select(nil) should return error")
+	}
+}
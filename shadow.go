@@ -0,0 +1,280 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-log"
+	"go.uber.org/zap"
+)
+
+// ShadowDiff 一次影子查询与主查询的结果对比
+type ShadowDiff struct {
+	Index          string        // 主查询的索引
+	ShadowIndex    string        // 影子查询的索引
+	PrimaryTotal   int64         // 主查询的命中总数
+	ShadowTotal    int64         // 影子查询的命中总数
+	PrimaryLatency time.Duration // 主查询耗时
+	ShadowLatency  time.Duration // 影子查询耗时
+	Overlap        float64       // 主查询返回的文档在影子查询结果中出现的比例（0~1），主查询无命中时为 1
+	OrderMatched   bool          // 两次查询返回的文档 ID 及顺序完全一致
+	Err            error         // 影子查询的错误，非 nil 时其余对比字段无意义
+}
+
+// ShadowHook 影子查询完成后的回调，在后台 goroutine 中执行
+type ShadowHook func(ctx context.Context, diff ShadowDiff)
+
+// shadowMaxInFlight 同时进行的影子查询上限，达到上限时丢弃新的镜像请求
+const shadowMaxInFlight = 32
+
+// ShadowStats 影子流量的累计统计
+type ShadowStats struct {
+	Mirrored        uint64  `json:"mirrored"`             // 已镜像的请求数
+	Dropped         uint64  `json:"dropped"`              // 进行中的影子查询达到上限而丢弃的请求数
+	Failed          uint64  `json:"failed"`               // 影子查询失败数
+	TotalMismatches uint64  `json:"total_mismatches"`     // 命中总数不一致的请求数
+	OrderMismatches uint64  `json:"order_mismatches"`     // 返回文档或顺序不一致的请求数
+	AvgOverlap      float64 `json:"avg_overlap"`          // 成功对比的请求的平均文档重合度
+	AvgLatencyDelta float64 `json:"avg_latency_delta_ms"` // 影子查询相对主查询的平均耗时差（毫秒）
+}
+
+// shadowTraffic 将一定比例的搜索请求异步镜像到另一个集群或索引，nil 时不镜像
+type shadowTraffic struct {
+	target *ElasticsearchClient // 为 nil 时使用当前客户端
+	index  string               // 为空时使用原索引
+	rate   float64
+	hook   ShadowHook
+	sample func() float64
+
+	pending sync.WaitGroup
+	slots   chan struct{} // 限制同时进行的影子查询数
+
+	mu           sync.Mutex
+	stats        ShadowStats
+	overlapSum   float64
+	latencySumMs float64
+}
+
+// newShadowTraffic 创建影子流量配置，比例为 0 或未指定目标集群和索引时返回 nil
+func newShadowTraffic(opts *Options) *shadowTraffic {
+	if opts.ShadowSampleRate <= 0 || (opts.ShadowTarget == nil && opts.ShadowIndex == "") {
+		return nil
+	}
+	return &shadowTraffic{
+		target: opts.ShadowTarget,
+		index:  opts.ShadowIndex,
+		rate:   opts.ShadowSampleRate,
+		hook:   opts.ShadowHook,
+		sample: rand.Float64,
+		slots:  make(chan struct{}, shadowMaxInFlight),
+	}
+}
+
+// shadowHits 对比所需的主查询结果摘要
+type shadowHits struct {
+	total int64
+	ids   []string
+}
+
+// summarizeShadowHits 提取命中总数和文档 ID，解析失败时返回 false
+func summarizeShadowHits(result map[string]interface{}) (shadowHits, bool) {
+	res, err := SearchResultFromMap(result)
+	if err != nil {
+		return shadowHits{}, false
+	}
+	ids := make([]string, len(res.Hits))
+	for i, hit := range res.Hits {
+		ids[i] = hit.ID
+	}
+	return shadowHits{total: res.Total, ids: ids}, true
+}
+
+// mirrorSearch 按比例在后台将搜索镜像到影子目标并记录差异，index 为解析前的索引名
+func (c *ElasticsearchClient) mirrorSearch(ctx context.Context, index string, query map[string]interface{}, cfg *searchConfig, result map[string]interface{}, latency time.Duration) {
+	s := c.shadow
+	if s == nil || s.sample() >= s.rate {
+		return
+	}
+	// 调用方可能修改返回结果，在当前 goroutine 中提取摘要
+	primary, ok := summarizeShadowHits(result)
+	if !ok {
+		return
+	}
+
+	target := s.target
+	if target == nil {
+		target = c
+	}
+	if target.ready() != nil {
+		return
+	}
+	shadowIndex := index
+	if s.index != "" {
+		shadowIndex = s.index
+	}
+	// 调用方可能复用查询，序列化后在后台 goroutine 中使用独立的副本
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return
+	}
+	shadowCfg := *cfg
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.mu.Lock()
+		s.stats.Dropped++
+		s.mu.Unlock()
+		return
+	}
+
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer func() { <-s.slots }()
+		ctx := context.WithoutCancel(ctx)
+		resolved := target.resolveIndex(ctx, shadowIndex)
+
+		var shadowQuery map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(queryBytes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&shadowQuery); err != nil {
+			return
+		}
+
+		start := time.Now()
+		shadowResult, err := queryWithTrace(
+			ctx,
+			"shadow_search",
+			resolved,
+			target.EnableTrace,
+			target.latency,
+			func(ctx context.Context) (map[string]interface{}, error) {
+				return target.search(ctx, resolved, shadowQuery, &shadowCfg)
+			},
+		)
+		diff := ShadowDiff{
+			Index:          index,
+			ShadowIndex:    shadowIndex,
+			PrimaryTotal:   primary.total,
+			PrimaryLatency: latency,
+			ShadowLatency:  time.Since(start),
+			Err:            err,
+		}
+		if err == nil {
+			shadowed, _ := summarizeShadowHits(shadowResult)
+			diff.ShadowTotal = shadowed.total
+			diff.Overlap, diff.OrderMatched = compareHitIDs(primary.ids, shadowed.ids)
+		}
+		s.record(diff)
+
+		if s.hook != nil {
+			s.hook(ctx, diff)
+			return
+		}
+		if err != nil {
+			log.FromContext(ctx).Warn("Elasticsearch shadow search failed",
+				zap.String("index", index), zap.String("shadow_index", shadowIndex), zap.Error(err))
+			return
+		}
+		if diff.PrimaryTotal != diff.ShadowTotal || !diff.OrderMatched {
+			log.FromContext(ctx).Debug("Elasticsearch shadow search result differs",
+				zap.String("index", index),
+				zap.String("shadow_index", shadowIndex),
+				zap.Int64("primary_total", diff.PrimaryTotal),
+				zap.Int64("shadow_total", diff.ShadowTotal),
+				zap.Float64("overlap", diff.Overlap),
+			)
+		}
+	}()
+}
+
+// compareHitIDs 计算主查询文档在影子结果中的重合度，以及两者顺序是否完全一致
+func compareHitIDs(primary, shadow []string) (float64, bool) {
+	matched := len(primary) == len(shadow)
+	seen := make(map[string]struct{}, len(shadow))
+	for i, id := range shadow {
+		seen[id] = struct{}{}
+		if matched && primary[i] != id {
+			matched = false
+		}
+	}
+	if len(primary) == 0 {
+		return 1, matched
+	}
+	found := 0
+	for _, id := range primary {
+		if _, ok := seen[id]; ok {
+			found++
+		}
+	}
+	return float64(found) / float64(len(primary)), matched
+}
+
+// record 累计一次对比结果
+func (s *shadowTraffic) record(diff ShadowDiff) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Mirrored++
+	if diff.Err != nil {
+		s.stats.Failed++
+		return
+	}
+	if diff.PrimaryTotal != diff.ShadowTotal {
+		s.stats.TotalMismatches++
+	}
+	if !diff.OrderMatched {
+		s.stats.OrderMismatches++
+	}
+	s.overlapSum += diff.Overlap
+	s.latencySumMs += float64(diff.ShadowLatency-diff.PrimaryLatency) / float64(time.Millisecond)
+
+	compared := float64(s.stats.Mirrored - s.stats.Failed)
+	s.stats.AvgOverlap = s.overlapSum / compared
+	s.stats.AvgLatencyDelta = s.latencySumMs / compared
+}
+
+// wait 等待进行中的影子查询结束，ctx 到期时不再等待
+func (s *shadowTraffic) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ShadowStats 返回影子流量的累计对比统计，未配置影子流量时返回零值
+func (c *ElasticsearchClient) ShadowStats() ShadowStats {
+	if c == nil || c.shadow == nil {
+		return ShadowStats{}
+	}
+	c.shadow.mu.Lock()
+	defer c.shadow.mu.Unlock()
+	return c.shadow.stats
+}
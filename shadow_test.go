@@ -0,0 +1,105 @@
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestShadowTraffic(t *testing.T) {
+	diffs := make(chan ShadowDiff, 1)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/orders/_search"):
+			w.Write([]byte(`{"hits":{"total":{"value":2},"hits":[{"_id":"1","_source":{}},{"_id":"2","_source":{}}]}}`))
+		case strings.HasPrefix(r.URL.Path, "/orders-v2/_search"):
+			w.Write([]byte(`{"hits":{"total":{"value":3},"hits":[{"_id":"2","_source":{}},{"_id":"3","_source":{}}]}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}, func(o *Options) {
+		o.ShadowIndex = "orders-v2"
+		o.ShadowSampleRate = 1
+		o.ShadowHook = func(_ context.Context, diff ShadowDiff) { diffs <- diff }
+	})
+
+	result, err := client.Search(context.Background(), "orders", map[string]interface{}{"query": MatchAll().Source()})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if res, _ := SearchResultFromMap(result); res.Total != 2 {
+		t.Errorf("Search() total = %d, want primary result", res.Total)
+	}
+
+	diff := <-diffs
+	if diff.Err != nil || diff.ShadowIndex != "orders-v2" || diff.PrimaryTotal != 2 || diff.ShadowTotal != 3 {
+		t.Errorf("diff = %+v", diff)
+	}
+	if diff.Overlap != 0.5 || diff.OrderMatched {
+		t.Errorf("Overlap = %v, OrderMatched = %v, want 0.5 and false", diff.Overlap, diff.OrderMatched)
+	}
+
+	if err := client.shadow.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	stats := client.ShadowStats()
+	if stats.Mirrored != 1 || stats.TotalMismatches != 1 || stats.OrderMismatches != 1 || stats.AvgOverlap != 0.5 {
+		t.Errorf("ShadowStats() = %+v", stats)
+	}
+
+	client.shadow.sample = func() float64 { return 0.99 }
+	client.shadow.rate = 0.5
+	if _, err := client.Search(context.Background(), "orders", nil); err != nil {
+		t.Fatal(err)
+	}
+	client.shadow.wait(context.Background())
+	if got := client.ShadowStats().Mirrored; got != 1 {
+		t.Errorf("Mirrored = %d, want unsampled request to be skipped", got)
+	}
+}
+
+func TestShadowTrafficCopiesQueryAndDropsWhenSaturated(t *testing.T) {
+	release := make(chan struct{})
+	shadowBodies := make(chan string, 1)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/orders-v2/_search") {
+			<-release
+			body, _ := io.ReadAll(r.Body)
+			shadowBodies <- string(body)
+		}
+		w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+	}, func(o *Options) {
+		o.ShadowIndex = "orders-v2"
+		o.ShadowSampleRate = 1
+	})
+	ctx := context.Background()
+
+	query := map[string]interface{}{"query": map[string]interface{}{"term": map[string]interface{}{"status": "paid"}}}
+	if _, err := client.Search(ctx, "orders", query); err != nil {
+		t.Fatal(err)
+	}
+	query["query"] = map[string]interface{}{"term": map[string]interface{}{"status": "changed"}}
+
+	filled := 0
+	for len(client.shadow.slots) < cap(client.shadow.slots) {
+		client.shadow.slots <- struct{}{}
+		filled++
+	}
+	if _, err := client.Search(ctx, "orders", nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < filled; i++ {
+		<-client.shadow.slots
+	}
+	if got := client.ShadowStats().Dropped; got != 1 {
+		t.Errorf("Dropped = %d, want 1 when saturated", got)
+	}
+
+	close(release)
+	if body := <-shadowBodies; !strings.Contains(body, `"paid"`) {
+		t.Errorf("shadow query = %s, want query as of the primary search", body)
+	}
+	client.shadow.wait(ctx)
+}
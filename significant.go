@@ -0,0 +1,95 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import "context"
+
+// significantAggregationName 便捷方法内部使用的聚合名称
+const significantAggregationName = "significant"
+
+// SignificantBucket significant_terms/significant_text 聚合的单个词项
+type SignificantBucket struct {
+	Key      interface{} `json:"key"`       // 词项，数值字段时为数字
+	DocCount int64       `json:"doc_count"` // 前景集合中包含该词项的文档数
+	BgCount  int64       `json:"bg_count"`  // 背景集合中包含该词项的文档数
+	Score    float64     `json:"score"`     // 显著性得分
+}
+
+// SignificantResult significant_terms/significant_text 聚合结果
+type SignificantResult struct {
+	DocCount int64               `json:"doc_count"` // 前景集合文档数
+	BgCount  int64               `json:"bg_count"`  // 背景集合文档数
+	Buckets  []SignificantBucket `json:"buckets"`
+}
+
+// SignificantTermsAggregation 构建 significant_terms 聚合，适用于 keyword 等结构化字段
+func SignificantTermsAggregation(field string, size int) map[string]interface{} {
+	agg := map[string]interface{}{"field": field}
+	if size > 0 {
+		agg["size"] = size
+	}
+	return map[string]interface{}{"significant_terms": agg}
+}
+
+// SignificantTextAggregation 构建 significant_text 聚合，适用于 text 字段（如日志消息），
+// 会过滤重复文本以减少模板化内容的干扰
+func SignificantTextAggregation(field string, size int) map[string]interface{} {
+	agg := map[string]interface{}{
+		"field":                 field,
+		"filter_duplicate_text": true,
+	}
+	if size > 0 {
+		agg["size"] = size
+	}
+	return map[string]interface{}{"significant_text": agg}
+}
+
+// Significant 返回 significant_terms/significant_text 聚合的结果
+func (a Aggregations) Significant(name string) (*SignificantResult, error) {
+	var result SignificantResult
+	if err := a.Decode(name, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SignificantTerms 找出查询匹配的文档中相对全部文档显著出现的词项（如异常时段的高频错误码）
+func (c *ElasticsearchClient) SignificantTerms(ctx context.Context, index string, query map[string]interface{}, field string, size int) (*SignificantResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.significant(ctx, index, query, SignificantTermsAggregation(field, size))
+}
+
+// SignificantText 找出查询匹配文档的文本字段中显著出现的词（如日志中的趋势关键词）
+func (c *ElasticsearchClient) SignificantText(ctx context.Context, index string, query map[string]interface{}, field string, size int) (*SignificantResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.significant(ctx, index, query, SignificantTextAggregation(field, size))
+}
+
+// significant 执行只返回聚合结果的搜索
+func (c *ElasticsearchClient) significant(ctx context.Context, index string, query map[string]interface{}, agg map[string]interface{}) (*SignificantResult, error) {
+	aggs, err := c.searchAggregations(ctx, index, query, map[string]interface{}{significantAggregationName: agg})
+	if err != nil {
+		return nil, err
+	}
+	return aggs.Significant(significantAggregationName)
+}
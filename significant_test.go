@@ -0,0 +1,48 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSignificantText(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]},"aggregations":{"significant":{"doc_count":120,"bg_count":50000,"buckets":[{"key":"timeout","doc_count":80,"bg_count":200,"score":12.5}]}}}`))
+	})
+
+	query := map[string]interface{}{"query": map[string]interface{}{"range": map[string]interface{}{"@timestamp": map[string]interface{}{"gte": "now-1h"}}}}
+	result, err := client.SignificantText(context.Background(), "logs", query, "message", 10)
+	if err != nil {
+		t.Fatalf("SignificantText() error = %v", err)
+	}
+	if result.DocCount != 120 || len(result.Buckets) != 1 {
+		t.Fatalf("SignificantText() = %+v", result)
+	}
+	if b := result.Buckets[0]; b.Key != "timeout" || b.DocCount != 80 || b.BgCount != 200 || b.Score != 12.5 {
+		t.Errorf("bucket = %+v", b)
+	}
+
+	agg := body["aggregations"].(map[string]interface{})["significant"].(map[string]interface{})["significant_text"].(map[string]interface{})
+	if agg["field"] != "message" || agg["size"] != float64(10) {
+		t.Errorf("aggregation = %v", agg)
+	}
+	if body["size"] != float64(0) || body["query"] == nil {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestSignificantTermsAggregation(t *testing.T) {
+	agg := SignificantTermsAggregation("error_code", 0)
+	terms := agg["significant_terms"].(map[string]interface{})
+	if terms["field"] != "error_code" {
+		t.Errorf("field = %v", terms["field"])
+	}
+	if _, ok := terms["size"]; ok {
+		t.Error("size should be omitted when zero")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+// Like 相似搜索的参照对象，可以是已有文档或任意文本
+type Like struct {
+	documentID string
+	text       string
+}
+
+// LikeDocument 以索引中已有的文档作为参照，结果中不包含该文档本身
+func LikeDocument(documentID string) Like {
+	return Like{documentID: documentID}
+}
+
+// LikeText 以文本作为参照
+func LikeText(text string) Like {
+	return Like{text: text}
+}
+
+// SimilarOptions 相似搜索选项，零值字段使用默认值
+type SimilarOptions struct {
+	Size               int                    // 返回结果数，默认 10
+	MinTermFreq        int                    // 词在参照对象中的最小出现次数，默认 1
+	MinDocFreq         int                    // 词在索引中的最小文档频率，默认 2
+	MaxQueryTerms      int                    // 选取的最大词数，默认 25
+	MinimumShouldMatch string                 // 最少匹配的词比例，默认 30%
+	Filter             map[string]interface{} // 附加的过滤条件（如只推荐同类商品）
+}
+
+// withDefaults 返回填充默认值后的相似搜索选项
+func (o *SimilarOptions) withDefaults() SimilarOptions {
+	opts := SimilarOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Size <= 0 {
+		opts.Size = 10
+	}
+	if opts.MinTermFreq <= 0 {
+		opts.MinTermFreq = 1
+	}
+	if opts.MinDocFreq <= 0 {
+		opts.MinDocFreq = 2
+	}
+	if opts.MaxQueryTerms <= 0 {
+		opts.MaxQueryTerms = 25
+	}
+	if opts.MinimumShouldMatch == "" {
+		opts.MinimumShouldMatch = "30%"
+	}
+	return opts
+}
+
+// FindSimilar 使用 more_like_this 查询查找与参照对象相似的文档，适用于“相关推荐”场景
+func (c *ElasticsearchClient) FindSimilar(ctx context.Context, index string, like Like, fields []string, opts *SimilarOptions) ([]Hit, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	if like.documentID == "" && like.text == "" {
+		return nil, fmt.Errorf("similar search requires a document ID or text")
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("similar search requires at least one field")
+	}
+	o := opts.withDefaults()
+
+	var likeClause interface{} = like.text
+	if like.documentID != "" {
+		likeClause = []interface{}{map[string]interface{}{"_index": c.resolveIndexName(ctx, index), "_id": like.documentID}}
+	}
+
+	query := map[string]interface{}{
+		"more_like_this": map[string]interface{}{
+			"fields":               fields,
+			"like":                 likeClause,
+			"min_term_freq":        o.MinTermFreq,
+			"min_doc_freq":         o.MinDocFreq,
+			"max_query_terms":      o.MaxQueryTerms,
+			"minimum_should_match": o.MinimumShouldMatch,
+		},
+	}
+	body := map[string]interface{}{"query": query, "size": o.Size}
+	if o.Filter != nil {
+		body = withBoolClause(body, "filter", o.Filter)
+	}
+
+	result, err := c.Search(ctx, index, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHits(result["hits"])
+}
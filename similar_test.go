@@ -0,0 +1,66 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestFindSimilar(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[{"_id":"7","_index":"articles","_score":3.5,"_source":{"title":"Go tips"}}]}}`))
+	})
+
+	hits, err := client.FindSimilar(context.Background(), "articles", LikeDocument("1"), []string{"title", "body"}, &SimilarOptions{Size: 5})
+	if err != nil {
+		t.Fatalf("FindSimilar() error = %v", err)
+	}
+	if len(hits) != 1 || hits[0].ID != "7" || hits[0].Score != 3.5 {
+		t.Fatalf("FindSimilar() = %+v", hits)
+	}
+	var doc struct {
+		Title string `json:"title"`
+	}
+	if err := hits[0].DecodeSource(&doc); err != nil || doc.Title != "Go tips" {
+		t.Errorf("DecodeSource() = %+v, %v", doc, err)
+	}
+
+	mlt := body["query"].(map[string]interface{})["more_like_this"].(map[string]interface{})
+	like := mlt["like"].([]interface{})[0].(map[string]interface{})
+	if like["_id"] != "1" || like["_index"] != "articles" {
+		t.Errorf("like = %v", like)
+	}
+	if body["size"] != float64(5) || mlt["minimum_should_match"] != "30%" {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestFindSimilarText(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	})
+
+	filter := Term("category", "books").Source()
+	if _, err := client.FindSimilar(context.Background(), "articles", LikeText("concurrency in go"), []string{"body"}, &SimilarOptions{Filter: filter}); err != nil {
+		t.Fatalf("FindSimilar() error = %v", err)
+	}
+	boolQuery := body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	mlt := boolQuery["must"].([]interface{})[0].(map[string]interface{})["more_like_this"].(map[string]interface{})
+	if mlt["like"] != "concurrency in go" {
+		t.Errorf("like = %v", mlt["like"])
+	}
+	if len(boolQuery["filter"].([]interface{})) != 1 {
+		t.Errorf("filter = %v", boolQuery["filter"])
+	}
+
+	if _, err := client.FindSimilar(context.Background(), "articles", Like{}, []string{"body"}, nil); err == nil {
+		t.Error("FindSimilar() without document or text should return error")
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultSoftDeleteField 默认的软删除时间字段
+const DefaultSoftDeleteField = "deleted_at"
+
+// softDeleteField 返回客户端使用的软删除字段
+func (c *ElasticsearchClient) softDeleteField() string {
+	if c.softDeleteFieldName != "" {
+		return c.softDeleteFieldName
+	}
+	return DefaultSoftDeleteField
+}
+
+// SoftDelete 将文档标记为已删除（写入删除时间），文档仍保留在索引中
+func (c *ElasticsearchClient) SoftDelete(ctx context.Context, index string, documentID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.Update(ctx, index, documentID, map[string]interface{}{
+		c.softDeleteField(): time.Now().UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// Restore 撤销软删除
+func (c *ElasticsearchClient) Restore(ctx context.Context, index string, documentID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.Update(ctx, index, documentID, map[string]interface{}{
+		c.softDeleteField(): nil,
+	})
+}
+
+// SearchActive 搜索未被软删除的文档
+func (c *ElasticsearchClient) SearchActive(ctx context.Context, index string, query map[string]interface{}) (map[string]interface{}, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.Search(ctx, index, c.excludeSoftDeleted(query))
+}
+
+// CountActive 统计未被软删除的文档数量
+func (c *ElasticsearchClient) CountActive(ctx context.Context, index string, query map[string]interface{}) (int64, error) {
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	return c.Count(ctx, index, c.excludeSoftDeleted(query))
+}
+
+// PurgeSoftDeleted 物理删除软删除时间早于 olderThan 之前的文档，返回删除的文档数，适合由定时任务调用
+func (c *ElasticsearchClient) PurgeSoftDeleted(ctx context.Context, index string, olderThan time.Duration) (int64, error) {
+	if err := c.ready(); err != nil {
+		return 0, err
+	}
+
+	if olderThan < 0 {
+		return 0, fmt.Errorf("olderThan cannot be negative")
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				c.softDeleteField(): map[string]interface{}{
+					"lte": fmt.Sprintf("now-%ds", int64(olderThan/time.Second)),
+				},
+			},
+		},
+	}
+	result, err := c.DeleteByQuery(ctx, index, query)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, _ := result["deleted"].(float64)
+	return int64(deleted), nil
+}
+
+// excludeSoftDeleted 为查询追加排除已软删除文档的条件
+func (c *ElasticsearchClient) excludeSoftDeleted(query map[string]interface{}) map[string]interface{} {
+	return withBoolClause(query, "must_not", Exists(c.softDeleteField()).Source())
+}
@@ -0,0 +1,90 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSoftDelete(t *testing.T) {
+	var updates []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Path == "/orders/_update/1" {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			updates = append(updates, body["doc"].(map[string]interface{}))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"result":"updated"}`))
+		}
+	})
+
+	if err := client.SoftDelete(context.Background(), "orders", "1"); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+	if err := client.Restore(context.Background(), "orders", "1"); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if len(updates) != 2 {
+		t.Fatalf("updates = %d, want 2", len(updates))
+	}
+	if deletedAt, ok := updates[0]["deleted_at"].(string); !ok || deletedAt == "" {
+		t.Errorf("SoftDelete() doc = %v", updates[0])
+	}
+	if v, ok := updates[1]["deleted_at"]; !ok || v != nil {
+		t.Errorf("Restore() doc = %v, want deleted_at null", updates[1])
+	}
+}
+
+func TestSearchActive(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/_search" {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			b, _ := json.Marshal(body)
+			got = string(b)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"hits":{"hits":[]}}`))
+		}
+	}, func(opts *Options) {
+		opts.SoftDeleteField = "removed_at"
+	})
+
+	query := map[string]interface{}{"query": map[string]interface{}{"term": map[string]interface{}{"status": "paid"}}}
+	if _, err := client.SearchActive(context.Background(), "orders", query); err != nil {
+		t.Fatalf("SearchActive() error = %v", err)
+	}
+	want := `{"query":{"bool":{"must":[{"term":{"status":"paid"}}],"must_not":[{"exists":{"field":"removed_at"}}]}}}`
+	if got != want {
+		t.Errorf("SearchActive() body = %s, want %s", got, want)
+	}
+}
+
+func TestPurgeSoftDeleted(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/_delete_by_query" {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			b, _ := json.Marshal(body)
+			got = string(b)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"deleted":7}`))
+		}
+	})
+
+	deleted, err := client.PurgeSoftDeleted(context.Background(), "orders", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeSoftDeleted() error = %v", err)
+	}
+	if deleted != 7 {
+		t.Errorf("PurgeSoftDeleted() = %d, want 7", deleted)
+	}
+	if !strings.Contains(got, `"lte":"now-86400s"`) {
+		t.Errorf("PurgeSoftDeleted() body = %s", got)
+	}
+}
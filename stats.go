@@ -0,0 +1,195 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// SlowLogThresholds 慢日志各级别的阈值，0 表示不修改，负数表示关闭该级别
+type SlowLogThresholds struct {
+	Warn  time.Duration
+	Info  time.Duration
+	Debug time.Duration
+	Trace time.Duration
+}
+
+// SlowLogConfig 索引级别的慢日志配置
+type SlowLogConfig struct {
+	SearchQuery SlowLogThresholds // 搜索 query 阶段阈值
+	SearchFetch SlowLogThresholds // 搜索 fetch 阶段阈值
+	Indexing    SlowLogThresholds // 索引写入阈值
+}
+
+// settings 将慢日志配置转换为索引设置
+func (c SlowLogConfig) settings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	c.SearchQuery.apply(settings, "index.search.slowlog.threshold.query")
+	c.SearchFetch.apply(settings, "index.search.slowlog.threshold.fetch")
+	c.Indexing.apply(settings, "index.indexing.slowlog.threshold.index")
+	return settings
+}
+
+// apply 将阈值写入设置，prefix 为设置项前缀
+func (t SlowLogThresholds) apply(settings map[string]interface{}, prefix string) {
+	levels := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"warn", t.Warn},
+		{"info", t.Info},
+		{"debug", t.Debug},
+		{"trace", t.Trace},
+	}
+	for _, level := range levels {
+		switch {
+		case level.value < 0:
+			settings[prefix+"."+level.name] = "-1"
+		case level.value > 0:
+			settings[prefix+"."+level.name] = formatKeepAlive(level.value)
+		}
+	}
+}
+
+// IndexStats 单个索引的使用统计
+type IndexStats struct {
+	UUID      string         `json:"uuid"`
+	Primaries IndexStatsData `json:"primaries"`
+	Total     IndexStatsData `json:"total"`
+}
+
+// IndexStatsData 索引统计指标
+type IndexStatsData struct {
+	Docs struct {
+		Count   int64 `json:"count"`
+		Deleted int64 `json:"deleted"`
+	} `json:"docs"`
+	Store struct {
+		SizeInBytes int64 `json:"size_in_bytes"`
+	} `json:"store"`
+	Indexing struct {
+		IndexTotal        int64 `json:"index_total"`
+		IndexTimeInMillis int64 `json:"index_time_in_millis"`
+		IndexFailed       int64 `json:"index_failed"`
+		DeleteTotal       int64 `json:"delete_total"`
+	} `json:"indexing"`
+	Search struct {
+		OpenContexts      int64 `json:"open_contexts"`
+		QueryTotal        int64 `json:"query_total"`
+		QueryTimeInMillis int64 `json:"query_time_in_millis"`
+		FetchTotal        int64 `json:"fetch_total"`
+		FetchTimeInMillis int64 `json:"fetch_time_in_millis"`
+		ScrollTotal       int64 `json:"scroll_total"`
+	} `json:"search"`
+	Refresh struct {
+		Total         int64 `json:"total"`
+		TotalInMillis int64 `json:"total_time_in_millis"`
+	} `json:"refresh"`
+	QueryCache struct {
+		MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+		HitCount          int64 `json:"hit_count"`
+		MissCount         int64 `json:"miss_count"`
+	} `json:"query_cache"`
+	RequestCache struct {
+		MemorySizeInBytes int64 `json:"memory_size_in_bytes"`
+		HitCount          int64 `json:"hit_count"`
+		MissCount         int64 `json:"miss_count"`
+	} `json:"request_cache"`
+}
+
+// PutIndexSettings 更新索引的动态设置
+func (c *ElasticsearchClient) PutIndexSettings(ctx context.Context, index string, settings map[string]interface{}) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	settingsBytes, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	req := esapi.IndicesPutSettingsRequest{
+		Index: []string{index},
+		Body:  strings.NewReader(string(settingsBytes)),
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return c.requestError("put index settings", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return c.responseError("put index settings", res)
+	}
+
+	return nil
+}
+
+// SetSlowLog 配置索引的搜索和写入慢日志阈值
+func (c *ElasticsearchClient) SetSlowLog(ctx context.Context, index string, config SlowLogConfig) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	settings := config.settings()
+	if len(settings) == 0 {
+		return fmt.Errorf("slow log config cannot be empty")
+	}
+	return c.PutIndexSettings(ctx, index, settings)
+}
+
+// IndexStats 获取索引的使用统计，metrics 为空时返回全部指标（如 "docs"、"search"、"indexing"）
+func (c *ElasticsearchClient) IndexStats(ctx context.Context, index string, metrics ...string) (map[string]*IndexStats, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	req := esapi.IndicesStatsRequest{
+		Index:  []string{index},
+		Metric: metrics,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("get index stats", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("index stats", res)
+	}
+
+	var result struct {
+		Indices map[string]*IndexStats `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Indices, nil
+}
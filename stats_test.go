@@ -0,0 +1,77 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSlowLogConfig_Settings(t *testing.T) {
+	cfg := SlowLogConfig{
+		SearchQuery: SlowLogThresholds{Warn: 10 * time.Second, Info: 500 * time.Millisecond},
+		Indexing:    SlowLogThresholds{Warn: -1},
+	}
+
+	settings := cfg.settings()
+	want := map[string]interface{}{
+		"index.search.slowlog.threshold.query.warn":   "10s",
+		"index.search.slowlog.threshold.query.info":   "500ms",
+		"index.indexing.slowlog.threshold.index.warn": "-1",
+	}
+	if len(settings) != len(want) {
+		t.Fatalf("settings() = %v, want %v", settings, want)
+	}
+	for k, v := range want {
+		if settings[k] != v {
+			t.Errorf("settings()[%s] = %v, want %v", k, settings[k], v)
+		}
+	}
+}
+
+func TestSetSlowLog(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/test-index/_settings" {
+			json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+
+	err := client.SetSlowLog(context.Background(), "test-index", SlowLogConfig{
+		SearchFetch: SlowLogThresholds{Debug: 2 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("SetSlowLog() error = %v", err)
+	}
+	if got["index.search.slowlog.threshold.fetch.debug"] != "2s" {
+		t.Errorf("SetSlowLog() body = %v", got)
+	}
+
+	if err := client.SetSlowLog(context.Background(), "test-index", SlowLogConfig{}); err == nil {
+		t.Error("SetSlowLog() with empty config should return error")
+	}
+}
+
+func TestIndexStats(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/test-index/_stats/docs,search" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"indices":{"test-index":{"uuid":"abc","primaries":{"docs":{"count":10}},"total":{"docs":{"count":20,"deleted":1},"search":{"query_total":42,"query_time_in_millis":100}}}}}`))
+		}
+	})
+
+	stats, err := client.IndexStats(context.Background(), "test-index", "docs", "search")
+	if err != nil {
+		t.Fatalf("IndexStats() error = %v", err)
+	}
+	s := stats["test-index"]
+	if s == nil {
+		t.Fatal("IndexStats() missing test-index")
+	}
+	if s.Primaries.Docs.Count != 10 || s.Total.Docs.Count != 20 || s.Total.Search.QueryTotal != 42 {
+		t.Errorf("IndexStats() = %+v", s)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// synonymPageSize 分页读取同义词集合时的每页规则数
+const synonymPageSize = 1000
+
+// SynonymRule 同义词规则，Synonyms 为 Solr 格式，如 "laptop, notebook" 或 "ipod => apple ipod"
+type SynonymRule struct {
+	ID       string `json:"id,omitempty"` // 为空时由服务端生成
+	Synonyms string `json:"synonyms"`
+}
+
+// SynonymGraphFilter 返回引用同义词集合的 synonym_graph 过滤器定义，放入 analysis.filter 后
+// 在 search_analyzer 中使用，同义词集合更新后无需重建索引即可生效
+func SynonymGraphFilter(setID string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":         "synonym_graph",
+		"synonyms_set": setID,
+		"updateable":   true,
+	}
+}
+
+// PutSynonymSet 创建或替换同义词集合，使用该集合的搜索分析器会自动重新加载
+func (c *ElasticsearchClient) PutSynonymSet(ctx context.Context, setID string, rules []SynonymRule) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"synonyms_set": rules})
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonym set: %w", err)
+	}
+	return c.resourceRequest(ctx, "put synonym set", setID, esapi.SynonymsPutSynonymRequest{
+		DocumentID: setID,
+		Body:       strings.NewReader(string(body)),
+	}, nil)
+}
+
+// GetSynonymSet 获取同义词集合的全部规则，集合不存在时返回的错误满足 IsNotFound
+func (c *ElasticsearchClient) GetSynonymSet(ctx context.Context, setID string) ([]SynonymRule, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var rules []SynonymRule
+	for {
+		from, size := len(rules), synonymPageSize
+		var page struct {
+			Count int           `json:"count"`
+			Rules []SynonymRule `json:"synonyms_set"`
+		}
+		req := esapi.SynonymsGetSynonymRequest{DocumentID: setID, From: &from, Size: &size}
+		if err := c.resourceRequest(ctx, "get synonym set", setID, req, &page); err != nil {
+			return nil, err
+		}
+		rules = append(rules, page.Rules...)
+		if len(page.Rules) == 0 || len(rules) >= page.Count {
+			return rules, nil
+		}
+	}
+}
+
+// DeleteSynonymSet 删除同义词集合，仍被索引引用时删除失败
+func (c *ElasticsearchClient) DeleteSynonymSet(ctx context.Context, setID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.resourceRequest(ctx, "delete synonym set", setID, esapi.SynonymsDeleteSynonymRequest{DocumentID: setID}, nil)
+}
+
+// PutSynonymRule 在同义词集合中创建或替换单条规则
+func (c *ElasticsearchClient) PutSynonymRule(ctx context.Context, setID string, rule SynonymRule) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if rule.ID == "" {
+		return fmt.Errorf("synonym rule ID cannot be empty")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"synonyms": rule.Synonyms})
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonym rule: %w", err)
+	}
+	return c.resourceRequest(ctx, "put synonym rule", setID, esapi.SynonymsPutSynonymRuleRequest{
+		SetID:  setID,
+		RuleID: rule.ID,
+		Body:   strings.NewReader(string(body)),
+	}, nil)
+}
+
+// DeleteSynonymRule 删除同义词集合中的单条规则
+func (c *ElasticsearchClient) DeleteSynonymRule(ctx context.Context, setID string, ruleID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+	if ruleID == "" {
+		return fmt.Errorf("synonym rule ID cannot be empty")
+	}
+
+	return c.resourceRequest(ctx, "delete synonym rule", setID, esapi.SynonymsDeleteSynonymRuleRequest{
+		SetID:  setID,
+		RuleID: ruleID,
+	}, nil)
+}
+
+// ReloadSearchAnalyzers 重新加载索引中可更新（updateable）的搜索分析器，用于基于文件的同义词更新后生效，
+// 返回每个索引重新加载的分析器名称
+func (c *ElasticsearchClient) ReloadSearchAnalyzers(ctx context.Context, index string) (map[string][]string, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var result struct {
+		ReloadDetails []struct {
+			Index             string   `json:"index"`
+			ReloadedAnalyzers []string `json:"reloaded_analyzers"`
+		} `json:"reload_details"`
+	}
+	req := esapi.IndicesReloadSearchAnalyzersRequest{Index: []string{index}}
+	if err := c.resourceRequest(ctx, "reload search analyzers", index, req, &result); err != nil {
+		return nil, err
+	}
+
+	reloaded := make(map[string][]string, len(result.ReloadDetails))
+	for _, detail := range result.ReloadDetails {
+		reloaded[detail.Index] = detail.ReloadedAnalyzers
+	}
+	return reloaded, nil
+}
@@ -0,0 +1,85 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSynonymSets(t *testing.T) {
+	bodies := map[string]string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.Method+" "+r.URL.Path] = string(body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/_synonyms/products":
+			// 模拟 1001 条规则，需要分两页读取
+			from := r.URL.Query().Get("from")
+			count := 1000
+			if from == "1000" {
+				count = 1
+			}
+			rules := make([]string, count)
+			for i := range rules {
+				rules[i] = fmt.Sprintf(`{"id":"r%s-%d","synonyms":"a, b"}`, from, i)
+			}
+			fmt.Fprintf(w, `{"count":1001,"synonyms_set":[%s]}`, strings.Join(rules, ","))
+		case r.URL.Path == "/orders/_reload_search_analyzers":
+			w.Write([]byte(`{"_shards":{"total":2,"successful":2,"failed":0},"reload_details":[{"index":"orders","reloaded_analyzers":["search_synonyms"],"reloaded_node_ids":["n1"]}]}`))
+		default:
+			w.Write([]byte(`{"result":"created","reload_analyzers_details":{}}`))
+		}
+	})
+	ctx := context.Background()
+
+	err := client.PutSynonymSet(ctx, "products", []SynonymRule{
+		{ID: "laptop", Synonyms: "laptop, notebook"},
+		{Synonyms: "ipod => apple ipod"},
+	})
+	if err != nil {
+		t.Fatalf("PutSynonymSet() error = %v", err)
+	}
+	want := `{"synonyms_set":[{"id":"laptop","synonyms":"laptop, notebook"},{"synonyms":"ipod =\u003e apple ipod"}]}`
+	if got := bodies["PUT /_synonyms/products"]; got != want {
+		t.Errorf("set body = %s", got)
+	}
+
+	rules, err := client.GetSynonymSet(ctx, "products")
+	if err != nil || len(rules) != 1001 || rules[1000].ID != "r1000-0" {
+		t.Fatalf("GetSynonymSet() = %d rules, %v", len(rules), err)
+	}
+
+	if err := client.PutSynonymRule(ctx, "products", SynonymRule{ID: "tv", Synonyms: "tv, television"}); err != nil {
+		t.Fatalf("PutSynonymRule() error = %v", err)
+	}
+	if got := bodies["PUT /_synonyms/products/tv"]; got != `{"synonyms":"tv, television"}` {
+		t.Errorf("rule body = %s", got)
+	}
+	if err := client.PutSynonymRule(ctx, "products", SynonymRule{Synonyms: "a, b"}); err == nil {
+		t.Error("PutSynonymRule() without ID should fail")
+	}
+	if err := client.DeleteSynonymRule(ctx, "products", "tv"); err != nil {
+		t.Fatalf("DeleteSynonymRule() error = %v", err)
+	}
+	if err := client.DeleteSynonymSet(ctx, "products"); err != nil {
+		t.Fatalf("DeleteSynonymSet() error = %v", err)
+	}
+	for _, key := range []string{"DELETE /_synonyms/products/tv", "DELETE /_synonyms/products"} {
+		if _, ok := bodies[key]; !ok {
+			t.Errorf("missing request %s", key)
+		}
+	}
+
+	reloaded, err := client.ReloadSearchAnalyzers(ctx, "orders")
+	if err != nil || len(reloaded["orders"]) != 1 || reloaded["orders"][0] != "search_synonyms" {
+		t.Errorf("ReloadSearchAnalyzers() = %v, %v", reloaded, err)
+	}
+
+	if filter := SynonymGraphFilter("products"); filter["synonyms_set"] != "products" || filter["updateable"] != true {
+		t.Errorf("SynonymGraphFilter() = %v", filter)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// operationTagPrefix 业务标签在 span 属性中的前缀
+const operationTagPrefix = "elasticsearch.tag."
+
+// operationTagsKey 业务标签在上下文中的键
+type operationTagsKey struct{}
+
+// operationTag 单个业务标签
+type operationTag struct {
+	key   string
+	value string
+}
+
+// WithOperationTag 在上下文中附加业务标签（如功能名、任务 ID），
+// 之后使用该上下文的所有 Elasticsearch 操作都会将标签写入 span 属性和日志。同名标签后设置的生效
+func WithOperationTag(ctx context.Context, key string, value string) context.Context {
+	existing := operationTags(ctx)
+	tags := make([]operationTag, 0, len(existing)+1)
+	for _, tag := range existing {
+		if tag.key != key {
+			tags = append(tags, tag)
+		}
+	}
+	tags = append(tags, operationTag{key: key, value: value})
+	return context.WithValue(ctx, operationTagsKey{}, tags)
+}
+
+// OperationTags 返回上下文中的业务标签
+func OperationTags(ctx context.Context) map[string]string {
+	tags := operationTags(ctx)
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.key] = tag.value
+	}
+	return result
+}
+
+// operationTags 返回上下文中的业务标签列表
+func operationTags(ctx context.Context) []operationTag {
+	tags, _ := ctx.Value(operationTagsKey{}).([]operationTag)
+	return tags
+}
+
+// operationTagFields 将业务标签转换为日志字段和 span 属性
+func operationTagFields(ctx context.Context) ([]zap.Field, []attribute.KeyValue) {
+	tags := operationTags(ctx)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	fields := make([]zap.Field, 0, len(tags))
+	attrs := make([]attribute.KeyValue, 0, len(tags))
+	for _, tag := range tags {
+		fields = append(fields, zap.String(tag.key, tag.value))
+		attrs = append(attrs, attribute.String(operationTagPrefix+tag.key, tag.value))
+	}
+	return fields, attrs
+}
@@ -0,0 +1,31 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithOperationTag(t *testing.T) {
+	base := WithOperationTag(context.Background(), "feature", "checkout")
+	ctx := WithOperationTag(base, "job_id", "42")
+	ctx = WithOperationTag(ctx, "feature", "refund")
+
+	tags := OperationTags(ctx)
+	if len(tags) != 2 || tags["feature"] != "refund" || tags["job_id"] != "42" {
+		t.Errorf("OperationTags() = %v", tags)
+	}
+	if parent := OperationTags(base); parent["feature"] != "checkout" || len(parent) != 1 {
+		t.Errorf("parent OperationTags() = %v, should not be modified", parent)
+	}
+	if OperationTags(context.Background()) != nil {
+		t.Error("OperationTags() without tags should be nil")
+	}
+
+	fields, attrs := operationTagFields(ctx)
+	if len(fields) != 2 || len(attrs) != 2 {
+		t.Fatalf("operationTagFields() = %d fields, %d attrs", len(fields), len(attrs))
+	}
+	if string(attrs[1].Key) != "elasticsearch.tag.feature" || attrs[1].Value.AsString() != "refund" {
+		t.Errorf("attrs[1] = %v", attrs[1])
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// taskPollInterval 等待任务完成时的轮询间隔
+const taskPollInterval = time.Second
+
+// TaskStatus 后台任务（如 reindex、update_by_query）的状态
+type TaskStatus struct {
+	TaskID           string
+	Action           string // 任务类型，如 indices:data/write/reindex
+	Completed        bool
+	Total            int64 // 需要处理的文档总数
+	Created          int64
+	Updated          int64
+	Deleted          int64
+	VersionConflicts int64
+	Noops            int64
+	Failures         int           // 完成后写入失败的文档数
+	Running          time.Duration // 已运行时间
+	Err              error         // 任务本身失败时的错误，为 *Error
+}
+
+// Progress 返回已处理文档的比例（0~1），总数未知时返回 0
+func (s *TaskStatus) Progress() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Created+s.Updated+s.Deleted+s.VersionConflicts+s.Noops) / float64(s.Total)
+}
+
+// GetTask 查询后台任务的当前状态
+func (c *ElasticsearchClient) GetTask(ctx context.Context, taskID string) (*TaskStatus, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	return c.getTask(ctx, taskID)
+}
+
+// WaitForTask 轮询后台任务直到完成或超时（timeout 为 0 时只受 ctx 限制）。
+// 任务失败或存在写入失败的文档时返回状态和错误
+func (c *ElasticsearchClient) WaitForTask(ctx context.Context, taskID string, timeout time.Duration) (*TaskStatus, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		status, err := c.getTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if status.Completed {
+			if status.Err != nil {
+				return status, status.Err
+			}
+			if status.Failures > 0 {
+				return status, fmt.Errorf("task %s completed with %d failures", taskID, status.Failures)
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("timed out waiting for task %s: %w", taskID, ctx.Err())
+		case <-time.After(taskPollInterval):
+		}
+	}
+}
+
+// getTask 内部查询任务状态方法
+func (c *ElasticsearchClient) getTask(ctx context.Context, taskID string) (*TaskStatus, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("task ID cannot be empty")
+	}
+
+	res, err := esapi.TasksGetRequest{TaskID: taskID}.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("get task", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("get task", res)
+	}
+
+	var result struct {
+		Completed bool `json:"completed"`
+		Task      struct {
+			Action             string       `json:"action"`
+			RunningTimeInNanos int64        `json:"running_time_in_nanos"`
+			Status             taskCounters `json:"status"`
+		} `json:"task"`
+		Response *struct {
+			taskCounters
+			Failures []json.RawMessage `json:"failures"`
+		} `json:"response"`
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	counters := result.Task.Status
+	status := &TaskStatus{
+		TaskID:    taskID,
+		Action:    result.Task.Action,
+		Completed: result.Completed,
+		Running:   time.Duration(result.Task.RunningTimeInNanos),
+	}
+	if result.Response != nil {
+		counters = result.Response.taskCounters
+		status.Failures = len(result.Response.Failures)
+	}
+	status.Total = counters.Total
+	status.Created = counters.Created
+	status.Updated = counters.Updated
+	status.Deleted = counters.Deleted
+	status.VersionConflicts = counters.VersionConflicts
+	status.Noops = counters.Noops
+	if len(result.Error) > 0 {
+		status.Err = c.itemError("task", http.StatusInternalServerError, result.Error)
+	}
+	return status, nil
+}
+
+// taskCounters 任务状态和结果中的文档计数
+type taskCounters struct {
+	Total            int64 `json:"total"`
+	Created          int64 `json:"created"`
+	Updated          int64 `json:"updated"`
+	Deleted          int64 `json:"deleted"`
+	VersionConflicts int64 `json:"version_conflicts"`
+	Noops            int64 `json:"noops"`
+}
+
+// TaskInfo 正在运行的任务信息
+type TaskInfo struct {
+	TaskID       string          `json:"-"` // 节点 ID:任务编号，可用于 GetTask、CancelTask
+	Node         string          `json:"node"`
+	ID           int64           `json:"id"`
+	Type         string          `json:"type"`
+	Action       string          `json:"action"`      // 如 indices:data/write/reindex
+	Description  string          `json:"description"` // 仅在 Detailed 时返回
+	StartTime    int64           `json:"start_time_in_millis"`
+	RunningNanos int64           `json:"running_time_in_nanos"`
+	Cancellable  bool            `json:"cancellable"`
+	Cancelled    bool            `json:"cancelled"`
+	ParentTaskID string          `json:"parent_task_id"`
+	Status       json.RawMessage `json:"status"` // 任务类型相关的进度信息
+}
+
+// Running 返回任务已运行的时间
+func (t *TaskInfo) Running() time.Duration {
+	return time.Duration(t.RunningNanos)
+}
+
+// Started 返回任务开始的时间
+func (t *TaskInfo) Started() time.Time {
+	return time.UnixMilli(t.StartTime)
+}
+
+// ListTasksOptions 任务列表的过滤条件
+type ListTasksOptions struct {
+	Actions      []string // 任务类型，支持通配符，如 *reindex、indices:data/write/*
+	Nodes        []string // 节点 ID 或名称
+	ParentTaskID string   // 只返回该任务的子任务
+	Detailed     bool     // 返回任务描述（如 reindex 的源和目标索引）
+}
+
+// ListTasks 列出集群中正在运行的任务，按开始时间排序
+func (c *ElasticsearchClient) ListTasks(ctx context.Context, opts *ListTasksOptions) ([]TaskInfo, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ListTasksOptions{}
+	}
+
+	req := esapi.TasksListRequest{
+		Actions:      opts.Actions,
+		Nodes:        opts.Nodes,
+		ParentTaskID: opts.ParentTaskID,
+		Detailed:     &opts.Detailed,
+		GroupBy:      "none",
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("list tasks", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("list tasks", res)
+	}
+
+	var result struct {
+		Tasks []TaskInfo `json:"tasks"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for i := range result.Tasks {
+		task := &result.Tasks[i]
+		task.TaskID = fmt.Sprintf("%s:%d", task.Node, task.ID)
+	}
+	sort.SliceStable(result.Tasks, func(i, j int) bool {
+		return result.Tasks[i].StartTime < result.Tasks[j].StartTime
+	})
+	return result.Tasks, nil
+}
+
+// CancelTask 请求取消任务（需为可取消的任务，如 reindex、update_by_query、delete_by_query），
+// 取消是异步的，已处理的文档不会回滚
+func (c *ElasticsearchClient) CancelTask(ctx context.Context, taskID string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	var result struct {
+		NodeFailures []json.RawMessage `json:"node_failures"`
+	}
+	if err := c.resourceRequest(ctx, "cancel task", taskID, esapi.TasksCancelRequest{TaskID: taskID}, &result); err != nil {
+		return err
+	}
+	if len(result.NodeFailures) > 0 {
+		return c.itemError("cancel task", http.StatusInternalServerError, result.NodeFailures[0])
+	}
+	return nil
+}
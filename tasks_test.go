@@ -0,0 +1,64 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListTasks(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_tasks" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"tasks":[
+			{"node":"n1","id":9,"type":"transport","action":"indices:data/write/reindex","description":"reindex from [a] to [b]","start_time_in_millis":2000,"running_time_in_nanos":3000000000,"cancellable":true,"status":{"total":10}},
+			{"node":"n2","id":4,"type":"transport","action":"indices:data/write/update/byquery","start_time_in_millis":1000,"running_time_in_nanos":5,"cancellable":true,"parent_task_id":"n1:1"}
+		]}`))
+	})
+
+	tasks, err := client.ListTasks(context.Background(), &ListTasksOptions{Actions: []string{"*reindex", "*byquery"}, Detailed: true})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	for _, want := range []string{"group_by=none", "detailed=true", "actions=%2Areindex%2C%2Abyquery"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query = %s, want %s", query, want)
+		}
+	}
+	if len(tasks) != 2 || tasks[0].TaskID != "n2:4" || tasks[1].TaskID != "n1:9" {
+		t.Fatalf("tasks = %+v", tasks)
+	}
+	if task := tasks[1]; task.Running() != 3*time.Second || !task.Started().Equal(time.UnixMilli(2000)) || task.Description == "" || len(task.Status) == 0 {
+		t.Errorf("tasks[1] = %+v", task)
+	}
+	if tasks[0].ParentTaskID != "n1:1" {
+		t.Errorf("tasks[0] = %+v", tasks[0])
+	}
+}
+
+func TestCancelTask(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_tasks/n1:9/_cancel":
+			w.Write([]byte(`{"nodes":{"n1":{"tasks":{"n1:9":{"action":"indices:data/write/reindex","cancelled":true}}}}}`))
+		default:
+			w.Write([]byte(`{"node_failures":[{"type":"failed_node_exception","reason":"Failed node [n3]"}]}`))
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.CancelTask(ctx, "n1:9"); err != nil {
+		t.Fatalf("CancelTask() error = %v", err)
+	}
+	if err := client.CancelTask(ctx, "n3:1"); err == nil || !strings.Contains(err.Error(), "failed_node_exception") {
+		t.Errorf("CancelTask() error = %v, want node failure", err)
+	}
+	if err := client.CancelTask(ctx, ""); err == nil {
+		t.Error("CancelTask(\"\") should fail")
+	}
+}
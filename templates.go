@@ -0,0 +1,237 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// IndexTemplate 可组合索引模板，创建匹配 IndexPatterns 的索引时自动应用
+type IndexTemplate struct {
+	IndexPatterns []string               // 匹配的索引名模式，如 logs-*
+	ComposedOf    []string               // 按顺序合并的组件模板名称
+	Priority      int                    // 多个模板匹配同一索引时优先级高者生效
+	Template      *IndexSpec             // 索引设置、映射和别名，优先于组件模板
+	Version       int64                  // 模板版本，便于判断是否需要更新
+	Meta          map[string]interface{} // 自定义元数据
+	DataStream    bool                   // 匹配的名称创建为数据流
+}
+
+// body 转换为请求体
+func (t *IndexTemplate) body() map[string]interface{} {
+	body := map[string]interface{}{"index_patterns": t.IndexPatterns}
+	if len(t.ComposedOf) > 0 {
+		body["composed_of"] = t.ComposedOf
+	}
+	if t.Priority != 0 {
+		body["priority"] = t.Priority
+	}
+	if t.Template != nil {
+		body["template"] = t.Template.body()
+	}
+	if t.Version != 0 {
+		body["version"] = t.Version
+	}
+	if t.Meta != nil {
+		body["_meta"] = t.Meta
+	}
+	if t.DataStream {
+		body["data_stream"] = map[string]interface{}{}
+	}
+	return body
+}
+
+// ComponentTemplate 组件模板，可被多个索引模板通过 ComposedOf 复用
+type ComponentTemplate struct {
+	Template *IndexSpec             // 索引设置、映射和别名
+	Version  int64                  // 模板版本
+	Meta     map[string]interface{} // 自定义元数据
+}
+
+// body 转换为请求体
+func (t *ComponentTemplate) body() map[string]interface{} {
+	body := map[string]interface{}{"template": t.Template.body()}
+	if t.Version != 0 {
+		body["version"] = t.Version
+	}
+	if t.Meta != nil {
+		body["_meta"] = t.Meta
+	}
+	return body
+}
+
+// templateSection 模板响应中 template 字段的结构
+type templateSection struct {
+	Settings map[string]interface{} `json:"settings"`
+	Mappings map[string]interface{} `json:"mappings"`
+	Aliases  map[string]interface{} `json:"aliases"`
+}
+
+// spec 转换为 IndexSpec
+func (t *templateSection) spec() *IndexSpec {
+	if t == nil {
+		return nil
+	}
+	return &IndexSpec{Settings: t.Settings, Mappings: t.Mappings, Aliases: t.Aliases}
+}
+
+// templateBody 序列化模板，template 可为 *IndexTemplate、*ComponentTemplate 或 map、JSON 字符串等原始请求体
+func templateBody(template interface{}) (string, error) {
+	switch t := template.(type) {
+	case nil:
+		return "", fmt.Errorf("template cannot be nil")
+	case *IndexTemplate:
+		template = t.body()
+	case IndexTemplate:
+		template = t.body()
+	case *ComponentTemplate:
+		template = t.body()
+	case ComponentTemplate:
+		template = t.body()
+	}
+	body, err := marshalDocument(template)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// PutIndexTemplate 创建或更新索引模板，template 可为 *IndexTemplate 或 map 等原始请求体
+func (c *ElasticsearchClient) PutIndexTemplate(ctx context.Context, name string, template interface{}) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	body, err := templateBody(template)
+	if err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "put index template", name, esapi.IndicesPutIndexTemplateRequest{
+		Name: name,
+		Body: strings.NewReader(body),
+	}, nil)
+}
+
+// GetIndexTemplate 获取索引模板，模板不存在时返回的错误满足 IsNotFound
+func (c *ElasticsearchClient) GetIndexTemplate(ctx context.Context, name string) (*IndexTemplate, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		IndexTemplates []struct {
+			Name          string `json:"name"`
+			IndexTemplate struct {
+				IndexPatterns []string               `json:"index_patterns"`
+				ComposedOf    []string               `json:"composed_of"`
+				Priority      int                    `json:"priority"`
+				Template      *templateSection       `json:"template"`
+				Version       int64                  `json:"version"`
+				Meta          map[string]interface{} `json:"_meta"`
+				DataStream    json.RawMessage        `json:"data_stream"`
+			} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := c.resourceRequest(ctx, "get index template", name, esapi.IndicesGetIndexTemplateRequest{Name: name}, &result); err != nil {
+		return nil, err
+	}
+	if len(result.IndexTemplates) == 0 {
+		return nil, fmt.Errorf("index template %w", ErrNotFound)
+	}
+
+	t := result.IndexTemplates[0].IndexTemplate
+	return &IndexTemplate{
+		IndexPatterns: t.IndexPatterns,
+		ComposedOf:    t.ComposedOf,
+		Priority:      t.Priority,
+		Template:      t.Template.spec(),
+		Version:       t.Version,
+		Meta:          t.Meta,
+		DataStream:    len(t.DataStream) > 0 && string(t.DataStream) != "null",
+	}, nil
+}
+
+// DeleteIndexTemplate 删除索引模板
+func (c *ElasticsearchClient) DeleteIndexTemplate(ctx context.Context, name string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.resourceRequest(ctx, "delete index template", name, esapi.IndicesDeleteIndexTemplateRequest{Name: name}, nil)
+}
+
+// PutComponentTemplate 创建或更新组件模板，template 可为 *ComponentTemplate 或 map 等原始请求体
+func (c *ElasticsearchClient) PutComponentTemplate(ctx context.Context, name string, template interface{}) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	body, err := templateBody(template)
+	if err != nil {
+		return err
+	}
+	return c.resourceRequest(ctx, "put component template", name, esapi.ClusterPutComponentTemplateRequest{
+		Name: name,
+		Body: strings.NewReader(body),
+	}, nil)
+}
+
+// GetComponentTemplate 获取组件模板，模板不存在时返回的错误满足 IsNotFound
+func (c *ElasticsearchClient) GetComponentTemplate(ctx context.Context, name string) (*ComponentTemplate, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ComponentTemplates []struct {
+			Name              string `json:"name"`
+			ComponentTemplate struct {
+				Template *templateSection       `json:"template"`
+				Version  int64                  `json:"version"`
+				Meta     map[string]interface{} `json:"_meta"`
+			} `json:"component_template"`
+		} `json:"component_templates"`
+	}
+	req := esapi.ClusterGetComponentTemplateRequest{Name: []string{name}}
+	if err := c.resourceRequest(ctx, "get component template", name, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result.ComponentTemplates) == 0 {
+		return nil, fmt.Errorf("component template %w", ErrNotFound)
+	}
+
+	t := result.ComponentTemplates[0].ComponentTemplate
+	return &ComponentTemplate{
+		Template: t.Template.spec(),
+		Version:  t.Version,
+		Meta:     t.Meta,
+	}, nil
+}
+
+// DeleteComponentTemplate 删除组件模板，仍被索引模板引用时删除失败
+func (c *ElasticsearchClient) DeleteComponentTemplate(ctx context.Context, name string) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	return c.resourceRequest(ctx, "delete component template", name, esapi.ClusterDeleteComponentTemplateRequest{Name: name}, nil)
+}
@@ -0,0 +1,94 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIndexTemplates(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[key] = body
+		switch key {
+		case "GET /_index_template/logs":
+			w.Write([]byte(`{"index_templates":[{"name":"logs","index_template":{
+				"index_patterns":["logs-*"],"composed_of":["logs-mappings"],"priority":200,"version":3,
+				"template":{"settings":{"index":{"number_of_shards":"1"}}},"data_stream":{}}}]}`))
+		case "GET /_component_template/logs-mappings":
+			w.Write([]byte(`{"component_templates":[{"name":"logs-mappings","component_template":{
+				"template":{"mappings":{"properties":{"message":{"type":"text"}}}},"version":2}}]}`))
+		case "GET /_index_template/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"resource_not_found_exception","reason":"index template matching [missing] not found"},"status":404}`))
+		default:
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+	ctx := context.Background()
+
+	err := client.PutComponentTemplate(ctx, "logs-mappings", &ComponentTemplate{
+		Template: &IndexSpec{Mappings: map[string]interface{}{"properties": map[string]interface{}{"message": map[string]interface{}{"type": "text"}}}},
+		Version:  2,
+	})
+	if err != nil {
+		t.Fatalf("PutComponentTemplate() error = %v", err)
+	}
+	if body := bodies["PUT /_component_template/logs-mappings"]; body["version"] != float64(2) || body["template"].(map[string]interface{})["mappings"] == nil {
+		t.Errorf("component template body = %v", body)
+	}
+
+	err = client.PutIndexTemplate(ctx, "logs", &IndexTemplate{
+		IndexPatterns: []string{"logs-*"},
+		ComposedOf:    []string{"logs-mappings"},
+		Priority:      200,
+		Template:      &IndexSpec{Settings: map[string]interface{}{"number_of_shards": 1}},
+		DataStream:    true,
+	})
+	if err != nil {
+		t.Fatalf("PutIndexTemplate() error = %v", err)
+	}
+	body := bodies["PUT /_index_template/logs"]
+	if body["priority"] != float64(200) || body["data_stream"] == nil || len(body["composed_of"].([]interface{})) != 1 {
+		t.Errorf("index template body = %v", body)
+	}
+
+	if err := client.PutIndexTemplate(ctx, "raw", map[string]interface{}{"index_patterns": []string{"raw-*"}}); err != nil {
+		t.Fatalf("PutIndexTemplate(map) error = %v", err)
+	}
+	if patterns, _ := bodies["PUT /_index_template/raw"]["index_patterns"].([]interface{}); len(patterns) != 1 {
+		t.Errorf("raw template body = %v", bodies["PUT /_index_template/raw"])
+	}
+	if err := client.PutIndexTemplate(ctx, "nil", nil); err == nil {
+		t.Error("PutIndexTemplate(nil) should fail")
+	}
+
+	template, err := client.GetIndexTemplate(ctx, "logs")
+	if err != nil {
+		t.Fatalf("GetIndexTemplate() error = %v", err)
+	}
+	if template.Priority != 200 || template.Version != 3 || !template.DataStream || template.IndexPatterns[0] != "logs-*" || template.Template.Settings == nil {
+		t.Errorf("GetIndexTemplate() = %+v", template)
+	}
+	component, err := client.GetComponentTemplate(ctx, "logs-mappings")
+	if err != nil || component.Version != 2 || component.Template.Mappings == nil {
+		t.Errorf("GetComponentTemplate() = %+v, %v", component, err)
+	}
+	if _, err := client.GetIndexTemplate(ctx, "missing"); !IsNotFound(err) {
+		t.Errorf("GetIndexTemplate(missing) error = %v, want not found", err)
+	}
+
+	if err := client.DeleteIndexTemplate(ctx, "logs"); err != nil {
+		t.Fatalf("DeleteIndexTemplate() error = %v", err)
+	}
+	if err := client.DeleteComponentTemplate(ctx, "logs-mappings"); err != nil {
+		t.Fatalf("DeleteComponentTemplate() error = %v", err)
+	}
+	if _, ok := bodies["DELETE /_component_template/logs-mappings"]; !ok {
+		t.Error("DeleteComponentTemplate() did not send a request")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timeSeriesAggregationName 时间序列便捷方法内部使用的聚合名称
+const timeSeriesAggregationName = "time_series"
+
+// calendarIntervals date_histogram 支持的日历间隔，其余间隔（如 5m、12h）按固定间隔处理
+var calendarIntervals = map[string]bool{
+	"minute": true, "1m": true,
+	"hour": true, "1h": true,
+	"day": true, "1d": true,
+	"week": true, "1w": true,
+	"month": true, "1M": true,
+	"quarter": true, "1q": true,
+	"year": true, "1y": true,
+}
+
+// Metric 时间序列每个分桶内计算的单值指标
+type Metric struct {
+	Name        string // 结果中 Metrics 的键
+	Aggregation string // 指标聚合类型（avg、sum、min、max、cardinality 等）
+	Field       string // 计算指标的字段
+}
+
+// AvgMetric 字段平均值指标，名称为 avg_<field>
+func AvgMetric(field string) Metric {
+	return Metric{Name: "avg_" + field, Aggregation: "avg", Field: field}
+}
+
+// SumMetric 字段求和指标，名称为 sum_<field>
+func SumMetric(field string) Metric {
+	return Metric{Name: "sum_" + field, Aggregation: "sum", Field: field}
+}
+
+// MinMetric 字段最小值指标，名称为 min_<field>
+func MinMetric(field string) Metric {
+	return Metric{Name: "min_" + field, Aggregation: "min", Field: field}
+}
+
+// MaxMetric 字段最大值指标，名称为 max_<field>
+func MaxMetric(field string) Metric {
+	return Metric{Name: "max_" + field, Aggregation: "max", Field: field}
+}
+
+// CardinalityMetric 字段不同值数量指标，名称为 cardinality_<field>
+func CardinalityMetric(field string) Metric {
+	return Metric{Name: "cardinality_" + field, Aggregation: "cardinality", Field: field}
+}
+
+// Bucket 时间序列的单个分桶
+type Bucket struct {
+	Start   time.Time          // 分桶起始时间
+	Count   int64              // 分桶内的文档数
+	Metrics map[string]float64 // 指标名称到值，分桶内无数据的指标值为 0
+}
+
+// TimeSeries 按时间间隔统计查询匹配文档的数量和指标，返回可直接用于绘图的分桶。
+// interval 为日历间隔（如 hour、1d、month）或固定间隔（如 5m、12h），中间没有数据的分桶也会返回
+func (c *ElasticsearchClient) TimeSeries(ctx context.Context, index string, dateField string, interval string, query map[string]interface{}, metrics ...Metric) ([]Bucket, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	if dateField == "" || interval == "" {
+		return nil, fmt.Errorf("time series requires a date field and an interval")
+	}
+
+	histogram := map[string]interface{}{
+		"field":         dateField,
+		"min_doc_count": 0,
+	}
+	if calendarIntervals[interval] {
+		histogram["calendar_interval"] = interval
+	} else {
+		histogram["fixed_interval"] = interval
+	}
+	agg := map[string]interface{}{"date_histogram": histogram}
+
+	if len(metrics) > 0 {
+		subAggs := make(map[string]interface{}, len(metrics))
+		for _, metric := range metrics {
+			if metric.Name == "" || metric.Aggregation == "" || metric.Field == "" {
+				return nil, fmt.Errorf("metric requires a name, aggregation and field")
+			}
+			if _, ok := subAggs[metric.Name]; ok {
+				return nil, fmt.Errorf("duplicate metric name %s", metric.Name)
+			}
+			subAggs[metric.Name] = map[string]interface{}{
+				metric.Aggregation: map[string]interface{}{"field": metric.Field},
+			}
+		}
+		agg["aggregations"] = subAggs
+	}
+
+	aggs, err := c.searchAggregations(ctx, index, query, map[string]interface{}{timeSeriesAggregationName: agg})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Buckets []map[string]json.RawMessage `json:"buckets"`
+	}
+	if err := aggs.Decode(timeSeriesAggregationName, &result); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]Bucket, len(result.Buckets))
+	for i, raw := range result.Buckets {
+		var key, count int64
+		if err := json.Unmarshal(raw["key"], &key); err != nil {
+			return nil, fmt.Errorf("failed to decode bucket key: %w", err)
+		}
+		if err := json.Unmarshal(raw["doc_count"], &count); err != nil {
+			return nil, fmt.Errorf("failed to decode bucket doc_count: %w", err)
+		}
+		bucket := Bucket{
+			Start:   time.UnixMilli(key).UTC(),
+			Count:   count,
+			Metrics: make(map[string]float64, len(metrics)),
+		}
+		for _, metric := range metrics {
+			value, err := Aggregations(raw).Value(metric.Name)
+			if err != nil {
+				return nil, err
+			}
+			bucket.Metrics[metric.Name] = value
+		}
+		buckets[i] = bucket
+	}
+	return buckets, nil
+}
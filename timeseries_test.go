@@ -0,0 +1,55 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTimeSeries(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"aggregations":{"time_series":{"buckets":[
+			{"key":1735689600000,"key_as_string":"2025-01-01T00:00:00Z","doc_count":3,"avg_amount":{"value":12.5},"sum_amount":{"value":37.5}},
+			{"key":1735693200000,"key_as_string":"2025-01-01T01:00:00Z","doc_count":0,"avg_amount":{"value":null},"sum_amount":{"value":0}}
+		]}}}`))
+	})
+
+	buckets, err := client.TimeSeries(context.Background(), "orders", "created_at", "hour", nil, AvgMetric("amount"), SumMetric("amount"))
+	if err != nil {
+		t.Fatalf("TimeSeries() error = %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("TimeSeries() = %d buckets, want 2", len(buckets))
+	}
+	if !buckets[0].Start.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || buckets[0].Count != 3 {
+		t.Errorf("bucket[0] = %+v", buckets[0])
+	}
+	if buckets[0].Metrics["avg_amount"] != 12.5 || buckets[0].Metrics["sum_amount"] != 37.5 {
+		t.Errorf("bucket[0].Metrics = %v", buckets[0].Metrics)
+	}
+	if buckets[1].Metrics["avg_amount"] != 0 {
+		t.Errorf("bucket[1].Metrics = %v", buckets[1].Metrics)
+	}
+
+	histogram := body["aggregations"].(map[string]interface{})["time_series"].(map[string]interface{})["date_histogram"].(map[string]interface{})
+	if histogram["calendar_interval"] != "hour" || histogram["field"] != "created_at" {
+		t.Errorf("date_histogram = %v", histogram)
+	}
+
+	client.TimeSeries(context.Background(), "orders", "created_at", "5m", nil)
+	histogram = body["aggregations"].(map[string]interface{})["time_series"].(map[string]interface{})["date_histogram"].(map[string]interface{})
+	if histogram["fixed_interval"] != "5m" {
+		t.Errorf("date_histogram = %v, want fixed_interval", histogram)
+	}
+}
+
+func TestTimeSeriesInvalidMetric(t *testing.T) {
+	client := newTestClient(t, nil)
+	if _, err := client.TimeSeries(context.Background(), "orders", "created_at", "1d", nil, AvgMetric("a"), AvgMetric("a")); err == nil {
+		t.Error("TimeSeries(duplicate metrics) error = nil, want error")
+	}
+}
@@ -18,6 +18,9 @@ package elasticsearch
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"time"
 
 	"github.com/go-anyway/framework-log"
@@ -29,6 +32,47 @@ import (
 	"go.uber.org/zap"
 )
 
+// PanicError 操作执行过程中发生的 panic，由追踪包装器恢复并转换为错误
+type PanicError struct {
+	Value interface{} // panic 的值
+	Stack []byte      // panic 时的调用栈
+}
+
+// Error 实现 error 接口
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("elasticsearch operation panicked: %v", e.Value)
+}
+
+// callHandler 执行操作并将 panic 转换为 PanicError
+func callHandler(ctx context.Context, handler func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return handler(ctx)
+}
+
+// callQueryHandler 执行查询操作并将 panic 转换为 PanicError
+func callQueryHandler(ctx context.Context, handler func(context.Context) (map[string]interface{}, error)) (result map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return handler(ctx)
+}
+
+// panicFields 返回 panic 错误需要额外记录的日志字段和追踪属性
+func panicFields(err error) ([]zap.Field, []attribute.KeyValue) {
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		return nil, nil
+	}
+	return []zap.Field{zap.ByteString("stack", panicErr.Stack)},
+		[]attribute.KeyValue{attribute.String("exception.stacktrace", string(panicErr.Stack))}
+}
+
 // executeWithTrace 带追踪的操作执行包装器
 func executeWithTrace(
 	ctx context.Context,
@@ -36,9 +80,11 @@ func executeWithTrace(
 	index string,
 	documentID string,
 	enableTrace bool,
+	latency *latencyRecorder,
 	handler func(context.Context) error,
 ) error {
 	startTime := time.Now()
+	tagFields, tagAttrs := operationTagFields(ctx)
 
 	// 创建追踪 span
 	var span trace.Span
@@ -50,22 +96,27 @@ func executeWithTrace(
 				attribute.String("db.operation", operation),
 				attribute.String("db.document_id", documentID),
 			),
+			trace.WithAttributes(tagAttrs...),
 		)
 		defer span.End()
 	}
 
 	// 执行操作
-	err := handler(ctx)
+	err := callHandler(ctx, handler)
 	duration := time.Since(startTime)
+	latency.record(operation, index, duration, err)
 
 	// 处理结果
 	if err != nil {
+		stackFields, stackAttrs := panicFields(err)
 		log.FromContext(ctx).Error("Elasticsearch operation failed",
-			zap.String("operation", operation),
-			zap.String("index", index),
-			zap.String("document_id", documentID),
-			zap.Duration("duration", duration),
-			zap.Error(err),
+			append([]zap.Field{
+				zap.String("operation", operation),
+				zap.String("index", index),
+				zap.String("document_id", documentID),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			}, append(tagFields, stackFields...)...)...,
 		)
 
 		// 更新追踪状态
@@ -76,13 +127,16 @@ func executeWithTrace(
 				attribute.String("db.status", "error"),
 				attribute.String("db.error", err.Error()),
 			)
+			span.SetAttributes(stackAttrs...)
 		}
 	} else {
 		log.FromContext(ctx).Info("Elasticsearch operation success",
-			zap.String("operation", operation),
-			zap.String("index", index),
-			zap.String("document_id", documentID),
-			zap.Duration("duration", duration),
+			append([]zap.Field{
+				zap.String("operation", operation),
+				zap.String("index", index),
+				zap.String("document_id", documentID),
+				zap.Duration("duration", duration),
+			}, tagFields...)...,
 		)
 
 		// 更新追踪状态
@@ -104,10 +158,12 @@ func queryWithTrace(
 	operation string,
 	index string,
 	enableTrace bool,
+	latency *latencyRecorder,
 	handler func(context.Context) (map[string]interface{}, error),
 ) (map[string]interface{}, error) {
 	startTime := time.Now()
 	var zero map[string]interface{}
+	tagFields, tagAttrs := operationTagFields(ctx)
 
 	// 创建追踪 span
 	var span trace.Span
@@ -118,21 +174,26 @@ func queryWithTrace(
 				attribute.String("db.name", index),
 				attribute.String("db.operation", operation),
 			),
+			trace.WithAttributes(tagAttrs...),
 		)
 		defer span.End()
 	}
 
 	// 执行操作
-	result, err := handler(ctx)
+	result, err := callQueryHandler(ctx, handler)
 	duration := time.Since(startTime)
+	latency.record(operation, index, duration, err)
 
 	// 处理结果
 	if err != nil {
+		stackFields, stackAttrs := panicFields(err)
 		log.FromContext(ctx).Error("Elasticsearch operation failed",
-			zap.String("operation", operation),
-			zap.String("index", index),
-			zap.Duration("duration", duration),
-			zap.Error(err),
+			append([]zap.Field{
+				zap.String("operation", operation),
+				zap.String("index", index),
+				zap.Duration("duration", duration),
+				zap.Error(err),
+			}, append(tagFields, stackFields...)...)...,
 		)
 
 		// 更新追踪状态
@@ -143,15 +204,18 @@ func queryWithTrace(
 				attribute.String("db.status", "error"),
 				attribute.String("db.error", err.Error()),
 			)
+			span.SetAttributes(stackAttrs...)
 		}
 
 		return zero, err
 	}
 
 	log.FromContext(ctx).Info("Elasticsearch operation success",
-		zap.String("operation", operation),
-		zap.String("index", index),
-		zap.Duration("duration", duration),
+		append([]zap.Field{
+			zap.String("operation", operation),
+			zap.String("index", index),
+			zap.Duration("duration", duration),
+		}, tagFields...)...,
 	)
 
 	// 更新追踪状态
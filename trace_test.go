@@ -0,0 +1,40 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExecuteWithTraceRecoversPanic(t *testing.T) {
+	for _, enableTrace := range []bool{false, true} {
+		err := executeWithTrace(context.Background(), "index", "orders", "1", enableTrace, nil, func(ctx context.Context) error {
+			var m map[string]interface{}
+			m["boom"] = 1
+			return nil
+		})
+
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("executeWithTrace() error = %v, want *PanicError", err)
+		}
+		if !strings.Contains(string(panicErr.Stack), "TestExecuteWithTraceRecoversPanic") {
+			t.Error("PanicError.Stack should contain the panicking frame")
+		}
+	}
+}
+
+func TestQueryWithTraceRecoversPanic(t *testing.T) {
+	result, err := queryWithTrace(context.Background(), "search", "orders", true, nil, func(ctx context.Context) (map[string]interface{}, error) {
+		panic("malformed response")
+	})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "malformed response" {
+		t.Fatalf("queryWithTrace() error = %v, want *PanicError", err)
+	}
+	if result != nil {
+		t.Errorf("queryWithTrace() result = %v, want nil", result)
+	}
+}
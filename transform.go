@@ -0,0 +1,85 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ReadTransformer 读取文档时对 _source 进行转换（如 PII 脱敏、字段级解密），
+// index 为文档所在的具体索引
+type ReadTransformer func(index string, source json.RawMessage) (json.RawMessage, error)
+
+// transformSource 对单个文档（Get 结果或搜索命中）的 _source 应用 ReadTransformer
+func (c *ElasticsearchClient) transformSource(index string, doc map[string]interface{}, useNumber bool) error {
+	source, ok := doc["_source"]
+	if !ok || source == nil {
+		return nil
+	}
+	if name, ok := doc["_index"].(string); ok && name != "" {
+		index = name
+	}
+
+	raw, err := json.Marshal(source)
+	if err != nil {
+		return fmt.Errorf("failed to marshal source: %w", err)
+	}
+	transformed, err := c.readTransformer(index, raw)
+	if err != nil {
+		return fmt.Errorf("failed to transform source: %w", err)
+	}
+
+	var result interface{}
+	decoder := json.NewDecoder(bytes.NewReader(transformed))
+	if useNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode transformed source: %w", err)
+	}
+	doc["_source"] = result
+	return nil
+}
+
+// transformHits 对搜索结果中的所有命中应用 ReadTransformer
+func (c *ElasticsearchClient) transformHits(index string, hits []interface{}, useNumber bool) error {
+	for _, hit := range hits {
+		doc, ok := hit.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := c.transformSource(index, doc, useNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformSearchResult 对搜索响应中的命中应用 ReadTransformer
+func (c *ElasticsearchClient) transformSearchResult(index string, result map[string]interface{}, useNumber bool) error {
+	if c.readTransformer == nil {
+		return nil
+	}
+	hits, ok := result["hits"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	items, _ := hits["hits"].([]interface{})
+	return c.transformHits(index, items, useNumber)
+}
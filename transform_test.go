@@ -0,0 +1,96 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func maskEmail(index string, source json.RawMessage) (json.RawMessage, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(source, &doc); err != nil {
+		return nil, err
+	}
+	if _, ok := doc["email"]; ok {
+		doc["email"] = "***@" + index
+	}
+	return json.Marshal(doc)
+}
+
+func TestReadTransformerGet(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_index":"users-v2","_id":"1","_source":{"name":"alice","email":"alice@example.com"}}`))
+	}, func(opts *Options) {
+		opts.ReadTransformer = maskEmail
+	})
+
+	result, err := client.Get(context.Background(), "users", "1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	source := result["_source"].(map[string]interface{})
+	if source["email"] != "***@users-v2" || source["name"] != "alice" {
+		t.Errorf("Get() _source = %v", source)
+	}
+}
+
+func TestReadTransformerSearch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hits":{"hits":[{"_index":"users","_source":{"email":"a@example.com"}},{"_index":"users","_source":{"email":"b@example.com"}}]}}`))
+	}, func(opts *Options) {
+		opts.ReadTransformer = maskEmail
+	})
+
+	result, err := client.Search(context.Background(), "users", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
+	for i, hit := range hits {
+		source := hit.(map[string]interface{})["_source"].(map[string]interface{})
+		if source["email"] != "***@users" {
+			t.Errorf("hit %d _source = %v", i, source)
+		}
+	}
+}
+
+func TestReadTransformerError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_index":"users","_id":"1","_source":{"secret":"x"}}`))
+	}, func(opts *Options) {
+		opts.ReadTransformer = func(index string, source json.RawMessage) (json.RawMessage, error) {
+			return nil, errors.New("key unavailable")
+		}
+	})
+
+	if _, err := client.Get(context.Background(), "users", "1"); err == nil {
+		t.Error("Get() should return transformer error")
+	}
+}
+
+func TestReadTransformerExport(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/users/_search" {
+			w.Write([]byte(`{"_scroll_id":"s1","hits":{"hits":[{"_index":"users","_source":{"email":"a@example.com"}}]}}`))
+			return
+		}
+		w.Write([]byte(`{"_scroll_id":"s1","hits":{"hits":[]}}`))
+	}, func(opts *Options) {
+		opts.ReadTransformer = maskEmail
+	})
+
+	var buf bytes.Buffer
+	if err := client.Export(context.Background(), "users", map[string]interface{}{}, nil, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if got, want := buf.String(), "{\"email\":\"***@users\"}\n"; got != want {
+		t.Errorf("Export() = %q, want %q", got, want)
+	}
+}
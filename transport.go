@@ -0,0 +1,133 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// newTransport 根据选项构建 HTTP Transport，设置连接超时、响应头超时、写入超时、连接池大小、代理和 TLS。
+// 读取超时只限制等待响应头的时间，不限制读取响应体，以免连接池中的空闲连接因读超时被关闭
+func newTransport(opts *Options) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil || opts.WriteTimeout <= 0 {
+			return conn, err
+		}
+		return &writeDeadlineConn{Conn: conn, timeout: opts.WriteTimeout}, nil
+	}
+	if opts.DialTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.DialTimeout
+	}
+	if opts.ReadTimeout > 0 {
+		transport.ResponseHeaderTimeout = opts.ReadTimeout
+	}
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		if transport.MaxIdleConns < opts.MaxIdleConnsPerHost {
+			transport.MaxIdleConns = opts.MaxIdleConnsPerHost
+		}
+	}
+	if opts.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.DisableHTTP2 {
+		// 非 nil 的空 TLSNextProto 阻止 Transport 通过 ALPN 协商 HTTP/2
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	proxy, err := newProxyFunc(opts)
+	if err != nil {
+		return nil, err
+	}
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+// newTLSConfig 根据选项构建 TLS 配置：加载私有 CA 证书和客户端证书，未启用 TLS 时返回 nil
+func newTLSConfig(opts *Options) (*tls.Config, error) {
+	if !opts.EnableTLS && opts.CACert == "" && opts.ClientCert == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.CACert != "" {
+		pem, err := os.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA cert: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA cert %s: no PEM certificates found", opts.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if opts.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// writeDeadlineConn 在每次写入前设置写超时，避免对端不读取时请求无限期阻塞在发送阶段
+type writeDeadlineConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+// Write 实现 net.Conn
+func (c *writeDeadlineConn) Write(b []byte) (int, error) {
+	if err := c.Conn.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Write(b)
+}
@@ -0,0 +1,129 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport, err := newTransport((&Options{}).Apply(PresetLowLatencySearch))
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+	if transport.ResponseHeaderTimeout != 5*time.Second {
+		t.Errorf("ResponseHeaderTimeout = %s, want 5s", transport.ResponseHeaderTimeout)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.MaxIdleConns < 64 {
+		t.Errorf("MaxIdleConnsPerHost = %d, MaxIdleConns = %d", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.RootCAs != nil {
+		t.Error("TLS config should not be customized when TLS is disabled")
+	}
+}
+
+func TestTLSWithPrivateCA(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Write([]byte(testInfoResponse))
+	}))
+	defer ts.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewElasticsearch(&Options{
+		Addresses:  []string{ts.URL},
+		EnableTLS:  true,
+		CACert:     caPath,
+		MaxRetries: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewElasticsearch() with CA cert error = %v", err)
+	}
+	client.Close()
+
+	if _, err := NewElasticsearch(&Options{
+		Addresses:   []string{ts.URL},
+		EnableTLS:   true,
+		MaxRetries:  1,
+		DialTimeout: time.Second,
+	}); err == nil {
+		t.Error("NewElasticsearch() without CA cert should fail certificate verification")
+	}
+
+	if _, err := NewElasticsearch(&Options{
+		Addresses: []string{ts.URL},
+		CACert:    filepath.Join(t.TempDir(), "missing.crt"),
+	}); err == nil {
+		t.Error("NewElasticsearch() with missing CA cert should return error")
+	}
+}
+
+func TestTransportTimeouts(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	// 接受连接但从不读取请求，也不返回响应
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	transport, err := newTransport(&Options{
+		DialTimeout:  time.Second,
+		ReadTimeout:  100 * time.Millisecond,
+		WriteTimeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+	client := &http.Client{Transport: transport}
+	url := "http://" + ln.Addr().String()
+
+	start := time.Now()
+	if _, err := client.Get(url); err == nil {
+		t.Error("GET should fail with read timeout")
+	}
+	if _, err := client.Post(url, "application/json", bytes.NewReader(make([]byte, 64<<20))); err == nil {
+		t.Error("POST should fail with write timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeouts took %s, want them to be enforced", elapsed)
+	}
+}
+
+func TestTransportConnectionPool(t *testing.T) {
+	transport, err := newTransport(&Options{
+		MaxIdleConns:        20,
+		MaxIdleConnsPerHost: 32,
+		MaxConnsPerHost:     50,
+		IdleConnTimeout:     2 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("newTransport() error = %v", err)
+	}
+	if transport.MaxIdleConns != 32 || transport.MaxIdleConnsPerHost != 32 {
+		t.Errorf("MaxIdleConns = %d, MaxIdleConnsPerHost = %d, want 32 and 32", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 50 || transport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("MaxConnsPerHost = %d, IdleConnTimeout = %s", transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// UpdateResult 单个文档的更新结果，与其他写入操作共用 WriteResult，
+// Result 为 updated、created 或 noop，created 表示文档由 upsert 新建
+type UpdateResult = WriteResult
+
+// UpdateOption 单次更新的选项
+type UpdateOption func(*updateConfig)
+
+// updateConfig 单次更新的配置
+type updateConfig struct {
+	detectNoop      *bool
+	docAsUpsert     bool
+	upsert          interface{}
+	script          map[string]interface{}
+	retryOnConflict *int
+	refresh         RefreshPolicy
+	concurrencyControl
+}
+
+// newUpdateConfig 应用更新选项
+func newUpdateConfig(opts []UpdateOption) *updateConfig {
+	cfg := &updateConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithDetectNoop 设置是否检测空操作，默认开启；关闭后即使内容未变化也会写入新版本
+func WithDetectNoop(detect bool) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.detectNoop = &detect
+	}
+}
+
+// WithDocAsUpsert 文档不存在时以部分文档创建
+func WithDocAsUpsert() UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.docAsUpsert = true
+	}
+}
+
+// WithUpsert 文档不存在时以 upsert 创建，存在时正常执行部分更新或脚本
+func WithUpsert(upsert interface{}) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.upsert = upsert
+	}
+}
+
+// WithScript 使用 painless 脚本更新文档（如 ctx._source.count += params.n），
+// 此时 Update 的 body 参数须为 nil
+func WithScript(source string, params map[string]interface{}) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.script = map[string]interface{}{"source": source, "lang": "painless"}
+		if len(params) > 0 {
+			cfg.script["params"] = params
+		}
+	}
+}
+
+// WithRetryOnConflict 设置版本冲突时的服务端重试次数
+func WithRetryOnConflict(retries int) UpdateOption {
+	return func(cfg *updateConfig) {
+		cfg.retryOnConflict = &retries
+	}
+}
+
+// body 构建更新请求体，doc 为部分文档（脚本更新时为 nil）
+func (cfg *updateConfig) body(doc interface{}) (map[string]interface{}, error) {
+	updateBody := map[string]interface{}{}
+	switch {
+	case cfg.script != nil && doc != nil:
+		return nil, fmt.Errorf("update body and script are mutually exclusive")
+	case cfg.script != nil:
+		if cfg.docAsUpsert {
+			return nil, fmt.Errorf("doc_as_upsert cannot be used with a script")
+		}
+		updateBody["script"] = cfg.script
+	default:
+		// 部分更新需要包装在 doc 字段中
+		docBytes, err := marshalDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		updateBody["doc"] = json.RawMessage(docBytes)
+		if cfg.docAsUpsert {
+			updateBody["doc_as_upsert"] = true
+		}
+	}
+	if cfg.upsert != nil {
+		upsertBytes, err := marshalDocument(cfg.upsert)
+		if err != nil {
+			return nil, err
+		}
+		updateBody["upsert"] = json.RawMessage(upsertBytes)
+	}
+	if cfg.detectNoop != nil {
+		updateBody["detect_noop"] = *cfg.detectNoop
+	}
+	return updateBody, nil
+}
+
+// UpdateManyOptions 批量部分更新选项
+type UpdateManyOptions struct {
+	Upsert          bool // 文档不存在时以部分文档创建（doc_as_upsert）
+	RetryOnConflict int  // 版本冲突时的服务端重试次数
+}
+
+// UpdateItemResult 单个文档的更新结果
+type UpdateItemResult struct {
+	Result string // 操作结果：updated、created、noop
+	Status int    // HTTP 状态码
+	Err    error  // 更新失败时的错误
+}
+
+// UpdateMany 使用一次 Bulk 请求按 ID 批量部分更新文档（自动处理追踪），返回每个 ID 的结果。
+// 单个文档失败不会使整个调用返回错误，需检查各结果的 Err；不会记录历史版本
+func (c *ElasticsearchClient) UpdateMany(ctx context.Context, index string, docs map[string]interface{}, opts *UpdateManyOptions) (map[string]*UpdateItemResult, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var results map[string]*UpdateItemResult
+	err := executeWithTrace(
+		ctx,
+		"update_many",
+		index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			var err error
+			results, err = c.updateMany(ctx, index, docs, opts)
+			return err
+		},
+	)
+	return results, err
+}
+
+// updateMany 内部批量部分更新方法
+func (c *ElasticsearchClient) updateMany(ctx context.Context, index string, docs map[string]interface{}, opts *UpdateManyOptions) (map[string]*UpdateItemResult, error) {
+	if len(docs) == 0 {
+		return map[string]*UpdateItemResult{}, nil
+	}
+	if opts == nil {
+		opts = &UpdateManyOptions{}
+	}
+
+	// 按 ID 排序，保证请求体稳定
+	ids := make([]string, 0, len(docs))
+	for id := range docs {
+		if err := ValidateDocumentID(id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var body strings.Builder
+	for _, id := range ids {
+		meta := map[string]interface{}{"_id": id}
+		if opts.RetryOnConflict > 0 {
+			meta["retry_on_conflict"] = opts.RetryOnConflict
+		}
+		action, err := json.Marshal(map[string]interface{}{"update": meta})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bulk action: %w", err)
+		}
+
+		docBytes, err := marshalDocument(docs[id])
+		if err != nil {
+			return nil, err
+		}
+		update := map[string]interface{}{"doc": json.RawMessage(docBytes)}
+		if opts.Upsert {
+			update["doc_as_upsert"] = true
+		}
+		source, err := json.Marshal(update)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal update body: %w", err)
+		}
+
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(source)
+		body.WriteByte('\n')
+	}
+
+	refresh, err := c.refreshPolicy(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	req := esapi.BulkRequest{
+		Index:   index,
+		Body:    strings.NewReader(body.String()),
+		Refresh: refresh,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, c.requestError("update many", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, c.responseError("update many", res)
+	}
+
+	var response struct {
+		Items []map[string]struct {
+			ID     string          `json:"_id"`
+			Result string          `json:"result"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	results := make(map[string]*UpdateItemResult, len(ids))
+	for _, item := range response.Items {
+		update, ok := item["update"]
+		if !ok {
+			continue
+		}
+		result := &UpdateItemResult{Result: update.Result, Status: update.Status}
+		switch {
+		case update.Status == http.StatusNotFound:
+			result.Err = fmt.Errorf("document %w", ErrNotFound)
+		case len(update.Error) > 0:
+			result.Err = c.itemError("update many", update.Status, update.Error)
+		}
+		results[update.ID] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpdateMany(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/_bulk" {
+			t.Errorf("path = %s, want /orders/_bulk", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"errors":true,"items":[
+			{"update":{"_id":"1","result":"updated","status":200}},
+			{"update":{"_id":"2","result":"noop","status":200}},
+			{"update":{"_id":"3","status":404,"error":{"type":"document_missing_exception","reason":"[3]: document missing"}}},
+			{"update":{"_id":"4","status":409,"error":{"type":"version_conflict_engine_exception","reason":"conflict"}}}
+		]}`))
+	})
+
+	docs := map[string]interface{}{
+		"3": map[string]interface{}{"status": "paid"},
+		"1": map[string]interface{}{"status": "paid"},
+		"2": map[string]interface{}{"status": "paid"},
+		"4": map[string]interface{}{"status": "paid"},
+	}
+	results, err := client.UpdateMany(context.Background(), "orders", docs, &UpdateManyOptions{Upsert: true, RetryOnConflict: 3})
+	if err != nil {
+		t.Fatalf("UpdateMany() error = %v", err)
+	}
+
+	wantBody := `{"update":{"_id":"1","retry_on_conflict":3}}
+{"doc":{"status":"paid"},"doc_as_upsert":true}
+{"update":{"_id":"2","retry_on_conflict":3}}
+{"doc":{"status":"paid"},"doc_as_upsert":true}
+{"update":{"_id":"3","retry_on_conflict":3}}
+{"doc":{"status":"paid"},"doc_as_upsert":true}
+{"update":{"_id":"4","retry_on_conflict":3}}
+{"doc":{"status":"paid"},"doc_as_upsert":true}
+`
+	if got != wantBody {
+		t.Errorf("UpdateMany() body = %s, want %s", got, wantBody)
+	}
+
+	if r := results["1"]; r == nil || r.Result != "updated" || r.Err != nil {
+		t.Errorf("results[1] = %+v", r)
+	}
+	if r := results["2"]; r == nil || r.Result != "noop" || r.Err != nil {
+		t.Errorf("results[2] = %+v", r)
+	}
+	if r := results["3"]; r == nil || !IsNotFound(r.Err) || r.Err.Error() != "document not found" {
+		t.Errorf("results[3] = %+v", r)
+	}
+	if r := results["4"]; r == nil || r.Status != http.StatusConflict || !IsConflict(r.Err) {
+		t.Errorf("results[4] = %+v", r)
+	}
+}
+
+func TestUpdateManyEmpty(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	results, err := client.UpdateMany(context.Background(), "orders", nil, nil)
+	if err != nil || len(results) != 0 {
+		t.Errorf("UpdateMany() = %v, %v", results, err)
+	}
+}
+
+func TestUpdateWithResultNoop(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_index":"orders","_id":"1","_version":4,"result":"noop","_seq_no":9,"_primary_term":1}`))
+	})
+
+	result, err := client.UpdateWithResult(context.Background(), "orders", "1", map[string]interface{}{"status": "paid"})
+	if err != nil {
+		t.Fatalf("UpdateWithResult() error = %v", err)
+	}
+	if !result.Noop() || result.Version != 4 || result.SeqNo != 9 {
+		t.Errorf("UpdateWithResult() = %+v", result)
+	}
+	if got != `{"doc":{"status":"paid"}}` {
+		t.Errorf("body = %s", got)
+	}
+
+	if err := client.Update(context.Background(), "orders", "1", map[string]interface{}{"status": "paid"}, WithDetectNoop(false)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got != `{"detect_noop":false,"doc":{"status":"paid"}}` {
+		t.Errorf("body = %s", got)
+	}
+}
+
+func TestUpdateUpsertAndScript(t *testing.T) {
+	var got, query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_index":"orders","_id":"1","_version":1,"result":"created"}`))
+	})
+	ctx := context.Background()
+
+	if err := client.Update(ctx, "orders", "1", map[string]interface{}{"status": "paid"}, WithDocAsUpsert(), WithRetryOnConflict(2)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got != `{"doc":{"status":"paid"},"doc_as_upsert":true}` {
+		t.Errorf("body = %s", got)
+	}
+	if !strings.Contains(query, "retry_on_conflict=2") {
+		t.Errorf("query = %s", query)
+	}
+
+	result, err := client.UpdateWithResult(ctx, "orders", "1", nil,
+		WithScript("ctx._source.count += params.n", map[string]interface{}{"n": 1}),
+		WithUpsert(map[string]interface{}{"count": 1}))
+	if err != nil || !result.Created() {
+		t.Fatalf("UpdateWithResult() = %+v, %v", result, err)
+	}
+	if got != `{"script":{"lang":"painless","params":{"n":1},"source":"ctx._source.count += params.n"},"upsert":{"count":1}}` {
+		t.Errorf("body = %s", got)
+	}
+
+	if err := client.Update(ctx, "orders", "1", map[string]interface{}{"a": 1}, WithScript("ctx.op = 'noop'", nil)); err == nil {
+		t.Error("Update() with body and script should fail")
+	}
+	if err := client.Update(ctx, "orders", "1", nil, WithScript("ctx.op = 'noop'", nil), WithDocAsUpsert()); err == nil {
+		t.Error("Update() with script and doc_as_upsert should fail")
+	}
+}
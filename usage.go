@@ -0,0 +1,215 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// FieldUsage 字段自跟踪开始（分片分配到节点）以来被查询访问的次数
+type FieldUsage struct {
+	Any           int64 `json:"any"` // 任意数据结构被访问的次数
+	InvertedIndex struct {
+		Terms           int64 `json:"terms"`
+		Postings        int64 `json:"postings"`
+		TermFrequencies int64 `json:"term_frequencies"`
+		Positions       int64 `json:"positions"`
+		Offsets         int64 `json:"offsets"`
+		Payloads        int64 `json:"payloads"`
+		Proximity       int64 `json:"proximity"`
+	} `json:"inverted_index"`
+	StoredFields int64 `json:"stored_fields"`
+	DocValues    int64 `json:"doc_values"`
+	Points       int64 `json:"points"`
+	Norms        int64 `json:"norms"`
+	TermVectors  int64 `json:"term_vectors"`
+	KnnVectors   int64 `json:"knn_vectors"`
+}
+
+// add 累加另一个分片的访问次数
+func (u *FieldUsage) add(other FieldUsage) {
+	u.Any += other.Any
+	u.InvertedIndex.Terms += other.InvertedIndex.Terms
+	u.InvertedIndex.Postings += other.InvertedIndex.Postings
+	u.InvertedIndex.TermFrequencies += other.InvertedIndex.TermFrequencies
+	u.InvertedIndex.Positions += other.InvertedIndex.Positions
+	u.InvertedIndex.Offsets += other.InvertedIndex.Offsets
+	u.InvertedIndex.Payloads += other.InvertedIndex.Payloads
+	u.InvertedIndex.Proximity += other.InvertedIndex.Proximity
+	u.StoredFields += other.StoredFields
+	u.DocValues += other.DocValues
+	u.Points += other.Points
+	u.Norms += other.Norms
+	u.TermVectors += other.TermVectors
+	u.KnnVectors += other.KnnVectors
+}
+
+// FieldUsageStats 返回匹配 index 的所有索引中各字段的访问次数（跨分片累加），键为字段路径。
+// 统计在分片重新分配或节点重启后清零，且不包含从未被访问的字段
+func (c *ElasticsearchClient) FieldUsageStats(ctx context.Context, index string) (map[string]FieldUsage, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var result map[string]json.RawMessage
+	if err := c.resourceRequest(ctx, "field usage stats", index, esapi.IndicesFieldUsageStatsRequest{Index: index}, &result); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]FieldUsage)
+	for name, raw := range result {
+		if name == "_shards" {
+			continue
+		}
+		var stats struct {
+			Shards []struct {
+				Stats struct {
+					Fields map[string]FieldUsage `json:"fields"`
+				} `json:"stats"`
+			} `json:"shards"`
+		}
+		if err := json.Unmarshal(raw, &stats); err != nil {
+			return nil, fmt.Errorf("failed to decode field usage of %s: %w", name, err)
+		}
+		for _, shard := range stats.Shards {
+			for field, fieldUsage := range shard.Stats.Fields {
+				total := usage[field]
+				total.add(fieldUsage)
+				usage[field] = total
+			}
+		}
+	}
+	return usage, nil
+}
+
+// UnusedFields 返回映射中自跟踪开始以来从未被查询访问的字段（含多字段），按路径排序，
+// 可作为映射清理的候选；统计窗口较短时结果可能包含低频使用的字段
+func (c *ElasticsearchClient) UnusedFields(ctx context.Context, index string) ([]string, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	mapping, err := c.GetMapping(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := c.FieldUsageStats(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []string
+	for _, field := range mappingFieldPaths("", mappingProperties(mapping, "properties")) {
+		if usage[field].Any == 0 {
+			unused = append(unused, field)
+		}
+	}
+	sort.Strings(unused)
+	return unused, nil
+}
+
+// mappingFieldPaths 返回映射中所有叶子字段和多字段的路径
+func mappingFieldPaths(prefix string, properties map[string]interface{}) []string {
+	var paths []string
+	for name, def := range properties {
+		field, _ := def.(map[string]interface{})
+		path := prefix + name
+		if sub := mappingProperties(field, "properties"); sub != nil {
+			paths = append(paths, mappingFieldPaths(path+".", sub)...)
+			continue
+		}
+		paths = append(paths, path)
+		paths = append(paths, mappingFieldPaths(path+".", mappingProperties(field, "fields"))...)
+	}
+	return paths
+}
+
+// FieldDiskUsage 字段各数据结构占用的磁盘空间（字节）
+type FieldDiskUsage struct {
+	Field         string `json:"-"`
+	Total         int64  `json:"total_in_bytes"`
+	InvertedIndex struct {
+		Total int64 `json:"total_in_bytes"`
+	} `json:"inverted_index"`
+	StoredFields int64 `json:"stored_fields_in_bytes"`
+	DocValues    int64 `json:"doc_values_in_bytes"`
+	Points       int64 `json:"points_in_bytes"`
+	Norms        int64 `json:"norms_in_bytes"`
+	TermVectors  int64 `json:"term_vectors_in_bytes"`
+	KnnVectors   int64 `json:"knn_vectors_in_bytes"`
+}
+
+// IndexDiskUsage 索引的磁盘占用分析结果
+type IndexDiskUsage struct {
+	Index     string           `json:"-"`
+	StoreSize int64            `json:"store_size_in_bytes"` // 索引（主分片）的总大小
+	AllFields FieldDiskUsage   `json:"all_fields"`          // 所有字段的合计
+	Fields    []FieldDiskUsage `json:"-"`                   // 各字段占用，按总大小降序
+}
+
+// DiskUsage 分析匹配 index 的索引中各字段占用的磁盘空间。
+// 该操作需要读取全部分片数据，对大索引开销较高，应在低峰期执行
+func (c *ElasticsearchClient) DiskUsage(ctx context.Context, index string) (map[string]*IndexDiskUsage, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+
+	index = c.resolveIndex(ctx, index)
+
+	var result map[string]json.RawMessage
+	expensive := true
+	req := esapi.IndicesDiskUsageRequest{Index: index, RunExpensiveTasks: &expensive}
+	if err := c.resourceRequest(ctx, "disk usage", index, req, &result); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]*IndexDiskUsage, len(result))
+	for name, raw := range result {
+		if name == "_shards" {
+			continue
+		}
+		var stats struct {
+			IndexDiskUsage
+			Fields map[string]FieldDiskUsage `json:"fields"`
+		}
+		if err := json.Unmarshal(raw, &stats); err != nil {
+			return nil, fmt.Errorf("failed to decode disk usage of %s: %w", name, err)
+		}
+
+		indexUsage := stats.IndexDiskUsage
+		indexUsage.Index = name
+		for field, fieldUsage := range stats.Fields {
+			fieldUsage.Field = field
+			indexUsage.Fields = append(indexUsage.Fields, fieldUsage)
+		}
+		sort.Slice(indexUsage.Fields, func(i, j int) bool {
+			if indexUsage.Fields[i].Total != indexUsage.Fields[j].Total {
+				return indexUsage.Fields[i].Total > indexUsage.Fields[j].Total
+			}
+			return indexUsage.Fields[i].Field < indexUsage.Fields[j].Field
+		})
+		usage[name] = &indexUsage
+	}
+	return usage, nil
+}
@@ -0,0 +1,83 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFieldUsageStats(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders/_field_usage_stats":
+			w.Write([]byte(`{"_shards":{"total":2,"successful":2,"failed":0},"orders":{"shards":[
+				{"stats":{"all_fields":{"any":3},"fields":{"status":{"any":2,"inverted_index":{"terms":2},"doc_values":1},"title":{"any":1}}}},
+				{"stats":{"all_fields":{"any":1},"fields":{"status":{"any":1,"inverted_index":{"terms":1}},"title.raw":{"any":0}}}}
+			]}}`))
+		case "/orders/_mapping":
+			w.Write([]byte(`{"orders":{"mappings":{"properties":{
+				"status":{"type":"keyword"},
+				"title":{"type":"text","fields":{"raw":{"type":"keyword"}}},
+				"user":{"properties":{"name":{"type":"text"}}}
+			}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	usage, err := client.FieldUsageStats(ctx, "orders")
+	if err != nil {
+		t.Fatalf("FieldUsageStats() error = %v", err)
+	}
+	status := usage["status"]
+	if status.Any != 3 || status.InvertedIndex.Terms != 3 || status.DocValues != 1 {
+		t.Errorf("FieldUsageStats()[status] = %+v", status)
+	}
+
+	unused, err := client.UnusedFields(ctx, "orders")
+	if err != nil {
+		t.Fatalf("UnusedFields() error = %v", err)
+	}
+	if want := []string{"title.raw", "user.name"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("UnusedFields() = %v, want %v", unused, want)
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"_shards":{"total":1,"successful":1,"failed":0},"orders":{
+			"store_size_in_bytes":1000,
+			"all_fields":{"total_in_bytes":900},
+			"fields":{
+				"status":{"total_in_bytes":100,"doc_values_in_bytes":60,"inverted_index":{"total_in_bytes":40}},
+				"body":{"total_in_bytes":700,"stored_fields_in_bytes":500,"inverted_index":{"total_in_bytes":200}},
+				"_id":{"total_in_bytes":100,"stored_fields_in_bytes":100}
+			}}}`))
+	})
+
+	usage, err := client.DiskUsage(context.Background(), "orders")
+	if err != nil {
+		t.Fatalf("DiskUsage() error = %v", err)
+	}
+	if query != "run_expensive_tasks=true" {
+		t.Errorf("DiskUsage() query = %q", query)
+	}
+	orders := usage["orders"]
+	if orders == nil || orders.StoreSize != 1000 || orders.AllFields.Total != 900 {
+		t.Fatalf("DiskUsage() = %+v", orders)
+	}
+	var fields []string
+	for _, field := range orders.Fields {
+		fields = append(fields, field.Field)
+	}
+	if want := []string{"body", "_id", "status"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("DiskUsage() fields = %v, want %v", fields, want)
+	}
+	if orders.Fields[0].InvertedIndex.Total != 200 || orders.Fields[2].DocValues != 60 {
+		t.Errorf("DiskUsage() body = %+v", orders.Fields[0])
+	}
+}
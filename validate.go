@@ -0,0 +1,91 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxIndexNameBytes 索引名称的最大字节数
+const maxIndexNameBytes = 255
+
+// maxDocumentIDBytes 文档 ID 的最大字节数
+const maxDocumentIDBytes = 512
+
+// indexNameForbiddenChars 索引名称中不允许出现的字符
+const indexNameForbiddenChars = "\\/*?\"<>| ,#:"
+
+// ErrInvalidIndexName 索引名称不合法
+var ErrInvalidIndexName = errors.New("invalid index name")
+
+// ErrInvalidDocumentID 文档 ID 不合法
+var ErrInvalidDocumentID = errors.New("invalid document ID")
+
+// ValidateIndexName 在客户端按服务端规则校验索引（或别名）名称：
+// 只能为小写，不能包含 \ / * ? " < > | 空格 , # :，不能以 - _ + 开头，不能为 . 或 ..，最长 255 字节。
+// 日期数学表达式（如 <logs-{now/d}>）只校验其中的固定部分
+func ValidateIndexName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidIndexName)
+	}
+	if len(name) > maxIndexNameBytes {
+		return fmt.Errorf("%w: %q exceeds %d bytes", ErrInvalidIndexName, name, maxIndexNameBytes)
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidIndexName, name)
+	}
+
+	static := name
+	if isDateMathIndex(name) {
+		static = name[1 : len(name)-1]
+		if i := strings.Index(static, "{"); i >= 0 {
+			static = static[:i]
+		}
+	}
+	if strings.ContainsAny(static[:min(1, len(static))], "-_+") {
+		return fmt.Errorf("%w: %q cannot start with '-', '_' or '+'", ErrInvalidIndexName, name)
+	}
+	if static != strings.ToLower(static) {
+		return fmt.Errorf("%w: %q must be lowercase", ErrInvalidIndexName, name)
+	}
+	if i := strings.IndexAny(static, indexNameForbiddenChars); i >= 0 {
+		return fmt.Errorf("%w: %q contains forbidden character %q", ErrInvalidIndexName, name, static[i])
+	}
+	return nil
+}
+
+// ValidateDocumentID 校验文档 ID：不能为空，最长 512 字节
+func ValidateDocumentID(documentID string) error {
+	if documentID == "" {
+		return fmt.Errorf("%w: ID cannot be empty", ErrInvalidDocumentID)
+	}
+	if len(documentID) > maxDocumentIDBytes {
+		return fmt.Errorf("%w: ID exceeds %d bytes", ErrInvalidDocumentID, maxDocumentIDBytes)
+	}
+	return nil
+}
+
+// documentIDPath 校验文档 ID 并编码为路径段，使包含 /、空格或非 ASCII 字符的 ID 能被正确传递
+func documentIDPath(documentID string) (string, error) {
+	if err := ValidateDocumentID(documentID); err != nil {
+		return "", err
+	}
+	return url.PathEscape(documentID), nil
+}
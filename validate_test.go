@@ -0,0 +1,101 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateIndexName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"orders", false},
+		{"orders-2025.01", false},
+		{".internal", false},
+		{"<logs-{now/d}>", false},
+		{"<logs-{now/d{yyyy.MM.dd|+08:00}}>", false},
+		{"", true},
+		{"Orders", true},
+		{"_orders", true},
+		{"-orders", true},
+		{"orders/v1", true},
+		{"orders v1", true},
+		{"orders,users", true},
+		{"orders*", true},
+		{"..", true},
+		{"<Logs-{now/d}>", true},
+		{strings.Repeat("a", 256), true},
+	}
+	for _, tt := range tests {
+		err := ValidateIndexName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateIndexName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidIndexName) {
+			t.Errorf("ValidateIndexName(%q) error = %v, want ErrInvalidIndexName", tt.name, err)
+		}
+	}
+}
+
+func TestValidateDocumentID(t *testing.T) {
+	if err := ValidateDocumentID(""); !errors.Is(err, ErrInvalidDocumentID) {
+		t.Errorf("ValidateDocumentID(\"\") error = %v", err)
+	}
+	if err := ValidateDocumentID(strings.Repeat("x", 513)); !errors.Is(err, ErrInvalidDocumentID) {
+		t.Errorf("ValidateDocumentID(long) error = %v", err)
+	}
+	if err := ValidateDocumentID("a/b c"); err != nil {
+		t.Errorf("ValidateDocumentID() error = %v", err)
+	}
+}
+
+func TestDocumentIDEncoding(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"_id":"x","found":true,"_source":{}}`))
+	})
+
+	ctx := context.Background()
+	if err := client.Index(ctx, "files", "dir/a b.txt", map[string]interface{}{}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if _, err := client.Get(ctx, "files", "用户/1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := client.Delete(ctx, "files", "a?b#c"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	want := []string{
+		"PUT /files/_doc/dir%2Fa%20b.txt",
+		"GET /files/_doc/%E7%94%A8%E6%88%B7%2F1",
+		"DELETE /files/_doc/a%3Fb%23c",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestCreateIndexInvalidName(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if err := client.CreateIndex(context.Background(), "Orders", nil); !errors.Is(err, ErrInvalidIndexName) {
+		t.Errorf("CreateIndex() error = %v, want ErrInvalidIndexName", err)
+	}
+	if _, err := client.Get(context.Background(), "orders", ""); !errors.Is(err, ErrInvalidDocumentID) {
+		t.Errorf("Get() error = %v, want ErrInvalidDocumentID", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// WarmUpOption 索引预热的选项
+type WarmUpOption func(*warmUpConfig)
+
+// warmUpConfig 单次预热的配置
+type warmUpConfig struct {
+	requestCache *bool
+}
+
+// newWarmUpConfig 应用预热选项
+func newWarmUpConfig(opts []WarmUpOption) *warmUpConfig {
+	cfg := &warmUpConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithRequestCache 预热查询携带 request_cache=true，使 size 为 0 的聚合结果进入分片请求缓存
+func WithRequestCache() WarmUpOption {
+	return func(cfg *warmUpConfig) {
+		enabled := true
+		cfg.requestCache = &enabled
+	}
+}
+
+// WarmUp 在新恢复或重建的索引上依次执行具有代表性的查询，用于蓝绿发布切换别名前
+// 预热文件系统缓存和查询缓存，避免切换后的冷启动延迟。
+// 单个查询失败不会中断后续查询，所有错误合并后返回
+func (c *ElasticsearchClient) WarmUp(ctx context.Context, index string, queries []map[string]interface{}, opts ...WarmUpOption) error {
+	if err := c.ready(); err != nil {
+		return err
+	}
+
+	index = c.resolveIndex(ctx, index)
+	cfg := newWarmUpConfig(opts)
+
+	return executeWithTrace(
+		ctx,
+		"warm_up",
+		index,
+		"",
+		c.EnableTrace,
+		c.latency,
+		func(ctx context.Context) error {
+			return c.warmUp(ctx, index, queries, cfg)
+		},
+	)
+}
+
+// warmUp 内部索引预热方法
+func (c *ElasticsearchClient) warmUp(ctx context.Context, index string, queries []map[string]interface{}, cfg *warmUpConfig) error {
+	var errs []error
+	for i, query := range queries {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		_, err := c.executeQueryRequest(ctx, index, query, func(indices []string, body *strings.Reader) esapi.Request {
+			return esapi.SearchRequest{
+				Index:        indices,
+				Body:         body,
+				RequestCache: cfg.requestCache,
+			}
+		}, "warm up", false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("warm up query %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,39 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWarmUp(t *testing.T) {
+	var calls, cached int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/orders-v2/_search") {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		calls++
+		if r.URL.Query().Get("request_cache") == "true" {
+			cached++
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"type":"parsing_exception"},"status":400}`))
+			return
+		}
+		w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+	})
+
+	queries := []map[string]interface{}{
+		{"query": map[string]interface{}{"bogus": map[string]interface{}{}}},
+		{"size": 0, "aggs": map[string]interface{}{"by_status": map[string]interface{}{"terms": map[string]interface{}{"field": "status"}}}},
+	}
+	err := client.WarmUp(context.Background(), "orders-v2", queries, WithRequestCache())
+	if err == nil || !strings.Contains(err.Error(), "warm up query 0") {
+		t.Fatalf("WarmUp() error = %v, want query 0 failure", err)
+	}
+	if calls != 2 || cached != 2 {
+		t.Errorf("calls = %d, cached = %d, want 2 and 2", calls, cached)
+	}
+}